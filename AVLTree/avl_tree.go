@@ -0,0 +1,248 @@
+package AVLTree
+
+import "sync"
+
+// AVLTree is a thread-safe ordered set kept height-balanced as an AVL tree: the heights of the two subtrees
+// of every node differ by at most one, so the tree is never taller than about 1.44·log2(n). Lookups, insertions
+// and removals take O(log n) time. Reads take a shared lock; writes take an exclusive lock.
+type AVLTree[T any] struct {
+	mu   sync.RWMutex     // guards all fields below
+	root *node[T]         // root of the tree, nil if empty
+	cmp  func(a, b T) int // element order
+	size int              // number of elements
+}
+
+// node is an AVL tree node. height is 1 for a leaf.
+type node[T any] struct {
+	val         T
+	left, right *node[T]
+	height      int
+}
+
+// NewAVLTree creates an empty set ordered by cmp, which returns a negative number when a < b,
+// zero when a == b and a positive number when a > b.
+func NewAVLTree[T any](cmp func(a, b T) int) *AVLTree[T] {
+	return &AVLTree[T]{cmp: cmp}
+}
+
+// height returns the height of n, 0 for an empty subtree.
+func height[T any](n *node[T]) int {
+	if n == nil {
+		return 0
+	}
+	return n.height
+}
+
+// balanceFactor returns the height of n's left subtree minus the height of its right subtree.
+func balanceFactor[T any](n *node[T]) int {
+	return height(n.left) - height(n.right)
+}
+
+// update recomputes n's height from its children.
+func update[T any](n *node[T]) {
+	n.height = 1 + max(height(n.left), height(n.right))
+}
+
+// rotateRight lifts n's left child above n and returns the new subtree root.
+func rotateRight[T any](n *node[T]) *node[T] {
+	l := n.left
+	n.left = l.right
+	l.right = n
+	update(n)
+	update(l)
+	return l
+}
+
+// rotateLeft lifts n's right child above n and returns the new subtree root.
+func rotateLeft[T any](n *node[T]) *node[T] {
+	r := n.right
+	n.right = r.left
+	r.left = n
+	update(n)
+	update(r)
+	return r
+}
+
+// rebalance restores the AVL property at n, whose subtrees are balanced and differ in height by at most two,
+// and returns the new subtree root.
+func rebalance[T any](n *node[T]) *node[T] {
+	update(n)
+	switch bf := balanceFactor(n); {
+	case bf > 1:
+		if balanceFactor(n.left) < 0 {
+			// Left-right case: turn it into the left-left case first
+			n.left = rotateLeft(n.left)
+		}
+		return rotateRight(n)
+	case bf < -1:
+		if balanceFactor(n.right) > 0 {
+			// Right-left case: turn it into the right-right case first
+			n.right = rotateRight(n.right)
+		}
+		return rotateLeft(n)
+	}
+	return n
+}
+
+// Insert adds val to the set. Inserting a value already present has no effect.
+func (t *AVLTree[T]) Insert(val T) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.root = t.insert(t.root, val)
+}
+
+// insert adds val to the subtree rooted at n and returns its new root (must be called with lock held).
+func (t *AVLTree[T]) insert(n *node[T], val T) *node[T] {
+	if n == nil {
+		t.size++
+		return &node[T]{val: val, height: 1}
+	}
+	switch c := t.cmp(val, n.val); {
+	case c < 0:
+		n.left = t.insert(n.left, val)
+	case c > 0:
+		n.right = t.insert(n.right, val)
+	default:
+		return n
+	}
+	return rebalance(n)
+}
+
+// Remove removes val from the set. Returns true if it was present.
+func (t *AVLTree[T]) Remove(val T) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	size := t.size
+	t.root = t.remove(t.root, val)
+	return t.size < size
+}
+
+// remove removes val from the subtree rooted at n and returns its new root (must be called with lock held).
+func (t *AVLTree[T]) remove(n *node[T], val T) *node[T] {
+	if n == nil {
+		return nil
+	}
+	switch c := t.cmp(val, n.val); {
+	case c < 0:
+		n.left = t.remove(n.left, val)
+	case c > 0:
+		n.right = t.remove(n.right, val)
+	default:
+		if n.left == nil || n.right == nil {
+			t.size--
+			if n.left != nil {
+				return n.left
+			}
+			return n.right
+		}
+		// Replace the value with its in-order successor and remove that from the right subtree
+		succ := n.right
+		for succ.left != nil {
+			succ = succ.left
+		}
+		n.val = succ.val
+		n.right = t.remove(n.right, succ.val)
+	}
+	return rebalance(n)
+}
+
+// Contains reports whether val is in the set.
+func (t *AVLTree[T]) Contains(val T) bool {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	for n := t.root; n != nil; {
+		switch c := t.cmp(val, n.val); {
+		case c < 0:
+			n = n.left
+		case c > 0:
+			n = n.right
+		default:
+			return true
+		}
+	}
+	return false
+}
+
+// Min returns the smallest element.
+func (t *AVLTree[T]) Min() (T, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	var zero T
+	if t.root == nil {
+		return zero, false
+	}
+	n := t.root
+	for n.left != nil {
+		n = n.left
+	}
+	return n.val, true
+}
+
+// Max returns the largest element.
+func (t *AVLTree[T]) Max() (T, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	var zero T
+	if t.root == nil {
+		return zero, false
+	}
+	n := t.root
+	for n.right != nil {
+		n = n.right
+	}
+	return n.val, true
+}
+
+// Len returns the number of elements in the set.
+func (t *AVLTree[T]) Len() int {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.size
+}
+
+// InOrder returns the elements in ascending order.
+func (t *AVLTree[T]) InOrder() []T {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	out := make([]T, 0, t.size)
+	var walk func(n *node[T])
+	walk = func(n *node[T]) {
+		if n == nil {
+			return
+		}
+		walk(n.left)
+		out = append(out, n.val)
+		walk(n.right)
+	}
+	walk(t.root)
+	return out
+}
+
+// Clear removes all elements.
+func (t *AVLTree[T]) Clear() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.root = nil
+	t.size = 0
+}
+
+// Copy creates a new independent set holding the same elements with the same comparator.
+// The tree structure is copied node by node, so no rebalancing is needed.
+func (t *AVLTree[T]) Copy() *AVLTree[T] {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	var clone func(n *node[T]) *node[T]
+	clone = func(n *node[T]) *node[T] {
+		if n == nil {
+			return nil
+		}
+		return &node[T]{val: n.val, left: clone(n.left), right: clone(n.right), height: n.height}
+	}
+	return &AVLTree[T]{root: clone(t.root), cmp: t.cmp, size: t.size}
+}
@@ -0,0 +1,143 @@
+package AVLTree
+
+import (
+	"cmp"
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+// checkInvariants verifies the search order, the cached heights, the AVL balance rule and the cached size,
+// and returns a description of the first violation found, or "".
+func (t *AVLTree[T]) checkInvariants() string {
+	count := 0
+	var walk func(n *node[T]) string
+	walk = func(n *node[T]) string {
+		if n == nil {
+			return ""
+		}
+		count++
+		if n.left != nil && t.cmp(n.left.val, n.val) >= 0 {
+			return "left child not smaller"
+		}
+		if n.right != nil && t.cmp(n.right.val, n.val) <= 0 {
+			return "right child not larger"
+		}
+		if n.height != 1+max(height(n.left), height(n.right)) {
+			return "stale height"
+		}
+		if bf := balanceFactor(n); bf < -1 || bf > 1 {
+			return fmt.Sprintf("balance factor %d", bf)
+		}
+		if problem := walk(n.left); problem != "" {
+			return problem
+		}
+		return walk(n.right)
+	}
+	if problem := walk(t.root); problem != "" {
+		return problem
+	}
+	if count != t.size {
+		return "size does not match the node count"
+	}
+	return ""
+}
+
+// shape renders the tree as nested parentheses, e.g. "(1 2 3)" for root 2 with children 1 and 3.
+func (t *AVLTree[T]) shape() string {
+	var render func(n *node[T]) string
+	render = func(n *node[T]) string {
+		if n == nil {
+			return "-"
+		}
+		if n.left == nil && n.right == nil {
+			return fmt.Sprint(n.val)
+		}
+		return fmt.Sprintf("(%s %v %s)", render(n.left), n.val, render(n.right))
+	}
+	return render(t.root)
+}
+
+func TestRotationCases(t *testing.T) {
+	cases := []struct {
+		name   string
+		insert []int
+	}{
+		{"LL", []int{3, 2, 1}},
+		{"RR", []int{1, 2, 3}},
+		{"LR", []int{3, 1, 2}},
+		{"RL", []int{1, 3, 2}},
+	}
+	for _, c := range cases {
+		tree := NewAVLTree[int](cmp.Compare[int])
+		for _, v := range c.insert {
+			tree.Insert(v)
+		}
+		if s := tree.shape(); s != "(1 2 3)" {
+			t.Errorf("%s: expected (1 2 3) after rebalancing, got %s", c.name, s)
+		}
+	}
+
+	// Removals trigger the same four cases
+	removals := []struct {
+		name   string
+		insert []int
+		remove int
+		want   string
+	}{
+		{"LL", []int{3, 2, 4, 1}, 4, "(1 2 3)"},
+		{"RR", []int{2, 1, 3, 4}, 1, "(2 3 4)"},
+		{"LR", []int{3, 1, 4, 2}, 4, "(1 2 3)"},
+		{"RL", []int{2, 1, 4, 3}, 1, "(2 3 4)"},
+	}
+	for _, c := range removals {
+		tree := NewAVLTree[int](cmp.Compare[int])
+		for _, v := range c.insert {
+			tree.Insert(v)
+		}
+		tree.Remove(c.remove)
+		if s := tree.shape(); s != c.want {
+			t.Errorf("%s removal: expected %s, got %s", c.name, c.want, s)
+		}
+	}
+}
+
+func TestInvariantsAfterEveryMutation(t *testing.T) {
+	for _, seed := range []int64{1, 2, 3} {
+		r := rand.New(rand.NewSource(seed))
+		tree := NewAVLTree[int](cmp.Compare[int])
+		ref := make(map[int]bool)
+		for i := 0; i < 5000; i++ {
+			v := r.Intn(500)
+			if r.Intn(3) == 0 {
+				if tree.Remove(v) != ref[v] {
+					t.Fatalf("Seed %d step %d: Remove(%d) disagrees with the reference", seed, i, v)
+				}
+				delete(ref, v)
+			} else {
+				tree.Insert(v)
+				ref[v] = true
+			}
+			if problem := tree.checkInvariants(); problem != "" {
+				t.Fatalf("Seed %d step %d: %s", seed, i, problem)
+			}
+		}
+		if tree.Len() != len(ref) {
+			t.Fatalf("Seed %d: expected %d elements, got %d", seed, len(ref), tree.Len())
+		}
+	}
+}
+
+func TestHeightStaysLogarithmic(t *testing.T) {
+	tree := NewAVLTree[int](cmp.Compare[int])
+	for i := 0; i < 1<<12; i++ {
+		tree.Insert(i)
+	}
+	// 1.44·log2(4096) ≈ 17.3
+	if h := height(tree.root); h > 17 {
+		t.Errorf("Sequential inserts produced height %d", h)
+	}
+	if problem := tree.checkInvariants(); problem != "" {
+		t.Error(problem)
+	}
+}
@@ -0,0 +1,102 @@
+package BlockingQueue
+
+import (
+	"context"
+	"fmt"
+
+	"GoSTL/Deque"
+)
+
+// BlockingQueue is a bounded, thread-safe FIFO queue for producer-consumer pipelines.
+// Push blocks while the queue is full and Pop blocks while it is empty, both until the operation can proceed
+// or their context is done. The elements live in a Deque; two semaphores, implemented as buffered channels,
+// count the free slots and the available elements so that waiting never spins and respects cancellation.
+type BlockingQueue[T any] struct {
+	d     *Deque.Deque[T] // stored elements, bounded at capacity
+	slots chan struct{}   // holds one token per occupied slot; sending acquires a slot
+	items chan struct{}   // holds one token per element ready to be popped
+}
+
+// NewBlockingQueue creates an empty queue that holds at most capacity elements.
+// Panics if capacity is not positive.
+func NewBlockingQueue[T any](capacity int) *BlockingQueue[T] {
+	if capacity <= 0 {
+		panic(fmt.Sprintf("BlockingQueue: invalid capacity %d", capacity))
+	}
+	return &BlockingQueue[T]{
+		d:     Deque.NewDequeWithData[T](nil, Deque.WithCapacity(min(capacity, 64)), Deque.WithMaxCapacity(capacity)),
+		slots: make(chan struct{}, capacity),
+		items: make(chan struct{}, capacity),
+	}
+}
+
+// Push adds val to the back of the queue, blocking while the queue is full.
+// Returns ctx.Err() without pushing if ctx is done before a slot becomes free.
+func (q *BlockingQueue[T]) Push(ctx context.Context, val T) error {
+	select {
+	case q.slots <- struct{}{}:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	q.push(val)
+	return nil
+}
+
+// TryPush adds val to the back of the queue if a slot is free, without blocking.
+// Returns false if the queue is full.
+func (q *BlockingQueue[T]) TryPush(val T) bool {
+	select {
+	case q.slots <- struct{}{}:
+	default:
+		return false
+	}
+	q.push(val)
+	return true
+}
+
+// push stores val and publishes it to poppers (the caller must hold a slot token).
+func (q *BlockingQueue[T]) push(val T) {
+	q.d.PushBack(val)
+	q.items <- struct{}{}
+}
+
+// Pop removes and returns the front element, blocking while the queue is empty.
+// Returns the zero value and ctx.Err() if ctx is done before an element becomes available.
+func (q *BlockingQueue[T]) Pop(ctx context.Context) (T, error) {
+	select {
+	case <-q.items:
+	case <-ctx.Done():
+		var zero T
+		return zero, ctx.Err()
+	}
+	return q.pop(), nil
+}
+
+// TryPop removes and returns the front element if there is one, without blocking.
+// Returns false if the queue is empty.
+func (q *BlockingQueue[T]) TryPop() (T, bool) {
+	select {
+	case <-q.items:
+	default:
+		var zero T
+		return zero, false
+	}
+	return q.pop(), true
+}
+
+// pop removes the front element and frees its slot (the caller must hold an item token).
+func (q *BlockingQueue[T]) pop() T {
+	val, _ := q.d.PopFront()
+	<-q.slots
+	return val
+}
+
+// Len returns the number of elements ready to be popped.
+func (q *BlockingQueue[T]) Len() int {
+	return len(q.items)
+}
+
+// Cap returns the maximum number of elements the queue holds.
+func (q *BlockingQueue[T]) Cap() int {
+	return cap(q.slots)
+}
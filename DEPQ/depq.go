@@ -0,0 +1,211 @@
+package DEPQ
+
+import "sync"
+
+// DEPQ is a thread-safe double-ended priority queue supporting O(log n) extraction of both the minimum
+// and the maximum element. It is implemented as an interval heap: node i holds the pair data[2i] <= data[2i+1],
+// the even slots form a min-heap, the odd slots form a max-heap, and every node's interval lies within
+// its parent's. The last node may hold a single element, which then acts as both its minimum and maximum.
+type DEPQ[T any] struct {
+	data []T               // interval heap, two slots per node
+	less func(a, b T) bool // ordering
+	mu   sync.Mutex        // guards data
+}
+
+// NewDEPQ creates an empty double-ended priority queue ordered by less.
+func NewDEPQ[T any](less func(a, b T) bool) *DEPQ[T] {
+	return &DEPQ[T]{data: make([]T, 0, 8), less: less}
+}
+
+// parentNode returns the index of the parent of the node holding slot k; k must not be in the root node.
+func parentNode(k int) int {
+	return (k/2 - 1) / 2
+}
+
+// Push adds val in O(log n).
+func (q *DEPQ[T]) Push(val T) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.data = append(q.data, val)
+	k := len(q.data) - 1
+	if k%2 == 1 {
+		// val completes a node: keep the pair ordered, then restore the heap it belongs to
+		if q.less(val, q.data[k-1]) {
+			q.data[k-1], q.data[k] = q.data[k], q.data[k-1]
+			q.upMin(k - 1)
+		} else {
+			q.upMax(k)
+		}
+		return
+	}
+	if k == 0 {
+		return
+	}
+	// val starts a new node on its own and may fall outside its parent's interval on either side
+	p := parentNode(k)
+	if q.less(val, q.data[2*p]) {
+		q.upMin(k)
+	} else if q.less(q.data[2*p+1], val) {
+		q.upMax(k)
+	}
+}
+
+// upMin moves the element at slot k up the min-heap.
+func (q *DEPQ[T]) upMin(k int) {
+	for k > 1 {
+		pk := 2 * parentNode(k)
+		if !q.less(q.data[k], q.data[pk]) {
+			return
+		}
+		q.data[k], q.data[pk] = q.data[pk], q.data[k]
+		k = pk
+	}
+}
+
+// upMax moves the element at slot k up the max-heap.
+func (q *DEPQ[T]) upMax(k int) {
+	for k > 1 {
+		pk := 2*parentNode(k) + 1
+		if !q.less(q.data[pk], q.data[k]) {
+			return
+		}
+		q.data[k], q.data[pk] = q.data[pk], q.data[k]
+		k = pk
+	}
+}
+
+// PopMin removes and returns the smallest element in O(log n). Returns false if the queue is empty.
+func (q *DEPQ[T]) PopMin() (T, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	var zero T
+	if len(q.data) == 0 {
+		return zero, false
+	}
+	result := q.data[0]
+	q.removeSlot(0)
+	if len(q.data) == 0 {
+		return result, true
+	}
+
+	// Trickle the moved element down the min-heap
+	n := len(q.data)
+	k := 0
+	for {
+		if k+1 < n && q.less(q.data[k+1], q.data[k]) {
+			q.data[k], q.data[k+1] = q.data[k+1], q.data[k]
+		}
+		c := 2 * (k + 1) // min slot of the first child node
+		if c >= n {
+			return result, true
+		}
+		m := c
+		if c+2 < n && q.less(q.data[c+2], q.data[m]) {
+			m = c + 2
+		}
+		if !q.less(q.data[m], q.data[k]) {
+			return result, true
+		}
+		q.data[k], q.data[m] = q.data[m], q.data[k]
+		k = m
+	}
+}
+
+// PopMax removes and returns the largest element in O(log n). Returns false if the queue is empty.
+func (q *DEPQ[T]) PopMax() (T, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	var zero T
+	switch len(q.data) {
+	case 0:
+		return zero, false
+	case 1:
+		result := q.data[0]
+		q.removeSlot(0)
+		return result, true
+	}
+	result := q.data[1]
+	q.removeSlot(1)
+	n := len(q.data)
+	if n <= 2 {
+		return result, true
+	}
+
+	// Trickle the moved element down the max-heap
+	k := 1
+	for {
+		if k%2 == 1 && q.less(q.data[k], q.data[k-1]) {
+			q.data[k], q.data[k-1] = q.data[k-1], q.data[k]
+		}
+		node := k / 2
+		c := 2*node + 1 // first child node
+		if 2*c >= n {
+			return result, true
+		}
+		m := maxSlot(c, n)
+		if 2*(c+1) < n {
+			if m2 := maxSlot(c+1, n); q.less(q.data[m], q.data[m2]) {
+				m = m2
+			}
+		}
+		if !q.less(q.data[k], q.data[m]) {
+			return result, true
+		}
+		q.data[k], q.data[m] = q.data[m], q.data[k]
+		k = m
+	}
+}
+
+// maxSlot returns the slot holding the maximum of node i in a heap of n elements.
+func maxSlot(i, n int) int {
+	if 2*i+1 < n {
+		return 2*i + 1
+	}
+	return 2 * i
+}
+
+// removeSlot overwrites slot k with the last element and shrinks the heap by one, zeroing the vacated slot.
+func (q *DEPQ[T]) removeSlot(k int) {
+	var zero T
+	last := len(q.data) - 1
+	q.data[k] = q.data[last]
+	q.data[last] = zero
+	q.data = q.data[:last]
+}
+
+// PeekMin returns the smallest element without removing it. Returns false if the queue is empty.
+func (q *DEPQ[T]) PeekMin() (T, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	var zero T
+	if len(q.data) == 0 {
+		return zero, false
+	}
+	return q.data[0], true
+}
+
+// PeekMax returns the largest element without removing it. Returns false if the queue is empty.
+func (q *DEPQ[T]) PeekMax() (T, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	var zero T
+	switch len(q.data) {
+	case 0:
+		return zero, false
+	case 1:
+		return q.data[0], true
+	}
+	return q.data[1], true
+}
+
+// Len returns the number of elements in the queue.
+func (q *DEPQ[T]) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return len(q.data)
+}
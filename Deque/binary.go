@@ -0,0 +1,75 @@
+package Deque
+
+import (
+	"fmt"
+	"sync/atomic"
+	"unsafe"
+
+	"GoSTL/internal/codec"
+)
+
+// MarshalBinary implements encoding.BinaryMarshaler using the binary format shared by the GoSTL containers:
+// a fixed header (version byte, int64 length, int64 initial capacity, uint64 element type hash)
+// followed by the elements in front-to-back order. Booleans, integers, floats and complex numbers are written
+// at a fixed width; strings and slices are length-prefixed; arrays and structs are written field by field.
+// Elements involving pointers, maps, interfaces or unexported struct fields are written with encoding/gob and
+// follow its rules: unexported fields are skipped, nil pointer elements cannot be encoded and concrete types
+// stored in interfaces must be registered with gob.Register. Channels and functions are not supported.
+func (q *Deque[T]) MarshalBinary() ([]byte, error) {
+	q.checkNil()
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	header := (*sliceHeader)(atomic.LoadPointer(&q.data))
+	front := int(atomic.LoadInt32(&q.front))
+	data := (*[1 << 30]T)(header.data)[:header.cap]
+	b, err := codec.Encode(int(atomic.LoadInt32(&q.length)), q.initCap, func(i int) T {
+		return data[(front+i)%header.cap]
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Deque: %w", err)
+	}
+	return b, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+// It replaces the contents of the deque with the elements encoded by MarshalBinary and restores the initial capacity.
+// The deque is left unchanged if the payload is malformed, was produced for a different element type or holds more
+// elements than the maximum capacity set by WithMaxCapacity; the restored capacity never exceeds that limit.
+func (q *Deque[T]) UnmarshalBinary(b []byte) error {
+	q.checkNil()
+	elems, initCap, err := codec.Decode[T](b, q.maxCap)
+	if err != nil {
+		return fmt.Errorf("Deque: %w", err)
+	}
+	length := len(elems)
+	data := elems[:cap(elems)]
+	if len(data) == 0 {
+		// Empty payload without a capacity hint: fall back to the default capacity of 8
+		capacity := 8
+		if q.maxCap > 0 {
+			capacity = min(capacity, q.maxCap)
+		}
+		data = make([]T, capacity)
+	}
+	capacity := len(data)
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.initCap = capacity
+	if initCap > 0 {
+		q.initCap = initCap
+	}
+	if q.maxCap > 0 && q.initCap > q.maxCap {
+		q.initCap = q.maxCap
+	}
+	header := (*sliceHeader)(unsafe.Pointer(&data))
+	atomic.StorePointer(&q.data, unsafe.Pointer(header))
+	atomic.StoreInt32(&q.front, 0)
+	atomic.StoreInt32(&q.back, int32(length%capacity))
+	atomic.StoreInt32(&q.length, int32(length))
+	q.notifyLocked()
+	q.shared.Store(false)
+	q.observeLen(int32(length))
+	return nil
+}
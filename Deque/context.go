@@ -0,0 +1,73 @@
+package Deque
+
+import "context"
+
+// waitChanLocked returns a channel that is closed at the next change of the deque's length
+// (must be called with lock held).
+func (q *Deque[T]) waitChanLocked() <-chan struct{} {
+	if q.changed == nil {
+		q.changed = make(chan struct{})
+	}
+	return q.changed
+}
+
+// notifyLocked wakes every goroutine waiting in a context-aware operation (must be called with lock held
+// after any change of length). It costs a nil check when nobody waits.
+func (q *Deque[T]) notifyLocked() {
+	if q.changed != nil {
+		close(q.changed)
+		q.changed = nil
+	}
+}
+
+// PushBackWithContext adds an element to the back of the deque, blocking while the deque is full at the
+// maximum capacity set by WithMaxCapacity until another goroutine frees space or ctx is done.
+// On an unbounded deque it never blocks, but like the bounded case it pushes nothing and returns ctx.Err()
+// if ctx is already done. Waiters are woken by every operation that changes the deque's length.
+func (q *Deque[T]) PushBackWithContext(ctx context.Context, val T) error {
+	q.checkNil()
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		q.mu.Lock()
+		if q.pushBackLocked(val) {
+			q.mu.Unlock()
+			return nil
+		}
+		changed := q.waitChanLocked()
+		q.mu.Unlock()
+
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// PopFrontWithContext removes and returns the element at the front of the deque, blocking while the deque
+// is empty until another goroutine pushes an element or ctx is done, in which case it returns ctx.Err().
+// Nothing is popped if ctx is already done. Waiters are woken as in PushBackWithContext.
+func (q *Deque[T]) PopFrontWithContext(ctx context.Context) (T, error) {
+	q.checkNil()
+	var zero T
+	for {
+		if err := ctx.Err(); err != nil {
+			return zero, err
+		}
+		q.mu.Lock()
+		if val, ok := q.popFrontLocked(); ok {
+			q.mu.Unlock()
+			return val, nil
+		}
+		changed := q.waitChanLocked()
+		q.mu.Unlock()
+
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return zero, ctx.Err()
+		}
+	}
+}
@@ -3,6 +3,7 @@ package Deque
 import (
 	"fmt"
 	"io"
+	"iter"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -15,8 +16,44 @@ type Deque[T any] struct {
 	front   int32          // atomic access
 	back    int32          // atomic access
 	length  int32          // atomic access
-	mu      sync.Mutex     // only for resize operations
+	mu      sync.Mutex     // guards every write to the backing array and the ring indices
 	initCap int            // initial capacity
+	growth  float64        // capacity multiplier applied when full (0 means 2)
+	maxCap  int            // hard capacity limit (0 means unbounded)
+	maxLen  atomic.Int32   // historical maximum length reported by MaxLen
+	stats   dequeCounters  // operation counters reported by Stats
+	shared  atomic.Bool    // backing array is shared with a COWSnapshot and must be copied before writing
+	changed chan struct{}  // closed at the next change of length to wake context-aware waiters, nil if none wait
+}
+
+// DequeStats is a point-in-time snapshot of a deque's operation counters.
+// The counters count elements, not calls: a bulk method such as AppendFromSlice or PopNFront adds the number of
+// elements it moved. Elements entering or leaving at an end through the push and pop methods (including Extend,
+// Prepend, AppendFromSlice, the PopN, PopIf and PopWhile variants and Drain) are counted at that end; elements
+// added or removed at a position by the other methods are counted by InsertCount and RemoveCount, whichever index
+// they use. Init, UnmarshalBinary and UnmarshalJSON replace the contents without counting, so until the next of
+// those, CurrentLen equals the length at the last ResetStats plus pushes and inserts minus pops and removes.
+type DequeStats struct {
+	PushFrontCount int64 // elements pushed at the front
+	PushBackCount  int64 // elements pushed at the back
+	PopFrontCount  int64 // elements popped from the front
+	PopBackCount   int64 // elements popped from the back
+	InsertCount    int64 // elements added by Insert, InsertSlice and SortedInsert
+	RemoveCount    int64 // elements removed by Remove, RemoveRange, SortedRemove, FilterInPlace, Compact, Clear and Reset
+	ResizeCount    int64 // number of times the backing array was grown
+	CurrentLen     int64 // length at the time of the snapshot
+	CurrentCap     int64 // capacity at the time of the snapshot
+}
+
+// dequeCounters holds the atomic counters behind DequeStats.
+type dequeCounters struct {
+	pushFront atomic.Int64
+	pushBack  atomic.Int64
+	popFront  atomic.Int64
+	popBack   atomic.Int64
+	insert    atomic.Int64
+	remove    atomic.Int64
+	resize    atomic.Int64
 }
 
 type sliceHeader struct {
@@ -26,6 +63,9 @@ type sliceHeader struct {
 }
 
 // NewDeque creates and initializes a new Deque with optional initial capacity.
+// All methods are safe for concurrent use except Init, which replaces the backing array
+// and must not run concurrently with any other operation on the same deque.
+// Calling any method on a nil *Deque panics with a descriptive message.
 func NewDeque[T any](initCap ...int) *Deque[T] {
 	q := &Deque[T]{}
 	capacity := 8
@@ -37,12 +77,25 @@ func NewDeque[T any](initCap ...int) *Deque[T] {
 	return q
 }
 
-// Init initializes or resets the deque.
+// checkNil panics with a descriptive message if q is a nil deque.
+func (q *Deque[T]) checkNil() {
+	if q == nil {
+		panic("Deque: operation on nil deque")
+	}
+}
+
+// Init initializes or resets the deque with a capacity of exactly n.
+// A non-positive n falls back to the default capacity of 8.
+// On a bounded deque the capacity is limited to the maximum capacity set by WithMaxCapacity.
 func (q *Deque[T]) Init(n int) {
+	q.checkNil()
 	capacity := 8
-	if n > capacity {
+	if n > 0 {
 		capacity = n
 	}
+	if q.maxCap > 0 && capacity > q.maxCap {
+		capacity = q.maxCap
+	}
 	q.initCap = capacity
 	data := make([]T, capacity)
 	header := (*sliceHeader)(unsafe.Pointer(&data))
@@ -50,13 +103,23 @@ func (q *Deque[T]) Init(n int) {
 	atomic.StoreInt32(&q.front, 0)
 	atomic.StoreInt32(&q.back, 0)
 	atomic.StoreInt32(&q.length, 0)
+	q.notifyLocked()
+	q.shared.Store(false)
 }
 
 // Format implements the fmt.Formatter interface.
 func (q *Deque[T]) Format(f fmt.State, verb rune) {
+	q.checkNil()
+	formatElems(f, verb, int(atomic.LoadInt32(&q.length)), func(i int) T {
+		val, _ := q.At(i)
+		return val
+	})
+}
+
+// formatElems writes the first elements of a deque of the given length for Format, reading them with at.
+func formatElems[T any](f fmt.State, verb rune, length int, at func(i int) T) {
 	switch verb {
 	case 'v', 's':
-		length := int(atomic.LoadInt32(&q.length))
 		if length == 0 {
 			_, _ = io.WriteString(f, "[]")
 			return
@@ -87,7 +150,7 @@ func (q *Deque[T]) Format(f fmt.State, verb rune) {
 		// Get the elements to display
 		elements := make([]T, limit)
 		for i := 0; i < limit; i++ {
-			elements[i], _ = q.At(i)
+			elements[i] = at(i)
 		}
 
 		// Build the output string
@@ -114,11 +177,13 @@ func (q *Deque[T]) Format(f fmt.State, verb rune) {
 
 // Empty returns true if the deque contains no elements.
 func (q *Deque[T]) Empty() bool {
+	q.checkNil()
 	return atomic.LoadInt32(&q.length) == 0
 }
 
 // Len returns the number of elements in the deque.
 func (q *Deque[T]) Len() int {
+	q.checkNil()
 	return int(atomic.LoadInt32(&q.length))
 }
 
@@ -141,61 +206,147 @@ func (q *Deque[T]) internalResize(newCap int) {
 
 	atomic.StorePointer(&q.data, unsafe.Pointer(newHeader))
 	atomic.StoreInt32(&q.front, 0)
-	atomic.StoreInt32(&q.back, length)
+	atomic.StoreInt32(&q.back, length%int32(newCap))
+	q.shared.Store(false)
+}
+
+// grow enlarges the backing array by the growth factor, capped at maxCap (must be called with lock held).
+// Returns false if the deque is already at its maximum capacity.
+func (q *Deque[T]) grow() bool {
+	header := (*sliceHeader)(atomic.LoadPointer(&q.data))
+	if q.maxCap > 0 && header.cap >= q.maxCap {
+		return false
+	}
+
+	factor := q.growth
+	if factor <= 1 {
+		factor = 2
+	}
+	newCap := int(float64(header.cap) * factor)
+	if header.cap == 0 {
+		newCap = q.initCap
+	} else if newCap <= header.cap {
+		newCap = header.cap + 1
+	}
+	if q.maxCap > 0 && newCap > q.maxCap {
+		newCap = q.maxCap
+	}
+
+	q.internalResize(newCap)
+	q.stats.resize.Add(1)
+	return true
+}
+
+// reserveLocked makes room for n more elements, resizing the backing array at most once, to the first capacity
+// along the growth sequence that fits them all (must be called with lock held).
+// Returns false without resizing if they would not fit within maxCap.
+func (q *Deque[T]) reserveLocked(n int) bool {
+	needed := int(atomic.LoadInt32(&q.length)) + n
+	if q.maxCap > 0 && needed > q.maxCap {
+		return false
+	}
+	header := (*sliceHeader)(atomic.LoadPointer(&q.data))
+	if needed <= header.cap {
+		return true
+	}
+
+	factor := q.growth
+	if factor <= 1 {
+		factor = 2
+	}
+	newCap := max(header.cap, q.initCap, 1)
+	for newCap < needed {
+		newCap = max(int(float64(newCap)*factor), newCap+1)
+	}
+	if q.maxCap > 0 && newCap > q.maxCap {
+		newCap = q.maxCap
+	}
+	q.internalResize(newCap)
+	q.stats.resize.Add(1)
+	return true
+}
+
+// fullMessage describes a push onto a deque that is full at its maximum capacity.
+func (q *Deque[T]) fullMessage() string {
+	return fmt.Sprintf("Deque: push on full deque, max capacity %d", q.maxCap)
 }
 
 // PushBack adds an element to the back of the deque.
+// Panics if the deque is full and already at the maximum capacity set by WithMaxCapacity.
 func (q *Deque[T]) PushBack(val T) {
-	for {
-		back := atomic.LoadInt32(&q.back)
-		length := atomic.LoadInt32(&q.length)
-		header := (*sliceHeader)(atomic.LoadPointer(&q.data))
-		capacity := int32(header.cap)
-
-		if length < capacity {
-			newBack := (back + 1) % capacity
-			if atomic.CompareAndSwapInt32(&q.back, back, newBack) {
-				(*[1 << 30]T)(header.data)[back] = val
-				atomic.AddInt32(&q.length, 1)
-				return
-			}
-			continue
-		}
+	q.checkNil()
+	if !q.pushBack(val) {
+		panic(q.fullMessage())
+	}
+}
 
-		// Need to resize
-		q.mu.Lock()
-		// Double check after acquiring lock
-		header = (*sliceHeader)(atomic.LoadPointer(&q.data))
-		if atomic.LoadInt32(&q.length) == int32(header.cap) {
-			newCap := header.cap * 2
-			if newCap == 0 {
-				newCap = q.initCap
-			}
-			q.internalResize(newCap)
-			header = (*sliceHeader)(atomic.LoadPointer(&q.data))
+// PushBackOrDiscard adds an element to the back of the deque unless the deque is full at the maximum
+// capacity set by WithMaxCapacity, in which case val is discarded. It never blocks on a full deque or panics.
+// Returns true if val was pushed; unbounded deques always accept it.
+func (q *Deque[T]) PushBackOrDiscard(val T) bool {
+	q.checkNil()
+	return q.pushBack(val)
+}
+
+// pushBack adds val to the back, growing the deque if needed.
+// Returns false without pushing if the deque is full at its maximum capacity.
+func (q *Deque[T]) pushBack(val T) bool {
+	// The hot push and pop paths unlock explicitly rather than with defer
+	q.mu.Lock()
+	ok := q.pushBackLocked(val)
+	q.mu.Unlock()
+	return ok
+}
+
+// pushBackLocked is pushBack for callers that already hold the lock.
+func (q *Deque[T]) pushBackLocked(val T) bool {
+	q.unshareLocked()
+	header := (*sliceHeader)(atomic.LoadPointer(&q.data))
+	if atomic.LoadInt32(&q.length) == int32(header.cap) {
+		if !q.grow() {
+			return false
 		}
-		back = atomic.LoadInt32(&q.back)
-		capacity = int32(header.cap)
-		(*[1 << 30]T)(header.data)[back] = val
-		atomic.StoreInt32(&q.back, (back+1)%capacity)
-		atomic.AddInt32(&q.length, 1)
-		q.mu.Unlock()
-		return
+		header = (*sliceHeader)(atomic.LoadPointer(&q.data))
 	}
+
+	back := atomic.LoadInt32(&q.back)
+	(*[1 << 30]T)(header.data)[back] = val
+	atomic.StoreInt32(&q.back, (back+1)%int32(header.cap))
+	q.observeLen(atomic.AddInt32(&q.length, 1))
+	q.notifyLocked()
+	q.stats.pushBack.Add(1)
+	return true
 }
 
 // PushFront adds an element to the front of the deque.
+// Panics if the deque is full and already at the maximum capacity set by WithMaxCapacity.
 func (q *Deque[T]) PushFront(val T) {
+	q.checkNil()
+	if !q.pushFront(val) {
+		panic(q.fullMessage())
+	}
+}
+
+// PushFrontOrDiscard adds an element to the front of the deque unless the deque is full at the maximum
+// capacity set by WithMaxCapacity, in which case val is discarded. It never blocks on a full deque or panics.
+// Returns true if val was pushed; unbounded deques always accept it.
+func (q *Deque[T]) PushFrontOrDiscard(val T) bool {
+	q.checkNil()
+	return q.pushFront(val)
+}
+
+// pushFront adds val to the front, growing the deque if needed.
+// Returns false without pushing if the deque is full at its maximum capacity.
+func (q *Deque[T]) pushFront(val T) bool {
 	q.mu.Lock()
 	defer q.mu.Unlock()
+	q.unshareLocked()
 
 	header := (*sliceHeader)(atomic.LoadPointer(&q.data))
 	if atomic.LoadInt32(&q.length) == int32(header.cap) {
-		newCap := header.cap * 2
-		if newCap == 0 {
-			newCap = q.initCap
+		if !q.grow() {
+			return false
 		}
-		q.internalResize(newCap)
 		header = (*sliceHeader)(atomic.LoadPointer(&q.data))
 	}
 
@@ -203,50 +354,63 @@ func (q *Deque[T]) PushFront(val T) {
 	newFront := (front - 1 + int32(header.cap)) % int32(header.cap)
 	(*[1 << 30]T)(header.data)[newFront] = val
 	atomic.StoreInt32(&q.front, newFront)
-	atomic.AddInt32(&q.length, 1)
+	q.observeLen(atomic.AddInt32(&q.length, 1))
+	q.notifyLocked()
+	q.stats.pushFront.Add(1)
+	return true
 }
 
 // PopBack removes and returns the element from the back of the deque.
+// The slot is cleared before it is released, so the deque keeps no reference to the popped element.
 func (q *Deque[T]) PopBack() (T, bool) {
+	q.checkNil()
+	q.mu.Lock()
 	var zero T
-	for {
-		length := atomic.LoadInt32(&q.length)
-		if length == 0 {
-			return zero, false
-		}
-
-		back := atomic.LoadInt32(&q.back)
-		newBack := (back - 1 + int32(len(q.currentData()))) % int32(len(q.currentData()))
-		if atomic.CompareAndSwapInt32(&q.back, back, newBack) {
-			if atomic.AddInt32(&q.length, -1) >= 0 {
-				return q.currentData()[newBack], true
-			}
-			// CAS failed, revert
-			atomic.StoreInt32(&q.back, back)
-			atomic.AddInt32(&q.length, 1)
-		}
+	if atomic.LoadInt32(&q.length) == 0 {
+		q.mu.Unlock()
+		return zero, false
 	}
+	q.unshareLocked()
+
+	data := q.currentData()
+	back := (atomic.LoadInt32(&q.back) - 1 + int32(len(data))) % int32(len(data))
+	val := data[back]
+	data[back] = zero // release the reference for GC
+	atomic.StoreInt32(&q.back, back)
+	atomic.AddInt32(&q.length, -1)
+	q.notifyLocked()
+	q.mu.Unlock()
+	q.stats.popBack.Add(1)
+	return val, true
 }
 
 // PopFront removes and returns the element from the front of the deque.
+// The slot is cleared before it is released, so the deque keeps no reference to the popped element.
 func (q *Deque[T]) PopFront() (T, bool) {
-	var zero T
-	for {
-		length := atomic.LoadInt32(&q.length)
-		if length == 0 {
-			return zero, false
-		}
+	q.checkNil()
+	q.mu.Lock()
+	val, ok := q.popFrontLocked()
+	q.mu.Unlock()
+	return val, ok
+}
 
-		front := atomic.LoadInt32(&q.front)
-		if atomic.CompareAndSwapInt32(&q.front, front, (front+1)%int32(len(q.currentData()))) {
-			if atomic.AddInt32(&q.length, -1) >= 0 {
-				return q.currentData()[front], true
-			}
-			// CAS failed, revert
-			atomic.StoreInt32(&q.front, front)
-			atomic.AddInt32(&q.length, 1)
-		}
+// popFrontLocked is PopFront for callers that already hold the lock.
+func (q *Deque[T]) popFrontLocked() (T, bool) {
+	var zero T
+	if atomic.LoadInt32(&q.length) == 0 {
+		return zero, false
 	}
+	q.unshareLocked()
+
+	data := q.currentData()
+	front := atomic.LoadInt32(&q.front)
+	val := data[front]
+	data[front] = zero // release the reference for GC
+	atomic.StoreInt32(&q.front, (front+1)%int32(len(data)))
+	atomic.AddInt32(&q.length, -1)
+	q.notifyLocked()
+	q.stats.popFront.Add(1)
+	return val, true
 }
 
 // currentData returns the current underlying slice (unsafe, for internal use only)
@@ -255,8 +419,12 @@ func (q *Deque[T]) currentData() []T {
 	return *(*[]T)(unsafe.Pointer(header))
 }
 
-// Front returns the element at the front of the deque without removing it.
+// Front returns a copy of the element at the front of the deque without removing it.
+// The slot is read under the mutex so a concurrent Set, Rotate or resize cannot change it mid-read.
 func (q *Deque[T]) Front() (T, bool) {
+	q.checkNil()
+	q.mu.Lock()
+	defer q.mu.Unlock()
 	var zero T
 	length := atomic.LoadInt32(&q.length)
 	if length == 0 {
@@ -266,8 +434,12 @@ func (q *Deque[T]) Front() (T, bool) {
 	return q.currentData()[front], true
 }
 
-// Back returns the element at the back of the deque without removing it.
+// Back returns a copy of the element at the back of the deque without removing it.
+// The slot is read under the mutex so a concurrent Set, Rotate or resize cannot change it mid-read.
 func (q *Deque[T]) Back() (T, bool) {
+	q.checkNil()
+	q.mu.Lock()
+	defer q.mu.Unlock()
 	var zero T
 	length := atomic.LoadInt32(&q.length)
 	if length == 0 {
@@ -280,21 +452,34 @@ func (q *Deque[T]) Back() (T, bool) {
 
 // Capacity returns the current capacity of the deque.
 func (q *Deque[T]) Capacity() int {
+	q.checkNil()
 	header := (*sliceHeader)(atomic.LoadPointer(&q.data))
 	return header.cap
 }
 
 // Clear removes all elements from the deque.
 func (q *Deque[T]) Clear() {
+	q.checkNil()
 	q.mu.Lock()
 	defer q.mu.Unlock()
+	if q.shared.Load() {
+		// Leave the shared array to the snapshot and continue on fresh storage of the same capacity
+		data := make([]T, len(q.currentData()))
+		atomic.StorePointer(&q.data, unsafe.Pointer((*sliceHeader)(unsafe.Pointer(&data))))
+		q.shared.Store(false)
+	} else {
+		clear(q.currentData())
+	}
+	q.stats.remove.Add(int64(atomic.LoadInt32(&q.length)))
 	atomic.StoreInt32(&q.front, 0)
 	atomic.StoreInt32(&q.back, 0)
 	atomic.StoreInt32(&q.length, 0)
+	q.notifyLocked()
 }
 
 // At returns the element at the specified index.
 func (q *Deque[T]) At(index int) (T, bool) {
+	q.checkNil()
 	var zero T
 	length := atomic.LoadInt32(&q.length)
 	if index < 0 {
@@ -310,6 +495,7 @@ func (q *Deque[T]) At(index int) (T, bool) {
 
 // ShrinkToFit reduces capacity to fit the current size.
 func (q *Deque[T]) ShrinkToFit() {
+	q.checkNil()
 	q.mu.Lock()
 	defer q.mu.Unlock()
 
@@ -333,11 +519,15 @@ func (q *Deque[T]) ShrinkToFit() {
 
 // Copy creates a new independent copy of the deque.
 func (q *Deque[T]) Copy() *Deque[T] {
+	q.checkNil()
 	q.mu.Lock()
 	defer q.mu.Unlock()
 
 	newDeque := NewDeque[T](q.Capacity())
+	newDeque.growth = q.growth
+	newDeque.maxCap = q.maxCap
 	length := atomic.LoadInt32(&q.length)
+	newDeque.observeLen(length)
 	if length == 0 {
 		return newDeque
 	}
@@ -365,8 +555,10 @@ func (q *Deque[T]) Copy() *Deque[T] {
 
 // Set sets the element at the specified index to the given value.
 func (q *Deque[T]) Set(index int, value T) bool {
+	q.checkNil()
 	q.mu.Lock()
 	defer q.mu.Unlock()
+	q.unshareLocked()
 
 	length := atomic.LoadInt32(&q.length)
 	if index < 0 {
@@ -385,11 +577,13 @@ func (q *Deque[T]) Set(index int, value T) bool {
 
 // Swap swaps the elements at the specified indices.
 func (q *Deque[T]) Swap(i, j int) bool {
+	q.checkNil()
 	if i == j {
 		return false
 	}
 	q.mu.Lock()
 	defer q.mu.Unlock()
+	q.unshareLocked()
 
 	length := atomic.LoadInt32(&q.length)
 	if i < 0 {
@@ -424,8 +618,10 @@ func (q *Deque[T]) reverseSlice(s []T, start, end int) {
 
 // Rotate rotates the deque by n positions to the right (positive n) or left (negative n).
 func (q *Deque[T]) Rotate(n int) {
+	q.checkNil()
 	q.mu.Lock()
 	defer q.mu.Unlock()
+	q.unshareLocked()
 
 	// Load all atomic values once at the beginning
 	length := int(atomic.LoadInt32(&q.length))
@@ -445,7 +641,7 @@ func (q *Deque[T]) Rotate(n int) {
 	header := (*sliceHeader)(atomic.LoadPointer(&q.data))
 	capacity := header.cap
 	front := int(atomic.LoadInt32(&q.front))
-	data := (*[1 << 30]T)(header.data)[:capacity]
+	data := (*[1 << 30]T)(header.data)[:capacity:capacity]
 
 	// Unified approach that works for both cases
 	q.reverseCircular(data, front, front+length, length)
@@ -465,8 +661,10 @@ func (q *Deque[T]) reverseCircular(data []T, start, end, count int) {
 
 // Reverse reverses the order of elements in the deque.
 func (q *Deque[T]) Reverse() {
+	q.checkNil()
 	q.mu.Lock()
 	defer q.mu.Unlock()
+	q.unshareLocked()
 
 	length := atomic.LoadInt32(&q.length)
 	if length <= 1 {
@@ -485,3 +683,787 @@ func (q *Deque[T]) Reverse() {
 		data[left], data[right] = data[right], data[left]
 	}
 }
+
+// TrySet replaces the element at the specified index with value only if it currently equals old according to eq.
+// The comparison and the write happen under the deque's mutex, so no other locked operation can change the slot in between.
+// Returns false if the index is out of range or the slot no longer holds old.
+func (q *Deque[T]) TrySet(index int, old, value T, eq func(a, b T) bool) bool {
+	q.checkNil()
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.unshareLocked()
+
+	length := atomic.LoadInt32(&q.length)
+	if index < 0 {
+		index += int(length)
+	}
+	if index < 0 || index >= int(length) {
+		return false
+	}
+
+	header := (*sliceHeader)(atomic.LoadPointer(&q.data))
+	front := atomic.LoadInt32(&q.front)
+	pos := (front + int32(index)) % int32(header.cap)
+	data := (*[1 << 30]T)(header.data)
+	if !eq(data[pos], old) {
+		return false
+	}
+	data[pos] = value
+	return true
+}
+
+// GetAndSet replaces the element at index with newVal and returns the element it replaced, reading and writing
+// under a single mutex acquisition so no other operation can observe or change the slot in between.
+// Unlike TrySet the write is unconditional. Negative indices count from the back.
+// Returns false and leaves the deque unchanged if index is out of range.
+func (q *Deque[T]) GetAndSet(index int, newVal T) (old T, ok bool) {
+	q.checkNil()
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.unshareLocked()
+
+	length := atomic.LoadInt32(&q.length)
+	if index < 0 {
+		index += int(length)
+	}
+	if index < 0 || index >= int(length) {
+		return old, false
+	}
+
+	header := (*sliceHeader)(atomic.LoadPointer(&q.data))
+	front := atomic.LoadInt32(&q.front)
+	pos := (front + int32(index)) % int32(header.cap)
+	data := (*[1 << 30]T)(header.data)
+	old, data[pos] = data[pos], newVal
+	return old, true
+}
+
+// MoveToFront moves the element at index to the front of the deque, shifting the elements before it back by one.
+// The move happens under a single mutex acquisition and costs O(min(index, Len()-index)) element moves.
+// Negative indices count from the back. Returns false and leaves the deque unchanged if index is out of range.
+func (q *Deque[T]) MoveToFront(index int) bool {
+	q.checkNil()
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	length := int(atomic.LoadInt32(&q.length))
+	if index < 0 {
+		index += length
+	}
+	if index < 0 || index >= length {
+		return false
+	}
+	// Removing first frees a slot, so the insert can never need to grow the deque
+	q.insertAtLocked(0, q.removeAtLocked(index))
+	return true
+}
+
+// MoveToBack moves the element at index to the back of the deque, shifting the elements after it forward by one.
+// The move happens under a single mutex acquisition and costs O(min(index, Len()-index)) element moves.
+// Negative indices count from the back. Returns false and leaves the deque unchanged if index is out of range.
+func (q *Deque[T]) MoveToBack(index int) bool {
+	q.checkNil()
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	length := int(atomic.LoadInt32(&q.length))
+	if index < 0 {
+		index += length
+	}
+	if index < 0 || index >= length {
+		return false
+	}
+	q.insertAtLocked(length-1, q.removeAtLocked(index))
+	return true
+}
+
+// PopFrontIf removes and returns the front element only if pred reports true for it.
+// The peek, the predicate check and the pop all happen under the deque's mutex.
+func (q *Deque[T]) PopFrontIf(pred func(T) bool) (T, bool) {
+	q.checkNil()
+	var zero T
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.unshareLocked()
+
+	if atomic.LoadInt32(&q.length) == 0 {
+		return zero, false
+	}
+
+	header := (*sliceHeader)(atomic.LoadPointer(&q.data))
+	front := atomic.LoadInt32(&q.front)
+	val := (*[1 << 30]T)(header.data)[front]
+	if !pred(val) {
+		return zero, false
+	}
+	(*[1 << 30]T)(header.data)[front] = zero
+
+	atomic.StoreInt32(&q.front, (front+1)%int32(header.cap))
+	atomic.AddInt32(&q.length, -1)
+	q.notifyLocked()
+	q.stats.popFront.Add(1)
+	return val, true
+}
+
+// PopBackIf removes and returns the back element only if pred reports true for it.
+// The peek, the predicate check and the pop all happen under the deque's mutex.
+func (q *Deque[T]) PopBackIf(pred func(T) bool) (T, bool) {
+	q.checkNil()
+	var zero T
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.unshareLocked()
+
+	if atomic.LoadInt32(&q.length) == 0 {
+		return zero, false
+	}
+
+	header := (*sliceHeader)(atomic.LoadPointer(&q.data))
+	back := atomic.LoadInt32(&q.back)
+	newBack := (back - 1 + int32(header.cap)) % int32(header.cap)
+	val := (*[1 << 30]T)(header.data)[newBack]
+	if !pred(val) {
+		return zero, false
+	}
+	(*[1 << 30]T)(header.data)[newBack] = zero
+
+	atomic.StoreInt32(&q.back, newBack)
+	atomic.AddInt32(&q.length, -1)
+	q.notifyLocked()
+	q.stats.popBack.Add(1)
+	return val, true
+}
+
+// Stats returns a snapshot of the deque's operation counters together with its current length and capacity.
+// Each counter is read atomically, but the snapshot as a whole is not taken under a lock.
+func (q *Deque[T]) Stats() DequeStats {
+	q.checkNil()
+	return DequeStats{
+		PushFrontCount: q.stats.pushFront.Load(),
+		PushBackCount:  q.stats.pushBack.Load(),
+		PopFrontCount:  q.stats.popFront.Load(),
+		PopBackCount:   q.stats.popBack.Load(),
+		InsertCount:    q.stats.insert.Load(),
+		RemoveCount:    q.stats.remove.Load(),
+		ResizeCount:    q.stats.resize.Load(),
+		CurrentLen:     int64(atomic.LoadInt32(&q.length)),
+		CurrentCap:     int64(q.Capacity()),
+	}
+}
+
+// ResetStats zeroes all operation counters reported by Stats.
+func (q *Deque[T]) ResetStats() {
+	q.checkNil()
+	q.stats.pushFront.Store(0)
+	q.stats.pushBack.Store(0)
+	q.stats.popFront.Store(0)
+	q.stats.popBack.Store(0)
+	q.stats.insert.Store(0)
+	q.stats.remove.Store(0)
+	q.stats.resize.Store(0)
+}
+
+// Debug returns a dump of the deque's internal ring-buffer state for debugging purposes:
+//
+//	front=N back=M len=L cap=C
+//	data=[slot0 slot1 ... slotC-1]
+//
+// Every physical slot is listed; slots outside the live range are shown as <empty>.
+// The output format is intended for humans only and may change without notice.
+func (q *Deque[T]) Debug() string {
+	q.checkNil()
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	header := (*sliceHeader)(atomic.LoadPointer(&q.data))
+	front := int(atomic.LoadInt32(&q.front))
+	back := int(atomic.LoadInt32(&q.back))
+	length := int(atomic.LoadInt32(&q.length))
+	capacity := header.cap
+	data := (*[1 << 30]T)(header.data)[:capacity]
+
+	var b strings.Builder
+	_, _ = fmt.Fprintf(&b, "front=%d back=%d len=%d cap=%d\ndata=[", front, back, length, capacity)
+	for i := 0; i < capacity; i++ {
+		if i > 0 {
+			b.WriteByte(' ')
+		}
+		if (i-front+capacity)%capacity < length {
+			b.WriteString(fmt.Sprint(data[i]))
+		} else {
+			b.WriteString("<empty>")
+		}
+	}
+	b.WriteByte(']')
+	return b.String()
+}
+
+// Reset removes all elements, reallocates the backing array at the deque's initial capacity and clears MaxLen.
+func (q *Deque[T]) Reset() {
+	q.checkNil()
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.stats.remove.Add(int64(atomic.LoadInt32(&q.length)))
+	q.Init(q.initCap)
+	q.maxLen.Store(0)
+}
+
+// Drain returns an iterator that pops and yields elements from the front one at a time until the deque is empty.
+// Each step pops a single element, so breaking out of the loop leaves the remaining elements in the deque
+// and elements pushed while iterating are yielded as well.
+func (q *Deque[T]) Drain() iter.Seq[T] {
+	q.checkNil()
+	return func(yield func(T) bool) {
+		for {
+			val, ok := q.PopFront()
+			if !ok || !yield(val) {
+				return
+			}
+		}
+	}
+}
+
+// IsContiguous reports whether the elements occupy a single unwrapped run of the backing array,
+// i.e. the ring buffer has not wrapped around its end.
+func (q *Deque[T]) IsContiguous() bool {
+	q.checkNil()
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	header := (*sliceHeader)(atomic.LoadPointer(&q.data))
+	front := int(atomic.LoadInt32(&q.front))
+	length := int(atomic.LoadInt32(&q.length))
+	return front+length <= header.cap
+}
+
+// observeLen raises the recorded maximum length to n if n exceeds it.
+func (q *Deque[T]) observeLen(n int32) {
+	for {
+		cur := q.maxLen.Load()
+		if n <= cur || q.maxLen.CompareAndSwap(cur, n) {
+			return
+		}
+	}
+}
+
+// MaxLen returns the largest length the deque has reached since it was created or last Reset.
+func (q *Deque[T]) MaxLen() int {
+	q.checkNil()
+	return int(q.maxLen.Load())
+}
+
+// ResetMaxLen restarts MaxLen tracking from the current length.
+func (q *Deque[T]) ResetMaxLen() {
+	q.checkNil()
+	q.maxLen.Store(atomic.LoadInt32(&q.length))
+}
+
+// PopNFront removes up to n elements from the front of the deque and returns them in front-to-back order.
+// All elements are removed under a single lock acquisition; fewer than n are returned if the deque is shorter.
+func (q *Deque[T]) PopNFront(n int) []T {
+	q.checkNil()
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.unshareLocked()
+
+	length := int(atomic.LoadInt32(&q.length))
+	if n > length {
+		n = length
+	}
+	if n <= 0 {
+		return []T{}
+	}
+
+	header := (*sliceHeader)(atomic.LoadPointer(&q.data))
+	front := int(atomic.LoadInt32(&q.front))
+	capacity := header.cap
+	data := (*[1 << 30]T)(header.data)[:capacity]
+
+	var zero T
+	result := make([]T, n)
+	for i := range result {
+		pos := (front + i) % capacity
+		result[i] = data[pos]
+		data[pos] = zero
+	}
+
+	atomic.StoreInt32(&q.front, int32((front+n)%capacity))
+	atomic.AddInt32(&q.length, int32(-n))
+	q.notifyLocked()
+	q.stats.popFront.Add(int64(n))
+	return result
+}
+
+// PopNBack removes up to n elements from the back of the deque and returns them in back-to-front order,
+// so the most recently pushed element comes first.
+// All elements are removed under a single lock acquisition; fewer than n are returned if the deque is shorter.
+func (q *Deque[T]) PopNBack(n int) []T {
+	q.checkNil()
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.unshareLocked()
+
+	length := int(atomic.LoadInt32(&q.length))
+	if n > length {
+		n = length
+	}
+	if n <= 0 {
+		return []T{}
+	}
+
+	header := (*sliceHeader)(atomic.LoadPointer(&q.data))
+	back := int(atomic.LoadInt32(&q.back))
+	capacity := header.cap
+	data := (*[1 << 30]T)(header.data)[:capacity]
+
+	var zero T
+	result := make([]T, n)
+	for i := range result {
+		pos := (back - 1 - i + capacity) % capacity
+		result[i] = data[pos]
+		data[pos] = zero
+	}
+
+	atomic.StoreInt32(&q.back, int32((back-n+capacity)%capacity))
+	atomic.AddInt32(&q.length, int32(-n))
+	q.notifyLocked()
+	q.stats.popBack.Add(int64(n))
+	return result
+}
+
+// PopFrontWhile pops elements from the front for as long as pred reports true for the current front element,
+// and returns them in front-to-back order. The mutex is held for the whole operation, so no concurrent push or
+// pop interleaves with it. Returns an empty, non-nil slice if the front element does not match.
+func (q *Deque[T]) PopFrontWhile(pred func(T) bool) []T {
+	q.checkNil()
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.unshareLocked()
+
+	header := (*sliceHeader)(atomic.LoadPointer(&q.data))
+	front := int(atomic.LoadInt32(&q.front))
+	length := int(atomic.LoadInt32(&q.length))
+	capacity := header.cap
+	data := (*[1 << 30]T)(header.data)[:capacity]
+
+	var zero T
+	result := []T{}
+	for len(result) < length {
+		pos := (front + len(result)) % capacity
+		if !pred(data[pos]) {
+			break
+		}
+		result = append(result, data[pos])
+		data[pos] = zero
+	}
+
+	n := len(result)
+	atomic.StoreInt32(&q.front, int32((front+n)%capacity))
+	atomic.AddInt32(&q.length, int32(-n))
+	q.notifyLocked()
+	q.stats.popFront.Add(int64(n))
+	return result
+}
+
+// PopBackWhile pops elements from the back for as long as pred reports true for the current back element,
+// and returns them in back-to-front order. The mutex is held for the whole operation, so no concurrent push or
+// pop interleaves with it. Returns an empty, non-nil slice if the back element does not match.
+func (q *Deque[T]) PopBackWhile(pred func(T) bool) []T {
+	q.checkNil()
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.unshareLocked()
+
+	header := (*sliceHeader)(atomic.LoadPointer(&q.data))
+	back := int(atomic.LoadInt32(&q.back))
+	length := int(atomic.LoadInt32(&q.length))
+	capacity := header.cap
+	data := (*[1 << 30]T)(header.data)[:capacity]
+
+	var zero T
+	result := []T{}
+	for len(result) < length {
+		pos := (back - 1 - len(result) + capacity) % capacity
+		if !pred(data[pos]) {
+			break
+		}
+		result = append(result, data[pos])
+		data[pos] = zero
+	}
+
+	n := len(result)
+	atomic.StoreInt32(&q.back, int32((back-n+capacity)%capacity))
+	atomic.AddInt32(&q.length, int32(-n))
+	q.notifyLocked()
+	q.stats.popBack.Add(int64(n))
+	return result
+}
+
+// PeekFrontN returns copies of up to n elements from the front of the deque in front-to-back order
+// without removing them. All elements are read under a single lock acquisition, so the result is a consistent view.
+func (q *Deque[T]) PeekFrontN(n int) []T {
+	q.checkNil()
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	length := int(atomic.LoadInt32(&q.length))
+	n = max(min(n, length), 0)
+	header := (*sliceHeader)(atomic.LoadPointer(&q.data))
+	front := int(atomic.LoadInt32(&q.front))
+	capacity := header.cap
+	data := (*[1 << 30]T)(header.data)[:capacity]
+
+	result := make([]T, n)
+	for i := range result {
+		result[i] = data[(front+i)%capacity]
+	}
+	return result
+}
+
+// PeekBackN returns copies of up to n elements from the back of the deque in back-to-front order,
+// matching the order PopNBack would return them, without removing them.
+// All elements are read under a single lock acquisition, so the result is a consistent view.
+func (q *Deque[T]) PeekBackN(n int) []T {
+	q.checkNil()
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	length := int(atomic.LoadInt32(&q.length))
+	n = max(min(n, length), 0)
+	header := (*sliceHeader)(atomic.LoadPointer(&q.data))
+	back := int(atomic.LoadInt32(&q.back))
+	capacity := header.cap
+	data := (*[1 << 30]T)(header.data)[:capacity]
+
+	result := make([]T, n)
+	for i := range result {
+		result[i] = data[(back-1-i+capacity)%capacity]
+	}
+	return result
+}
+
+// ToSlice returns a copy of the elements in front-to-back order.
+func (q *Deque[T]) ToSlice() []T {
+	q.checkNil()
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.snapshotLocked()
+}
+
+// ForEach calls fn with the index and value of every element in front-to-back order.
+// It iterates over a snapshot taken under the mutex, so fn may safely call back into the deque.
+func (q *Deque[T]) ForEach(fn func(int, T)) {
+	q.checkNil()
+	q.mu.Lock()
+	snapshot := q.snapshotLocked()
+	q.mu.Unlock()
+	for i, val := range snapshot {
+		fn(i, val)
+	}
+}
+
+// FromSlice creates a Deque holding a copy of s, with s[0] at the front.
+// It is shorthand for NewDequeWithData without options.
+func FromSlice[T any](s []T) *Deque[T] {
+	return NewDequeWithData(s)
+}
+
+// AppendFromSlice appends all elements of s to the back of the deque, in order, under a single lock acquisition.
+// The backing array is resized at most once, to the first capacity along the growth sequence that fits them all.
+// Panics, leaving the deque unchanged, if the elements do not fit within the maximum capacity set by WithMaxCapacity.
+func (q *Deque[T]) AppendFromSlice(s []T) {
+	q.checkNil()
+	if len(s) == 0 {
+		return
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.unshareLocked()
+
+	if !q.reserveLocked(len(s)) {
+		panic(q.fullMessage())
+	}
+
+	header := (*sliceHeader)(atomic.LoadPointer(&q.data))
+	back := int(atomic.LoadInt32(&q.back))
+	capacity := header.cap
+	data := (*[1 << 30]T)(header.data)[:capacity]
+	n := copy(data[back:], s)
+	copy(data, s[n:])
+	atomic.StoreInt32(&q.back, int32((back+len(s))%capacity))
+	q.observeLen(atomic.AddInt32(&q.length, int32(len(s))))
+	q.notifyLocked()
+	q.stats.pushBack.Add(int64(len(s)))
+}
+
+// searchLocked returns the smallest logical index i in [0, Len()] for which pred(At(i)) is true,
+// assuming pred is false for a prefix of the deque and true for the rest (must be called with lock held).
+func (q *Deque[T]) searchLocked(pred func(T) bool) int {
+	header := (*sliceHeader)(atomic.LoadPointer(&q.data))
+	front := int(atomic.LoadInt32(&q.front))
+	capacity := header.cap
+	data := (*[1 << 30]T)(header.data)[:capacity]
+
+	lo, hi := 0, int(atomic.LoadInt32(&q.length))
+	for lo < hi {
+		mid := int(uint(lo+hi) >> 1)
+		if pred(data[(front+mid)%capacity]) {
+			hi = mid
+		} else {
+			lo = mid + 1
+		}
+	}
+	return lo
+}
+
+// insertAtLocked inserts val before the element at logical index (0 <= index <= Len()), shifting
+// whichever side of the deque holds fewer elements. Grows the backing array if it is full and
+// returns false without inserting if the deque is already at its maximum capacity (must be called with lock held).
+func (q *Deque[T]) insertAtLocked(index int, val T) bool {
+	q.unshareLocked()
+	header := (*sliceHeader)(atomic.LoadPointer(&q.data))
+	if atomic.LoadInt32(&q.length) == int32(header.cap) {
+		if !q.grow() {
+			return false
+		}
+		header = (*sliceHeader)(atomic.LoadPointer(&q.data))
+	}
+
+	front := int(atomic.LoadInt32(&q.front))
+	length := int(atomic.LoadInt32(&q.length))
+	capacity := header.cap
+	data := (*[1 << 30]T)(header.data)[:capacity]
+
+	if index < length/2 {
+		// Move the elements before index one slot towards the front
+		newFront := (front - 1 + capacity) % capacity
+		for i := 0; i < index; i++ {
+			data[(newFront+i)%capacity] = data[(newFront+i+1)%capacity]
+		}
+		data[(newFront+index)%capacity] = val
+		atomic.StoreInt32(&q.front, int32(newFront))
+	} else {
+		// Move the elements from index onwards one slot towards the back
+		for i := length; i > index; i-- {
+			data[(front+i)%capacity] = data[(front+i-1)%capacity]
+		}
+		data[(front+index)%capacity] = val
+		atomic.StoreInt32(&q.back, int32((front+length+1)%capacity))
+	}
+	q.observeLen(atomic.AddInt32(&q.length, 1))
+	q.notifyLocked()
+	return true
+}
+
+// removeAtLocked removes and returns the element at logical index (0 <= index < Len()), shifting
+// whichever side of the deque holds fewer elements and zeroing the vacated slot (must be called with lock held).
+func (q *Deque[T]) removeAtLocked(index int) T {
+	q.unshareLocked()
+	header := (*sliceHeader)(atomic.LoadPointer(&q.data))
+	front := int(atomic.LoadInt32(&q.front))
+	length := int(atomic.LoadInt32(&q.length))
+	capacity := header.cap
+	data := (*[1 << 30]T)(header.data)[:capacity]
+
+	var zero T
+	val := data[(front+index)%capacity]
+	if index < length/2 {
+		// Close the gap by moving the elements before index one slot towards the back
+		for i := index; i > 0; i-- {
+			data[(front+i)%capacity] = data[(front+i-1)%capacity]
+		}
+		data[front] = zero
+		atomic.StoreInt32(&q.front, int32((front+1)%capacity))
+	} else {
+		// Close the gap by moving the elements after index one slot towards the front
+		for i := index; i < length-1; i++ {
+			data[(front+i)%capacity] = data[(front+i+1)%capacity]
+		}
+		last := (front + length - 1) % capacity
+		data[last] = zero
+		atomic.StoreInt32(&q.back, int32(last))
+	}
+	atomic.AddInt32(&q.length, -1)
+	q.notifyLocked()
+	return val
+}
+
+// Insert inserts val before the element currently at index, so that val ends up at index, shifting whichever
+// side of the deque holds fewer elements, like std::deque. Index Len() appends at the back, and negative indices
+// count from the back. Returns false and leaves the deque unchanged if index is out of range or the deque is full
+// at the maximum capacity set by WithMaxCapacity.
+func (q *Deque[T]) Insert(index int, val T) bool {
+	q.checkNil()
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	length := int(atomic.LoadInt32(&q.length))
+	if index < 0 {
+		index += length
+	}
+	if index < 0 || index > length {
+		return false
+	}
+	if !q.insertAtLocked(index, val) {
+		return false
+	}
+	q.stats.insert.Add(1)
+	return true
+}
+
+// InsertSlice inserts vals, in order, before the element currently at index under a single lock acquisition.
+// The backing array is resized at most once and the shorter side of the deque is shifted by len(vals) slots.
+// Index handling and the result are as for Insert; inserting an empty slice at a valid index returns true.
+func (q *Deque[T]) InsertSlice(index int, vals []T) bool {
+	q.checkNil()
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	length := int(atomic.LoadInt32(&q.length))
+	if index < 0 {
+		index += length
+	}
+	if index < 0 || index > length {
+		return false
+	}
+	if len(vals) == 0 {
+		return true
+	}
+	q.unshareLocked()
+	if !q.reserveLocked(len(vals)) {
+		return false
+	}
+
+	header := (*sliceHeader)(atomic.LoadPointer(&q.data))
+	front := int(atomic.LoadInt32(&q.front))
+	capacity := header.cap
+	data := (*[1 << 30]T)(header.data)[:capacity]
+	k := len(vals)
+
+	if index < length/2 {
+		// Move the elements before index k slots towards the front
+		front = (front - k%capacity + capacity) % capacity
+		for i := 0; i < index; i++ {
+			data[(front+i)%capacity] = data[(front+i+k)%capacity]
+		}
+		atomic.StoreInt32(&q.front, int32(front))
+	} else {
+		// Move the elements from index onwards k slots towards the back
+		for i := length - 1; i >= index; i-- {
+			data[(front+i+k)%capacity] = data[(front+i)%capacity]
+		}
+		atomic.StoreInt32(&q.back, int32((front+length+k)%capacity))
+	}
+	for j, val := range vals {
+		data[(front+index+j)%capacity] = val
+	}
+	q.observeLen(atomic.AddInt32(&q.length, int32(k)))
+	q.notifyLocked()
+	q.stats.insert.Add(int64(k))
+	return true
+}
+
+// Remove removes and returns the element at index, shifting whichever side of the deque holds fewer elements
+// to close the gap. Negative indices count from the back. Returns false if index is out of range.
+func (q *Deque[T]) Remove(index int) (T, bool) {
+	q.checkNil()
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	length := int(atomic.LoadInt32(&q.length))
+	if index < 0 {
+		index += length
+	}
+	if index < 0 || index >= length {
+		var zero T
+		return zero, false
+	}
+	q.stats.remove.Add(1)
+	return q.removeAtLocked(index), true
+}
+
+// RemoveRange removes the elements in the half-open range [from, to) under a single lock acquisition,
+// shifting whichever side of the range holds fewer elements and zeroing the vacated slots.
+// Negative indices count from the back. Returns false and leaves the deque unchanged unless
+// 0 <= from <= to <= Len() after adjustment; an empty range returns true.
+func (q *Deque[T]) RemoveRange(from, to int) bool {
+	q.checkNil()
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	length := int(atomic.LoadInt32(&q.length))
+	if from < 0 {
+		from += length
+	}
+	if to < 0 {
+		to += length
+	}
+	if from < 0 || from > to || to > length {
+		return false
+	}
+	k := to - from
+	if k == 0 {
+		return true
+	}
+	q.unshareLocked()
+
+	header := (*sliceHeader)(atomic.LoadPointer(&q.data))
+	front := int(atomic.LoadInt32(&q.front))
+	capacity := header.cap
+	data := (*[1 << 30]T)(header.data)[:capacity]
+
+	var zero T
+	if from < length-to {
+		// Close the gap by moving the elements before from k slots towards the back
+		for i := from - 1; i >= 0; i-- {
+			data[(front+i+k)%capacity] = data[(front+i)%capacity]
+		}
+		for i := 0; i < k; i++ {
+			data[(front+i)%capacity] = zero
+		}
+		atomic.StoreInt32(&q.front, int32((front+k)%capacity))
+	} else {
+		// Close the gap by moving the elements from to onwards k slots towards the front
+		for i := to; i < length; i++ {
+			data[(front+i-k)%capacity] = data[(front+i)%capacity]
+		}
+		for i := length - k; i < length; i++ {
+			data[(front+i)%capacity] = zero
+		}
+		atomic.StoreInt32(&q.back, int32((front+length-k)%capacity))
+	}
+	atomic.AddInt32(&q.length, int32(-k))
+	q.notifyLocked()
+	q.stats.remove.Add(int64(k))
+	return true
+}
+
+// COWSnapshot returns a frozen-in-time copy of the deque in O(1) using copy-on-write.
+// The snapshot and the original share the backing array, and both are marked as shared; the first write to
+// either of them (push, pop, set, sort, ...) first copies the live elements into private storage, so neither
+// ever observes the other's changes. Reads never copy. Repeated snapshots are cheap, and each deque pays
+// for at most one O(n) copy after a snapshot is taken.
+// The snapshot is taken under the mutex, so it reflects a single consistent state of the deque.
+func (q *Deque[T]) COWSnapshot() *Deque[T] {
+	q.checkNil()
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	snap := &Deque[T]{initCap: q.initCap, growth: q.growth, maxCap: q.maxCap}
+	atomic.StorePointer(&snap.data, atomic.LoadPointer(&q.data))
+	atomic.StoreInt32(&snap.front, atomic.LoadInt32(&q.front))
+	atomic.StoreInt32(&snap.back, atomic.LoadInt32(&q.back))
+	atomic.StoreInt32(&snap.length, atomic.LoadInt32(&q.length))
+	snap.observeLen(atomic.LoadInt32(&q.length))
+	snap.shared.Store(true)
+	q.shared.Store(true)
+	return snap
+}
+
+// unshareLocked gives the deque private storage if its backing array is shared with a COWSnapshot
+// (must be called with lock held and before any write to the backing array).
+func (q *Deque[T]) unshareLocked() {
+	if q.shared.Load() {
+		q.internalResize((*sliceHeader)(atomic.LoadPointer(&q.data)).cap)
+	}
+}
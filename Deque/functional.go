@@ -0,0 +1,753 @@
+package Deque
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"unsafe"
+
+	"GoSTL/Tuple"
+)
+
+// lockBoth locks a and b in address order so that concurrent callers locking the same pair cannot deadlock.
+// A mutex shared by both arguments is locked only once. The returned function releases the locks.
+func lockBoth(a, b *sync.Mutex) (unlock func()) {
+	if a == b {
+		a.Lock()
+		return a.Unlock
+	}
+	if uintptr(unsafe.Pointer(a)) > uintptr(unsafe.Pointer(b)) {
+		a, b = b, a
+	}
+	a.Lock()
+	b.Lock()
+	return func() {
+		b.Unlock()
+		a.Unlock()
+	}
+}
+
+// snapshotLocked copies the elements into a new slice in front-to-back order (must be called with lock held).
+func (q *Deque[T]) snapshotLocked() []T {
+	return q.appendLocked(make([]T, 0, atomic.LoadInt32(&q.length)))
+}
+
+// appendLocked appends the elements to dst in front-to-back order and returns the extended slice
+// (must be called with lock held).
+func (q *Deque[T]) appendLocked(dst []T) []T {
+	header := (*sliceHeader)(atomic.LoadPointer(&q.data))
+	front := int(atomic.LoadInt32(&q.front))
+	length := int(atomic.LoadInt32(&q.length))
+	capacity := header.cap
+	data := (*[1 << 30]T)(header.data)[:capacity]
+
+	end := min(front+length, capacity)
+	dst = append(dst, data[front:end]...)
+	return append(dst, data[:length-(end-front)]...)
+}
+
+// adoptSlice creates a Deque that takes ownership of data's backing array without copying it.
+// Slices with a capacity below the default of 8 are copied into a fresh array instead.
+func adoptSlice[T any](data []T) *Deque[T] {
+	length := len(data)
+	if cap(data) < 8 {
+		data = append(make([]T, 0, 8), data...)
+	}
+	data = data[:cap(data)]
+
+	q := &Deque[T]{initCap: len(data)}
+	header := (*sliceHeader)(unsafe.Pointer(&data))
+	atomic.StorePointer(&q.data, unsafe.Pointer(header))
+	atomic.StoreInt32(&q.back, int32(length%len(data)))
+	atomic.StoreInt32(&q.length, int32(length))
+	q.observeLen(int32(length))
+	return q
+}
+
+// derive creates a Deque holding elems, with the same initial capacity, growth factor and maximum capacity as q.
+// Like adoptSlice it takes ownership of elems' backing array when that is large enough, but the resulting capacity
+// is at least q's initial capacity and does not exceed q's maximum capacity. Panics if elems do not fit within
+// the maximum capacity, which would otherwise leave a deque holding more elements than its backing array.
+func (q *Deque[T]) derive(elems []T) *Deque[T] {
+	if q.maxCap > 0 && len(elems) > q.maxCap {
+		panic(fmt.Sprintf("Deque: %d elements exceed max capacity %d", len(elems), q.maxCap))
+	}
+	capacity := max(len(elems), q.initCap, 1)
+	if q.maxCap > 0 && capacity > q.maxCap {
+		capacity = q.maxCap
+	}
+	if cap(elems) < capacity {
+		elems = append(make([]T, 0, capacity), elems...)
+	}
+	data := elems[:capacity:capacity]
+
+	d := &Deque[T]{initCap: q.initCap, growth: q.growth, maxCap: q.maxCap}
+	header := (*sliceHeader)(unsafe.Pointer(&data))
+	atomic.StorePointer(&d.data, unsafe.Pointer(header))
+	atomic.StoreInt32(&d.back, int32(len(elems)%capacity))
+	atomic.StoreInt32(&d.length, int32(len(elems)))
+	d.observeLen(int32(len(elems)))
+	return d
+}
+
+// Zip returns a new Deque pairing the elements of da and db position by position.
+// The result stops at the shorter of the two inputs. Both deques are read under their mutexes,
+// acquired together in a deterministic order, so the pairs reflect a single consistent state.
+func Zip[A, B any](da *Deque[A], db *Deque[B]) *Deque[Tuple.Pair[A, B]] {
+	da.checkNil()
+	db.checkNil()
+	unlock := lockBoth(&da.mu, &db.mu)
+	as := da.snapshotLocked()
+	bs := db.snapshotLocked()
+	unlock()
+
+	n := min(len(as), len(bs))
+	pairs := make([]Tuple.Pair[A, B], n)
+	for i := range pairs {
+		pairs[i] = Tuple.NewPair(as[i], bs[i])
+	}
+	return adoptSlice(pairs)
+}
+
+// ZipWith returns a new Deque holding fn applied to the elements of da and db position by position.
+// Like Zip it stops at the shorter input and reads both deques under their mutexes, acquired together,
+// but fn is called only after both locks are released, so it may use either deque.
+func ZipWith[A, B, C any](da *Deque[A], db *Deque[B], fn func(A, B) C) *Deque[C] {
+	da.checkNil()
+	db.checkNil()
+	unlock := lockBoth(&da.mu, &db.mu)
+	as := da.snapshotLocked()
+	bs := db.snapshotLocked()
+	unlock()
+
+	n := min(len(as), len(bs))
+	out := make([]C, n)
+	for i := range out {
+		out[i] = fn(as[i], bs[i])
+	}
+	return adoptSlice(out)
+}
+
+// Unzip splits a Deque of pairs into a Deque of first elements and a Deque of second elements.
+// The input is read under its mutex and left unchanged.
+func Unzip[A, B any](d *Deque[Tuple.Pair[A, B]]) (*Deque[A], *Deque[B]) {
+	d.checkNil()
+	d.mu.Lock()
+	pairs := d.snapshotLocked()
+	d.mu.Unlock()
+
+	as, bs := Tuple.Unzip(pairs)
+	return adoptSlice(as), adoptSlice(bs)
+}
+
+// Unique returns a new Deque holding the elements of the deque with all duplicates removed, keeping the
+// first occurrence of each value in front-to-back order. Duplicates are detected with eq by a linear scan
+// of the values kept so far, so the cost is O(n²) in the worst case; use UniqueOrdered for comparable types.
+// The result is sized for the elements kept and has the same initial capacity, growth factor and maximum capacity
+// as the receiver, which is unchanged.
+func (q *Deque[T]) Unique(eq func(a, b T) bool) *Deque[T] {
+	q.checkNil()
+	q.mu.Lock()
+	elems := q.snapshotLocked()
+	q.mu.Unlock()
+
+	var kept []T
+	for _, val := range elems {
+		seen := false
+		for _, k := range kept {
+			if eq(k, val) {
+				seen = true
+				break
+			}
+		}
+		if !seen {
+			kept = append(kept, val)
+		}
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.derive(kept)
+}
+
+// UniqueOrdered returns a new Deque holding the elements of d with all duplicates removed, keeping the
+// first occurrence of each value in front-to-back order. Seen values are tracked in a map, so the cost is O(n).
+// Like Unique, the result is sized for the elements kept and has the same settings as d, which is unchanged.
+func UniqueOrdered[T comparable](d *Deque[T]) *Deque[T] {
+	d.checkNil()
+	d.mu.Lock()
+	elems := d.snapshotLocked()
+	d.mu.Unlock()
+
+	seen := make(map[T]struct{})
+	var kept []T
+	for _, val := range elems {
+		if _, ok := seen[val]; !ok {
+			seen[val] = struct{}{}
+			kept = append(kept, val)
+		}
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.derive(kept)
+}
+
+// Flatten concatenates the inner deques of d, in order, into a new Deque. The total length is computed
+// up front so the result is allocated once. Each inner deque is copied under its own mutex; nil inner
+// deques are treated as empty. d and the inner deques are unchanged.
+func Flatten[T any](d *Deque[*Deque[T]]) *Deque[T] {
+	d.checkNil()
+	d.mu.Lock()
+	inners := d.snapshotLocked()
+	d.mu.Unlock()
+
+	total := 0
+	for _, inner := range inners {
+		if inner != nil {
+			total += inner.Len()
+		}
+	}
+	out := make([]T, 0, total)
+	for _, inner := range inners {
+		if inner == nil {
+			continue
+		}
+		inner.mu.Lock()
+		out = inner.appendLocked(out)
+		inner.mu.Unlock()
+	}
+	return adoptSlice(out)
+}
+
+// FlatMap maps every element of d to a Deque with fn and concatenates the results, in order, into a new Deque.
+// fn is called outside d's mutex, so it may use d; nil results are treated as empty. d is unchanged.
+func FlatMap[T, U any](d *Deque[T], fn func(T) *Deque[U]) *Deque[U] {
+	d.checkNil()
+	d.mu.Lock()
+	elems := d.snapshotLocked()
+	d.mu.Unlock()
+
+	var out []U
+	for _, val := range elems {
+		inner := fn(val)
+		if inner == nil {
+			continue
+		}
+		inner.mu.Lock()
+		out = inner.appendLocked(out)
+		inner.mu.Unlock()
+	}
+	return adoptSlice(out)
+}
+
+// FlattenSlices concatenates the slices held by d, in order, into a new Deque. It is Flatten for deques of
+// slices: the total length is computed from the snapshot so the result is allocated once. d is unchanged.
+func FlattenSlices[T any](d *Deque[[]T]) *Deque[T] {
+	d.checkNil()
+	d.mu.Lock()
+	inners := d.snapshotLocked()
+	d.mu.Unlock()
+
+	total := 0
+	for _, inner := range inners {
+		total += len(inner)
+	}
+	out := make([]T, 0, total)
+	for _, inner := range inners {
+		out = append(out, inner...)
+	}
+	return adoptSlice(out)
+}
+
+// FlatMapSlices maps every element of d to a slice with fn and concatenates the results, in order, into a new Deque.
+// The results are collected first so that the output is allocated once. fn is called outside d's mutex, so it may
+// use d. d is unchanged.
+func FlatMapSlices[T, U any](d *Deque[T], fn func(T) []U) *Deque[U] {
+	d.checkNil()
+	d.mu.Lock()
+	elems := d.snapshotLocked()
+	d.mu.Unlock()
+
+	inners := make([][]U, len(elems))
+	total := 0
+	for i, val := range elems {
+		inners[i] = fn(val)
+		total += len(inners[i])
+	}
+	out := make([]U, 0, total)
+	for _, inner := range inners {
+		out = append(out, inner...)
+	}
+	return adoptSlice(out)
+}
+
+// GroupBy partitions the elements of d by the key fn computes for them. Each group is a new Deque holding its
+// elements in their original front-to-back order, with the same initial capacity, growth factor and maximum
+// capacity as d. d is read from a snapshot and left unchanged, and key is called outside d's mutex, so it may use d.
+// The returned map is a fresh value owned by the caller.
+func GroupBy[T any, K comparable](d *Deque[T], key func(T) K) map[K]*Deque[T] {
+	d.checkNil()
+	d.mu.Lock()
+	elems := d.snapshotLocked()
+	d.mu.Unlock()
+
+	groups := make(map[K][]T)
+	for _, val := range elems {
+		k := key(val)
+		groups[k] = append(groups[k], val)
+	}
+	out := make(map[K]*Deque[T], len(groups))
+	for k, group := range groups {
+		out[k] = d.derive(group)
+	}
+	return out
+}
+
+// Filter returns a new Deque holding, in front-to-back order, the elements of the deque for which pred reports true.
+// pred is called under the deque's mutex, so it must not use the deque. The result has the same initial capacity,
+// growth factor and maximum capacity as the receiver, which is unchanged.
+func (q *Deque[T]) Filter(pred func(T) bool) *Deque[T] {
+	q.checkNil()
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	header := (*sliceHeader)(atomic.LoadPointer(&q.data))
+	front := int(atomic.LoadInt32(&q.front))
+	length := int(atomic.LoadInt32(&q.length))
+	capacity := header.cap
+	data := (*[1 << 30]T)(header.data)[:capacity]
+
+	var kept []T
+	for i := 0; i < length; i++ {
+		if val := data[(front+i)%capacity]; pred(val) {
+			kept = append(kept, val)
+		}
+	}
+	return q.derive(kept)
+}
+
+// FilterInPlace removes the elements for which pred reports false, keeping the order of the rest.
+// The survivors are compacted towards the front within the existing backing array and the vacated slots are zeroed,
+// so no second deque is allocated. pred is called under the deque's mutex, so it must not use the deque.
+func (q *Deque[T]) FilterInPlace(pred func(T) bool) {
+	q.checkNil()
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.unshareLocked()
+
+	header := (*sliceHeader)(atomic.LoadPointer(&q.data))
+	front := int(atomic.LoadInt32(&q.front))
+	length := int(atomic.LoadInt32(&q.length))
+	capacity := header.cap
+	data := (*[1 << 30]T)(header.data)[:capacity]
+
+	kept := 0
+	for i := 0; i < length; i++ {
+		val := data[(front+i)%capacity]
+		if pred(val) {
+			data[(front+kept)%capacity] = val
+			kept++
+		}
+	}
+	var zero T
+	for i := kept; i < length; i++ {
+		data[(front+i)%capacity] = zero
+	}
+	atomic.StoreInt32(&q.back, int32((front+kept)%capacity))
+	atomic.StoreInt32(&q.length, int32(kept))
+	q.notifyLocked()
+	q.stats.remove.Add(int64(length - kept))
+}
+
+// Compact removes consecutive duplicates in place, keeping the first element of every run of equal elements,
+// like slices.CompactFunc or C++'s std::unique. Each element is compared with eq against the last element kept.
+// Unlike Unique, which removes all duplicates into a new Deque, only adjacent ones are removed; sort first to
+// remove them all.
+// If the deque ends up using less than half of its capacity, the backing array is shrunk to fit
+// (never below the initial capacity). eq is called under the deque's mutex, so it must not use the deque.
+func (q *Deque[T]) Compact(eq func(T, T) bool) {
+	q.checkNil()
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	length := int(atomic.LoadInt32(&q.length))
+	if length < 2 {
+		return
+	}
+	q.unshareLocked()
+
+	header := (*sliceHeader)(atomic.LoadPointer(&q.data))
+	front := int(atomic.LoadInt32(&q.front))
+	capacity := header.cap
+	data := (*[1 << 30]T)(header.data)[:capacity]
+
+	kept := 1
+	for i := 1; i < length; i++ {
+		val := data[(front+i)%capacity]
+		if !eq(data[(front+kept-1)%capacity], val) {
+			data[(front+kept)%capacity] = val
+			kept++
+		}
+	}
+	var zero T
+	for i := kept; i < length; i++ {
+		data[(front+i)%capacity] = zero
+	}
+	atomic.StoreInt32(&q.back, int32((front+kept)%capacity))
+	atomic.StoreInt32(&q.length, int32(kept))
+	q.notifyLocked()
+	q.stats.remove.Add(int64(length - kept))
+
+	if newCap := max(kept, q.initCap); kept < capacity/2 && newCap < capacity {
+		q.internalResize(newCap)
+	}
+}
+
+// Map applies fn to every element of d, in front-to-back order, and returns the results as a new Deque.
+// fn is called outside d's mutex, so it may use d. d is unchanged.
+func Map[T, U any](d *Deque[T], fn func(T) U) *Deque[U] {
+	d.checkNil()
+	d.mu.Lock()
+	elems := d.snapshotLocked()
+	d.mu.Unlock()
+
+	out := make([]U, len(elems))
+	for i, val := range elems {
+		out[i] = fn(val)
+	}
+	return adoptSlice(out)
+}
+
+// MapInPlace replaces every element of the deque with the result of fn, in front-to-back order.
+// fn is called under the deque's mutex, so it must not use the deque.
+func (q *Deque[T]) MapInPlace(fn func(T) T) {
+	q.checkNil()
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.unshareLocked()
+
+	header := (*sliceHeader)(atomic.LoadPointer(&q.data))
+	front := int(atomic.LoadInt32(&q.front))
+	length := int(atomic.LoadInt32(&q.length))
+	capacity := header.cap
+	data := (*[1 << 30]T)(header.data)[:capacity]
+	for i := 0; i < length; i++ {
+		pos := (front + i) % capacity
+		data[pos] = fn(data[pos])
+	}
+}
+
+// Reduce folds the elements of d, in front-to-back order, into an accumulator that starts at initial.
+// The elements are snapshotted under d's mutex, so the result reflects a single consistent state,
+// and fn is called outside the mutex, so it may use d.
+func Reduce[T, U any](d *Deque[T], initial U, fn func(U, T) U) U {
+	d.checkNil()
+	d.mu.Lock()
+	elems := d.snapshotLocked()
+	d.mu.Unlock()
+
+	acc := initial
+	for _, val := range elems {
+		acc = fn(acc, val)
+	}
+	return acc
+}
+
+// ReduceRight is like Reduce but folds the elements in back-to-front order.
+func ReduceRight[T, U any](d *Deque[T], initial U, fn func(U, T) U) U {
+	d.checkNil()
+	d.mu.Lock()
+	elems := d.snapshotLocked()
+	d.mu.Unlock()
+
+	acc := initial
+	for i := len(elems) - 1; i >= 0; i-- {
+		acc = fn(acc, elems[i])
+	}
+	return acc
+}
+
+// Find returns the index and value of the first element, scanning front to back, for which pred reports true.
+// The mutex is held for the whole scan, so pred must not use the deque. If no element matches,
+// index is -1 and found is false.
+func (q *Deque[T]) Find(pred func(T) bool) (index int, val T, found bool) {
+	q.checkNil()
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	header := (*sliceHeader)(atomic.LoadPointer(&q.data))
+	front := int(atomic.LoadInt32(&q.front))
+	length := int(atomic.LoadInt32(&q.length))
+	capacity := header.cap
+	data := (*[1 << 30]T)(header.data)[:capacity]
+	for i := 0; i < length; i++ {
+		if v := data[(front+i)%capacity]; pred(v) {
+			return i, v, true
+		}
+	}
+	return -1, val, false
+}
+
+// FindLast is like Find but scans back to front, returning the last matching element.
+func (q *Deque[T]) FindLast(pred func(T) bool) (index int, val T, found bool) {
+	q.checkNil()
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	header := (*sliceHeader)(atomic.LoadPointer(&q.data))
+	front := int(atomic.LoadInt32(&q.front))
+	length := int(atomic.LoadInt32(&q.length))
+	capacity := header.cap
+	data := (*[1 << 30]T)(header.data)[:capacity]
+	for i := length - 1; i >= 0; i-- {
+		if v := data[(front+i)%capacity]; pred(v) {
+			return i, v, true
+		}
+	}
+	return -1, val, false
+}
+
+// FindAll returns the logical indices, in ascending order, of all elements for which pred reports true.
+// The mutex is held for the whole scan, so pred must not use the deque. Returns an empty, non-nil slice if none match.
+func (q *Deque[T]) FindAll(pred func(T) bool) []int {
+	q.checkNil()
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	header := (*sliceHeader)(atomic.LoadPointer(&q.data))
+	front := int(atomic.LoadInt32(&q.front))
+	length := int(atomic.LoadInt32(&q.length))
+	capacity := header.cap
+	data := (*[1 << 30]T)(header.data)[:capacity]
+
+	indices := []int{}
+	for i := 0; i < length; i++ {
+		if pred(data[(front+i)%capacity]) {
+			indices = append(indices, i)
+		}
+	}
+	return indices
+}
+
+// Extend appends all elements of other, in front-to-back order, to the back of the deque. Both mutexes are held,
+// acquired in a deterministic order, and the elements are copied directly between the backing arrays after at
+// most one resize, so no intermediate slice is allocated. other is unchanged and may be the deque itself.
+// Panics, leaving the deque unchanged, if the elements do not fit within the maximum capacity set by WithMaxCapacity.
+func (q *Deque[T]) Extend(other *Deque[T]) {
+	q.checkNil()
+	other.checkNil()
+	defer lockBoth(&q.mu, &other.mu)()
+	q.unshareLocked()
+	n := int(atomic.LoadInt32(&other.length))
+	if !q.reserveLocked(n) {
+		panic(q.fullMessage())
+	}
+
+	back := int(atomic.LoadInt32(&q.back))
+	capacity := q.copyFromLocked(other, back, n)
+	atomic.StoreInt32(&q.back, int32((back+n)%capacity))
+	q.observeLen(atomic.AddInt32(&q.length, int32(n)))
+	q.notifyLocked()
+	q.stats.pushBack.Add(int64(n))
+}
+
+// Prepend inserts all elements of other at the front of the deque, keeping their order, so that other's front
+// becomes the deque's front. Locking, copying, the capacity limit and the handling of other are as for Extend.
+func (q *Deque[T]) Prepend(other *Deque[T]) {
+	q.checkNil()
+	other.checkNil()
+	defer lockBoth(&q.mu, &other.mu)()
+	q.unshareLocked()
+	n := int(atomic.LoadInt32(&other.length))
+	if !q.reserveLocked(n) {
+		panic(q.fullMessage())
+	}
+
+	capacity := (*sliceHeader)(atomic.LoadPointer(&q.data)).cap
+	front := (int(atomic.LoadInt32(&q.front)) - n%capacity + capacity) % capacity
+	q.copyFromLocked(other, front, n)
+	atomic.StoreInt32(&q.front, int32(front))
+	q.observeLen(atomic.AddInt32(&q.length, int32(n)))
+	q.notifyLocked()
+	q.stats.pushFront.Add(int64(n))
+}
+
+// copyFromLocked copies the first n elements of other, in front-to-back order, into q's backing array starting at
+// physical slot start, wrapping around its end, and returns q's capacity (must be called with both locks held).
+// The destination slots must lie outside q's live range, which makes copying from q itself safe.
+func (q *Deque[T]) copyFromLocked(other *Deque[T], start, n int) int {
+	header := (*sliceHeader)(atomic.LoadPointer(&q.data))
+	capacity := header.cap
+	data := (*[1 << 30]T)(header.data)[:capacity]
+	srcHeader := (*sliceHeader)(atomic.LoadPointer(&other.data))
+	srcFront := int(atomic.LoadInt32(&other.front))
+	src := (*[1 << 30]T)(srcHeader.data)[:srcHeader.cap]
+	for i := 0; i < n; i++ {
+		data[(start+i)%capacity] = src[(srcFront+i)%srcHeader.cap]
+	}
+	return capacity
+}
+
+// Split returns two new, independent deques: left holds the elements [0, index) and right holds [index, Len()).
+// Both share the receiver's initial capacity, growth factor and maximum capacity; the receiver is unchanged.
+// Negative indices count from the back. Returns nil, nil, false if index is out of range.
+func (q *Deque[T]) Split(index int) (left, right *Deque[T], ok bool) {
+	q.checkNil()
+	q.mu.Lock()
+	elems := q.snapshotLocked()
+	q.mu.Unlock()
+
+	if index < 0 {
+		index += len(elems)
+	}
+	if index < 0 || index > len(elems) {
+		return nil, nil, false
+	}
+	// The right half is copied so the two deques never share a backing array
+	return q.derive(elems[:index:index]), q.derive(append([]T(nil), elems[index:]...)), true
+}
+
+// Windows returns a function that yields the overlapping windows of size consecutive elements, in front-to-back
+// order: each call returns a fresh copy of the next window and true, or nil and false once all Len()-size+1
+// windows have been returned. A deque shorter than size yields no windows. The elements are snapshotted when
+// Windows is called, so later changes to the deque are not seen. Panics if size is not positive.
+func (q *Deque[T]) Windows(size int) func() ([]T, bool) {
+	q.checkNil()
+	if size <= 0 {
+		panic(fmt.Sprintf("Deque: invalid window size %d", size))
+	}
+	q.mu.Lock()
+	elems := q.snapshotLocked()
+	q.mu.Unlock()
+
+	pos := 0
+	return func() ([]T, bool) {
+		if pos+size > len(elems) {
+			return nil, false
+		}
+		window := slices.Clone(elems[pos : pos+size])
+		pos++
+		return window, true
+	}
+}
+
+// Chunks returns a function that yields consecutive, non-overlapping chunks of size elements, in front-to-back
+// order: each call returns a fresh copy of the next chunk and true, or nil and false once the deque is exhausted.
+// The last chunk holds the remaining elements and may be shorter than size. The elements are snapshotted when
+// Chunks is called, so later changes to the deque are not seen. Panics if size is not positive.
+func (q *Deque[T]) Chunks(size int) func() ([]T, bool) {
+	q.checkNil()
+	if size <= 0 {
+		panic(fmt.Sprintf("Deque: invalid chunk size %d", size))
+	}
+	q.mu.Lock()
+	elems := q.snapshotLocked()
+	q.mu.Unlock()
+
+	pos := 0
+	return func() ([]T, bool) {
+		if pos >= len(elems) {
+			return nil, false
+		}
+		end := min(pos+size, len(elems))
+		chunk := slices.Clone(elems[pos:end])
+		pos = end
+		return chunk, true
+	}
+}
+
+// Any reports whether pred is true for at least one element. The elements are visited front to back under the
+// mutex without copying, and the scan stops at the first match, so pred must not use the deque.
+func (q *Deque[T]) Any(pred func(T) bool) bool {
+	q.checkNil()
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	header := (*sliceHeader)(atomic.LoadPointer(&q.data))
+	front := int(atomic.LoadInt32(&q.front))
+	length := int(atomic.LoadInt32(&q.length))
+	capacity := header.cap
+	data := (*[1 << 30]T)(header.data)[:capacity]
+	for i := 0; i < length; i++ {
+		if pred(data[(front+i)%capacity]) {
+			return true
+		}
+	}
+	return false
+}
+
+// All reports whether pred is true for every element; it is true for an empty deque.
+// The scan stops at the first element for which pred is false.
+func (q *Deque[T]) All(pred func(T) bool) bool {
+	return !q.Any(func(v T) bool { return !pred(v) })
+}
+
+// None reports whether pred is false for every element; it is true for an empty deque.
+// The scan stops at the first element for which pred is true.
+func (q *Deque[T]) None(pred func(T) bool) bool {
+	return !q.Any(pred)
+}
+
+// Count returns the number of elements for which pred is true. The elements are visited front to back under
+// the mutex directly in the ring buffer, so the count reflects a single consistent state and nothing is allocated.
+// pred must not use the deque.
+func (q *Deque[T]) Count(pred func(T) bool) int {
+	q.checkNil()
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	header := (*sliceHeader)(atomic.LoadPointer(&q.data))
+	front := int(atomic.LoadInt32(&q.front))
+	length := int(atomic.LoadInt32(&q.length))
+	capacity := header.cap
+	data := (*[1 << 30]T)(header.data)[:capacity]
+
+	n := 0
+	for i := 0; i < length; i++ {
+		if pred(data[(front+i)%capacity]) {
+			n++
+		}
+	}
+	return n
+}
+
+// CountEqual returns the number of elements equal to val according to eq.
+func (q *Deque[T]) CountEqual(val T, eq func(T, T) bool) int {
+	return q.Count(func(v T) bool { return eq(v, val) })
+}
+
+// Equal reports whether q and other have the same length and eq holds for every pair of elements at the
+// same position. Both deques are locked for the whole comparison, in a deterministic order so that concurrent
+// a.Equal(b) and b.Equal(a) calls cannot deadlock; eq must therefore not call back into either deque.
+func (q *Deque[T]) Equal(other *Deque[T], eq func(a, b T) bool) bool {
+	q.checkNil()
+	other.checkNil()
+	if q == other {
+		return true
+	}
+	unlock := lockBoth(&q.mu, &other.mu)
+	defer unlock()
+
+	length := int(atomic.LoadInt32(&q.length))
+	if length != int(atomic.LoadInt32(&other.length)) {
+		return false
+	}
+	qHeader := (*sliceHeader)(atomic.LoadPointer(&q.data))
+	oHeader := (*sliceHeader)(atomic.LoadPointer(&other.data))
+	qData := (*[1 << 30]T)(qHeader.data)[:qHeader.cap]
+	oData := (*[1 << 30]T)(oHeader.data)[:oHeader.cap]
+	qFront := int(atomic.LoadInt32(&q.front))
+	oFront := int(atomic.LoadInt32(&other.front))
+
+	for i := 0; i < length; i++ {
+		if !eq(qData[(qFront+i)%qHeader.cap], oData[(oFront+i)%oHeader.cap]) {
+			return false
+		}
+	}
+	return true
+}
+
+// DequeEqual reports whether a and b hold the same elements in the same order, comparing them with ==.
+func DequeEqual[T comparable](a, b *Deque[T]) bool {
+	return a.Equal(b, func(x, y T) bool { return x == y })
+}
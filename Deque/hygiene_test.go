@@ -0,0 +1,220 @@
+package Deque
+
+import (
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+// AllSlotsAboveTopAreZero reports whether every slot outside the live range [front, front+length)
+// holds the zero value of T, i.e. whether popped elements are no longer referenced by the backing array.
+func (q *Deque[T]) AllSlotsAboveTopAreZero() bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	header := (*sliceHeader)(atomic.LoadPointer(&q.data))
+	front := int(atomic.LoadInt32(&q.front))
+	length := int(atomic.LoadInt32(&q.length))
+	capacity := header.cap
+	data := (*[1 << 30]T)(header.data)[:capacity]
+	for i := length; i < capacity; i++ {
+		if !reflect.ValueOf(&data[(front+i)%capacity]).Elem().IsZero() {
+			return false
+		}
+	}
+	return true
+}
+
+// AllSlotsAboveTopAreZero is Deque.AllSlotsAboveTopAreZero for UnsafeDeque.
+func (q *UnsafeDeque[T]) AllSlotsAboveTopAreZero() bool {
+	for i := q.length; i < len(q.data); i++ {
+		if !reflect.ValueOf(&q.data[q.slot(i)]).Elem().IsZero() {
+			return false
+		}
+	}
+	return true
+}
+
+func newPointerDeque(n int) *Deque[*int] {
+	q := NewDeque[*int]()
+	for i := 0; i < n; i++ {
+		v := i
+		q.PushBack(&v)
+	}
+	return q
+}
+
+func TestPopFrontReleasesSlots(t *testing.T) {
+	q := newPointerDeque(100)
+	for i := 0; i < 100; i++ {
+		q.PopFront()
+		if !q.AllSlotsAboveTopAreZero() {
+			t.Fatalf("Stale reference left after %d PopFront calls", i+1)
+		}
+	}
+}
+
+func TestPopBackReleasesSlots(t *testing.T) {
+	q := newPointerDeque(100)
+	for i := 0; i < 100; i++ {
+		q.PopBack()
+		if !q.AllSlotsAboveTopAreZero() {
+			t.Fatalf("Stale reference left after %d PopBack calls", i+1)
+		}
+	}
+}
+
+func TestWrappedPopsReleaseSlots(t *testing.T) {
+	q := newPointerDeque(8)
+	for i := 0; i < 6; i++ {
+		q.PopFront()
+	}
+	for i := 0; i < 4; i++ {
+		v := i
+		q.PushBack(&v)
+	}
+	for !q.Empty() {
+		q.PopBack()
+		if !q.AllSlotsAboveTopAreZero() {
+			t.Fatal("Stale reference left after PopBack across the wrap point")
+		}
+	}
+}
+
+func TestConditionalPopsReleaseSlots(t *testing.T) {
+	q := newPointerDeque(10)
+	always := func(*int) bool { return true }
+	for i := 0; i < 5; i++ {
+		q.PopFrontIf(always)
+		q.PopBackIf(always)
+	}
+	if !q.Empty() || !q.AllSlotsAboveTopAreZero() {
+		t.Error("PopFrontIf/PopBackIf should release popped slots")
+	}
+}
+
+func TestBatchPopsReleaseSlots(t *testing.T) {
+	q := newPointerDeque(20)
+	q.PopNFront(7)
+	q.PopNBack(7)
+	if q.Len() != 6 || !q.AllSlotsAboveTopAreZero() {
+		t.Error("PopNFront/PopNBack should release popped slots")
+	}
+}
+
+func TestPopWhileReleasesSlots(t *testing.T) {
+	q := newPointerDeque(20)
+	q.PopFrontWhile(func(v *int) bool { return *v < 5 })
+	q.PopBackWhile(func(v *int) bool { return *v >= 15 })
+	if q.Len() != 10 || !q.AllSlotsAboveTopAreZero() {
+		t.Error("PopFrontWhile/PopBackWhile should release popped slots")
+	}
+}
+
+func TestSortedRemoveReleasesSlots(t *testing.T) {
+	q := newPointerDeque(20)
+	cmpPtr := func(a, b *int) int { return *a - *b }
+	for _, v := range []int{1, 18, 5, 12} {
+		if !q.SortedRemove(&v, cmpPtr) {
+			t.Fatalf("SortedRemove(%d) should succeed", v)
+		}
+	}
+	if q.Len() != 16 || !q.AllSlotsAboveTopAreZero() {
+		t.Error("SortedRemove should release vacated slots on both sides")
+	}
+}
+
+func TestFilterInPlaceReleasesSlots(t *testing.T) {
+	q := newPointerDeque(20)
+	q.PopFront()
+	q.PushBack(new(int))
+	q.FilterInPlace(func(v *int) bool { return *v%4 == 1 })
+	if q.Len() != 5 || !q.AllSlotsAboveTopAreZero() {
+		t.Error("FilterInPlace should release removed slots")
+	}
+}
+
+func TestCompactReleasesSlots(t *testing.T) {
+	q := newPointerDeque(20)
+	q.PopFront()
+	q.PushBack(new(int))
+	q.Compact(func(a, b *int) bool { return *a/2 == *b/2 })
+	if q.Len() != 11 || !q.AllSlotsAboveTopAreZero() {
+		t.Error("Compact should release removed slots")
+	}
+}
+
+func TestClearReleasesSlots(t *testing.T) {
+	q := newPointerDeque(50)
+	q.Clear()
+	if !q.AllSlotsAboveTopAreZero() {
+		t.Error("Clear should release all slots")
+	}
+}
+
+func TestSortReleasesSlots(t *testing.T) {
+	q := newPointerDeque(20)
+	for i := 0; i < 10; i++ {
+		q.PopFront()
+	}
+	q.Sort(func(a, b *int) bool { return *a > *b })
+	if front, _ := q.Front(); q.Len() != 10 || *front != 19 || !q.AllSlotsAboveTopAreZero() {
+		t.Error("Sort should not leave stale copies behind the linearized elements")
+	}
+}
+
+func TestRemoveRangeReleasesSlots(t *testing.T) {
+	q := newPointerDeque(20)
+	q.RemoveRange(2, 6)
+	q.RemoveRange(10, 15)
+	q.Remove(3)
+	if q.Len() != 10 || !q.AllSlotsAboveTopAreZero() {
+		t.Error("Remove and RemoveRange should release vacated slots on both sides")
+	}
+}
+
+func TestUnsafeDequePopsReleaseSlots(t *testing.T) {
+	q := NewUnsafeDeque[*int]()
+	for i := 0; i < 20; i++ {
+		v := i
+		q.PushBack(&v)
+		q.PushFront(&v)
+	}
+	for i := 0; i < 15; i++ {
+		q.PopFront()
+		q.PopBack()
+	}
+	if !q.AllSlotsAboveTopAreZero() {
+		t.Error("UnsafeDeque pops should release popped slots")
+	}
+	q.Insert(3, nil)
+	q.RemoveRange(1, 4)
+	q.FilterInPlace(func(p *int) bool { return p != nil && *p%2 == 0 })
+	if !q.AllSlotsAboveTopAreZero() {
+		t.Error("UnsafeDeque removals should release vacated slots")
+	}
+}
+
+func TestUnsafeDequeMirrorsDequeMethods(t *testing.T) {
+	safe := reflect.TypeOf(&Deque[int]{})
+	unsafe := reflect.TypeOf(&UnsafeDeque[int]{})
+	if safe.NumMethod() != unsafe.NumMethod() {
+		t.Errorf("Deque has %d methods but UnsafeDeque has %d", safe.NumMethod(), unsafe.NumMethod())
+	}
+	for i := 0; i < safe.NumMethod(); i++ {
+		m := safe.Method(i)
+		um, ok := unsafe.MethodByName(m.Name)
+		if !ok {
+			t.Errorf("UnsafeDeque is missing %s", m.Name)
+			continue
+		}
+		// Compare signatures without the receiver, with *Deque standing for *UnsafeDeque
+		want := strings.ReplaceAll(m.Type.String(), "*Deque.Deque[", "*Deque.UnsafeDeque[")
+		want = strings.Replace(want, "(*Deque.UnsafeDeque[int]", "(", 1)
+		got := strings.Replace(um.Type.String(), "(*Deque.UnsafeDeque[int]", "(", 1)
+		if got != want {
+			t.Errorf("%s: UnsafeDeque signature %s does not match Deque's %s", m.Name, got, want)
+		}
+	}
+}
@@ -0,0 +1,149 @@
+package Deque
+
+import (
+	"sync/atomic"
+
+	iterator "GoSTL/Iterator"
+)
+
+// Compile-time check that Cursor implements the iteration protocol shared by the GoSTL containers.
+var _ iterator.IndexedIterator[int] = (*Cursor[int])(nil)
+
+// Iterator walks the elements of a Deque by position, forwards or backwards.
+// It records the backing array, the front and the length of the deque when it is created, in O(1) and without
+// copying, and walks that range of the array. Elements pushed afterwards are never visited, and growing or
+// shrinking the deque moves it to new storage without disturbing the iterator. Elements popped, overwritten or
+// reordered in place after creation may be observed; a popped element reads as the zero value until its slot is
+// reused. The deque stays fully usable while it is iterated.
+//
+// Forward iterators come from Begin and End, reverse iterators from RBegin and REnd; for a reverse iterator
+// Next moves towards the front. A typical loop is
+//
+//	for it := d.Begin(); it.Valid(); it.Next() {
+//		use(it.Index(), it.Value())
+//	}
+type Iterator[T any] struct {
+	data   []T // backing array of the deque when the iterator was created
+	front  int // index in data of the front element at creation
+	length int // number of elements at creation
+	pos    int // logical index from the front, from -1 (before the front) to length (past the back)
+	step   int // +1 for forward iterators, -1 for reverse iterators
+}
+
+// newIterator creates an iterator over the current elements of q positioned at pos, moving by step on Next.
+// The backing array, front and length are read under the mutex, so they describe a single state of the deque.
+func (q *Deque[T]) newIterator(pos func(length int) int, step int) *Iterator[T] {
+	q.checkNil()
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	length := int(atomic.LoadInt32(&q.length))
+	return &Iterator[T]{
+		data:   q.currentData(),
+		front:  int(atomic.LoadInt32(&q.front)),
+		length: length,
+		pos:    pos(length),
+		step:   step,
+	}
+}
+
+// Begin returns a forward iterator positioned at the front element (invalid if the deque is empty).
+func (q *Deque[T]) Begin() *Iterator[T] {
+	return q.newIterator(func(int) int { return 0 }, 1)
+}
+
+// End returns a forward iterator positioned just past the back element. It is not valid;
+// calling Prev moves it onto the back element.
+func (q *Deque[T]) End() *Iterator[T] {
+	return q.newIterator(func(length int) int { return length }, 1)
+}
+
+// RBegin returns a reverse iterator positioned at the back element (invalid if the deque is empty).
+func (q *Deque[T]) RBegin() *Iterator[T] {
+	return q.newIterator(func(length int) int { return length - 1 }, -1)
+}
+
+// REnd returns a reverse iterator positioned just before the front element. It is not valid;
+// calling Prev moves it onto the front element.
+func (q *Deque[T]) REnd() *Iterator[T] {
+	return q.newIterator(func(int) int { return -1 }, -1)
+}
+
+// move shifts the position by delta, clamped to the before-front and past-back positions,
+// and returns the element at the new position.
+func (it *Iterator[T]) move(delta int) (T, bool) {
+	it.pos = min(max(it.pos+delta, -1), it.length)
+	return it.current()
+}
+
+// current returns the element at the iterator's position, or false if the position is not valid.
+func (it *Iterator[T]) current() (T, bool) {
+	if !it.Valid() {
+		var zero T
+		return zero, false
+	}
+	return it.data[(it.front+it.pos)%len(it.data)], true
+}
+
+// Next advances the iterator one element in its direction and returns the element it now points to.
+// Returns false once the iterator has moved past the last element.
+func (it *Iterator[T]) Next() (T, bool) {
+	return it.move(it.step)
+}
+
+// Prev moves the iterator one element against its direction and returns the element it now points to.
+// Returns false once the iterator has moved before the first element.
+func (it *Iterator[T]) Prev() (T, bool) {
+	return it.move(-it.step)
+}
+
+// Value returns the element the iterator points to, or the zero value if the iterator is not valid.
+func (it *Iterator[T]) Value() T {
+	val, _ := it.current()
+	return val
+}
+
+// Index returns the position of the current element in the deque, counted from the front,
+// for both forward and reverse iterators. It is -1 or Len() when the iterator is not valid.
+func (it *Iterator[T]) Index() int {
+	return it.pos
+}
+
+// Valid reports whether the iterator points to an element.
+func (it *Iterator[T]) Valid() bool {
+	return it.pos >= 0 && it.pos < it.length
+}
+
+// Cursor adapts an Iterator to the Iterator.IndexedIterator protocol shared by the GoSTL containers, so that
+// Deque iterators work with the adapters of the Iterator package. The first call to Next reports whether the
+// underlying iterator points to an element; later calls advance it in its direction.
+type Cursor[T any] struct {
+	it      *Iterator[T] // underlying iterator, advanced by Next
+	started bool         // true once Next has been called
+}
+
+// Cursor returns a Cursor starting at the iterator's current position. The cursor advances it
+// as it goes, so the iterator should not be moved directly while the cursor is in use.
+func (it *Iterator[T]) Cursor() *Cursor[T] {
+	return &Cursor[T]{it: it}
+}
+
+// Next moves to the next element and reports whether there is one.
+func (c *Cursor[T]) Next() bool {
+	if !c.started {
+		c.started = true
+		return c.it.Valid()
+	}
+	_, ok := c.it.Next()
+	return ok
+}
+
+// Value returns the current element, or the zero value if there is none.
+func (c *Cursor[T]) Value() T {
+	return c.it.Value()
+}
+
+// Index returns the position of the current element in the deque, counted from the front.
+func (c *Cursor[T]) Index() int {
+	return c.it.Index()
+}
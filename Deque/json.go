@@ -0,0 +1,55 @@
+package Deque
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync/atomic"
+	"unsafe"
+)
+
+// MarshalJSON implements json.Marshaler, encoding the elements as a JSON array in front-to-back order.
+// The elements are snapshotted under the mutex and encoded with json.Marshal, whose errors are returned as is.
+func (q *Deque[T]) MarshalJSON() ([]byte, error) {
+	q.checkNil()
+	q.mu.Lock()
+	elems := q.snapshotLocked()
+	q.mu.Unlock()
+	return json.Marshal(elems)
+}
+
+// UnmarshalJSON implements json.Unmarshaler, replacing the contents of the deque with the elements of a JSON array,
+// the first element becoming the front. The array is decoded with json.Unmarshal before the deque is touched,
+// so the deque is left unchanged on error or if the elements exceed the maximum capacity set by WithMaxCapacity.
+// A JSON null empties the deque.
+func (q *Deque[T]) UnmarshalJSON(b []byte) error {
+	q.checkNil()
+	var elems []T
+	if err := json.Unmarshal(b, &elems); err != nil {
+		return err
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.maxCap > 0 && len(elems) > q.maxCap {
+		return fmt.Errorf("Deque: %d elements exceed max capacity %d", len(elems), q.maxCap)
+	}
+	if q.initCap <= 0 {
+		q.initCap = 8
+	}
+	capacity := max(q.initCap, len(elems))
+	if q.maxCap > 0 && capacity > q.maxCap {
+		capacity = q.maxCap
+	}
+	data := make([]T, capacity)
+	copy(data, elems)
+
+	header := (*sliceHeader)(unsafe.Pointer(&data))
+	atomic.StorePointer(&q.data, unsafe.Pointer(header))
+	atomic.StoreInt32(&q.front, 0)
+	atomic.StoreInt32(&q.back, int32(len(elems)%capacity))
+	atomic.StoreInt32(&q.length, int32(len(elems)))
+	q.notifyLocked()
+	q.shared.Store(false)
+	q.observeLen(int32(len(elems)))
+	return nil
+}
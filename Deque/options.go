@@ -0,0 +1,91 @@
+package Deque
+
+import (
+	"fmt"
+	"sync/atomic"
+)
+
+// DequeOption configures a Deque created by NewDequeWithData or an UnsafeDeque created by NewUnsafeDequeWithData.
+type DequeOption func(*dequeConfig)
+
+// dequeConfig collects the settings applied by DequeOption values.
+type dequeConfig struct {
+	capacity     int     // requested initial capacity (0 means derive from data)
+	growthFactor float64 // capacity multiplier when full (0 means 2)
+	maxCapacity  int     // hard capacity limit (0 means unbounded)
+}
+
+// WithCapacity sets the initial capacity of the deque.
+// It is ignored if it is not positive or smaller than the initial data.
+func WithCapacity(n int) DequeOption {
+	return func(c *dequeConfig) {
+		c.capacity = n
+	}
+}
+
+// WithGrowthFactor sets the multiplier applied to the capacity whenever the deque is full.
+// Factors not greater than 1 are ignored and the default factor of 2 is used.
+func WithGrowthFactor(f float64) DequeOption {
+	return func(c *dequeConfig) {
+		c.growthFactor = f
+	}
+}
+
+// WithMaxCapacity sets a hard limit on the capacity of the deque; 0 means unbounded.
+// The backing array never grows beyond n, and pushing onto a deque that is full at n panics.
+func WithMaxCapacity(n int) DequeOption {
+	return func(c *dequeConfig) {
+		c.maxCapacity = n
+	}
+}
+
+// NewDequeWithData creates a Deque holding a copy of data, front to back, configured by opts.
+// The capacity is the WithCapacity value if it can hold data, otherwise len(data) rounded up
+// to the next power of two (at least 8), and never more than the WithMaxCapacity limit.
+// Panics if data does not fit within the maximum capacity.
+func NewDequeWithData[T any](data []T, opts ...DequeOption) *Deque[T] {
+	cfg, capacity := configure(len(data), opts)
+	q := &Deque[T]{growth: cfg.growthFactor, maxCap: cfg.maxCapacity}
+	q.Init(capacity)
+	header := (*sliceHeader)(atomic.LoadPointer(&q.data))
+	copy((*[1 << 30]T)(header.data)[:capacity], data)
+	atomic.StoreInt32(&q.back, int32(len(data)%capacity))
+	atomic.StoreInt32(&q.length, int32(len(data)))
+	q.observeLen(int32(len(data)))
+	return q
+}
+
+// NewUnsafeDequeWithData is NewDequeWithData for UnsafeDeque, with the same options and capacity rules.
+func NewUnsafeDequeWithData[T any](data []T, opts ...DequeOption) *UnsafeDeque[T] {
+	cfg, capacity := configure(len(data), opts)
+	q := &UnsafeDeque[T]{growth: cfg.growthFactor, maxCap: cfg.maxCapacity}
+	q.Init(capacity)
+	copy(q.data, data)
+	q.length = len(data)
+	q.maxLen = len(data)
+	return q
+}
+
+// configure applies opts and returns the resulting configuration together with the initial capacity
+// of a deque created with n elements of data. Panics if n exceeds the maximum capacity.
+func configure(n int, opts []DequeOption) (dequeConfig, int) {
+	var cfg dequeConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if cfg.maxCapacity > 0 && n > cfg.maxCapacity {
+		panic(fmt.Sprintf("Deque: %d elements exceed max capacity %d", n, cfg.maxCapacity))
+	}
+
+	capacity := cfg.capacity
+	if capacity <= 0 || capacity < n {
+		capacity = 8
+		for capacity < n {
+			capacity *= 2
+		}
+	}
+	if cfg.maxCapacity > 0 && capacity > cfg.maxCapacity {
+		capacity = cfg.maxCapacity
+	}
+	return cfg, capacity
+}
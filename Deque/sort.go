@@ -0,0 +1,209 @@
+package Deque
+
+import (
+	"cmp"
+	"slices"
+	"sync/atomic"
+)
+
+// SortOrdered sorts the elements of d in ascending order using cmp.Compare.
+// The elements are copied out of the ring buffer, sorted and written back in place under the deque's mutex.
+func SortOrdered[T cmp.Ordered](d *Deque[T]) {
+	d.checkNil()
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.unshareLocked()
+
+	length := int(atomic.LoadInt32(&d.length))
+	if length <= 1 {
+		return
+	}
+
+	header := (*sliceHeader)(atomic.LoadPointer(&d.data))
+	front := int(atomic.LoadInt32(&d.front))
+	capacity := header.cap
+	data := (*[1 << 30]T)(header.data)[:capacity]
+
+	// Fast path: the live elements already form a single slice
+	if front+length <= capacity {
+		slices.Sort(data[front : front+length])
+		return
+	}
+
+	tmp := make([]T, length)
+	for i := range tmp {
+		tmp[i] = data[(front+i)%capacity]
+	}
+	slices.Sort(tmp)
+	for i, val := range tmp {
+		data[(front+i)%capacity] = val
+	}
+}
+
+// BinarySearchOrdered searches for val in d, which must be sorted in ascending order.
+// It returns the position where val is found, or the position where it would be inserted
+// to keep d sorted, and a bool reporting whether val was found.
+func BinarySearchOrdered[T cmp.Ordered](d *Deque[T], val T) (int, bool) {
+	d.checkNil()
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	length := int(atomic.LoadInt32(&d.length))
+	header := (*sliceHeader)(atomic.LoadPointer(&d.data))
+	front := int(atomic.LoadInt32(&d.front))
+	capacity := header.cap
+	data := (*[1 << 30]T)(header.data)[:capacity]
+
+	// Fast path: the live elements already form a single slice
+	if front+length <= capacity {
+		return slices.BinarySearch(data[front:front+length], val)
+	}
+
+	lo, hi := 0, length
+	for lo < hi {
+		mid := int(uint(lo+hi) >> 1)
+		if cmp.Less(data[(front+mid)%capacity], val) {
+			lo = mid + 1
+		} else {
+			hi = mid
+		}
+	}
+	return lo, lo < length && cmp.Compare(data[(front+lo)%capacity], val) == 0
+}
+
+// SortedInsert inserts val into the deque, which must be sorted according to less, keeping it sorted,
+// and returns the index at which val was inserted. The position is found by binary search and val
+// is placed after any elements equal to it; the insertion shifts the shorter side of the deque,
+// so the cost is O(log n) comparisons plus O(n) moves.
+// Panics if the deque is full and already at the maximum capacity set by WithMaxCapacity.
+func (q *Deque[T]) SortedInsert(val T, less func(a, b T) bool) int {
+	q.checkNil()
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	index := q.searchLocked(func(x T) bool { return less(val, x) })
+	if !q.insertAtLocked(index, val) {
+		panic(q.fullMessage())
+	}
+	q.stats.insert.Add(1)
+	return index
+}
+
+// SortedRemove removes the first element equal to val from the deque, which must be sorted according to cmp.
+// The element is located by binary search and removed by shifting the shorter side of the deque.
+// Returns true if an element was removed.
+func (q *Deque[T]) SortedRemove(val T, cmp func(a, b T) int) bool {
+	q.checkNil()
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	index := q.searchLocked(func(x T) bool { return cmp(x, val) >= 0 })
+	if index >= int(atomic.LoadInt32(&q.length)) {
+		return false
+	}
+	header := (*sliceHeader)(atomic.LoadPointer(&q.data))
+	front := int(atomic.LoadInt32(&q.front))
+	if cmp((*[1 << 30]T)(header.data)[(front+index)%header.cap], val) != 0 {
+		return false
+	}
+	q.removeAtLocked(index)
+	q.stats.remove.Add(1)
+	return true
+}
+
+// Sort sorts the elements of the deque in place according to less, which must be a strict weak ordering.
+// The sort is not guaranteed to be stable. Afterwards the elements are linearized at the start of the backing array
+// (front=0); a deque whose elements are already contiguous is sorted without allocating.
+func (q *Deque[T]) Sort(less func(a, b T) bool) {
+	q.checkNil()
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.sortLocked(func(s []T) { slices.SortFunc(s, lessToCmp(less)) })
+}
+
+// SortStable sorts the elements of d in place according to less, keeping equal elements in their original order.
+// Like Sort, it leaves the elements linearized at the start of the backing array.
+func SortStable[T any](d *Deque[T], less func(a, b T) bool) {
+	d.checkNil()
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.sortLocked(func(s []T) { slices.SortStableFunc(s, lessToCmp(less)) })
+}
+
+// lessToCmp adapts a less function to the three-way comparison expected by the slices package.
+func lessToCmp[T any](less func(a, b T) bool) func(a, b T) int {
+	return func(a, b T) int {
+		if less(a, b) {
+			return -1
+		}
+		if less(b, a) {
+			return 1
+		}
+		return 0
+	}
+}
+
+// sortLocked linearizes the elements at the start of the backing array, setting front=0 and back=length,
+// and sorts them with sortFn (must be called with lock held). Contiguous elements are moved in place;
+// only a wrapped deque is copied through a temporary slice.
+func (q *Deque[T]) sortLocked(sortFn func([]T)) {
+	q.unshareLocked()
+
+	length := int(atomic.LoadInt32(&q.length))
+	header := (*sliceHeader)(atomic.LoadPointer(&q.data))
+	front := int(atomic.LoadInt32(&q.front))
+	capacity := header.cap
+	data := (*[1 << 30]T)(header.data)[:capacity]
+
+	if front+length <= capacity {
+		if front > 0 {
+			copy(data, data[front:front+length])
+			clear(data[max(length, front) : front+length])
+		}
+	} else {
+		tmp := q.snapshotLocked()
+		copy(data, tmp)
+		clear(data[length:])
+	}
+	sortFn(data[:length])
+	atomic.StoreInt32(&q.front, 0)
+	atomic.StoreInt32(&q.back, int32(length%capacity))
+}
+
+// BinarySearch searches for target in the deque, which must be sorted in ascending order according to cmp.
+// It returns the index of the first element equal to target, or the index where target would be inserted
+// to keep the deque sorted, and whether target was found. The search runs directly on the ring buffer,
+// mapping each logical index to its physical slot, so it needs O(log n) comparisons and no copying.
+func (q *Deque[T]) BinarySearch(target T, cmp func(a, b T) int) (index int, found bool) {
+	q.checkNil()
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	index = q.searchLocked(func(x T) bool { return cmp(x, target) >= 0 })
+	if index >= int(atomic.LoadInt32(&q.length)) {
+		return index, false
+	}
+	header := (*sliceHeader)(atomic.LoadPointer(&q.data))
+	front := int(atomic.LoadInt32(&q.front))
+	return index, cmp((*[1 << 30]T)(header.data)[(front+index)%header.cap], target) == 0
+}
+
+// LowerBound returns the index of the first element not less than target in the deque, which must be sorted
+// in ascending order according to cmp, or Len() if there is none. Like sort.Search, it is the first position
+// at which target could be inserted while keeping the deque sorted.
+func (q *Deque[T]) LowerBound(target T, cmp func(a, b T) int) int {
+	q.checkNil()
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.searchLocked(func(x T) bool { return cmp(x, target) >= 0 })
+}
+
+// UpperBound returns the index of the first element greater than target in the deque, which must be sorted
+// in ascending order according to cmp, or Len() if there is none. It is the last position at which target
+// could be inserted while keeping the deque sorted, so UpperBound-LowerBound counts the elements equal to target.
+func (q *Deque[T]) UpperBound(target T, cmp func(a, b T) int) int {
+	q.checkNil()
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.searchLocked(func(x T) bool { return cmp(x, target) > 0 })
+}
@@ -0,0 +1,1308 @@
+package Deque
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"iter"
+	"slices"
+	"strings"
+
+	"GoSTL/internal/codec"
+)
+
+// UnsafeDeque is a double-ended queue with the same ring-buffer layout and the same methods as Deque, but without
+// any synchronization: fields are read and written directly, with no atomics and no mutex.
+// It is meant for hot paths owned by a single goroutine.
+//
+// Every method behaves like its Deque counterpart, with a few consequences of the missing lock: callbacks may use
+// the deque only where Deque allows it, COWSnapshot copies the elements eagerly, and the context-aware operations
+// cannot be woken by another goroutine, so they return ErrFull or ErrEmpty instead of waiting.
+// The package-level helpers such as Map, Reduce and Zip take a *Deque.
+//
+// UnsafeDeque is NOT safe for concurrent use. Calling its methods from more than one goroutine without external
+// synchronization is undefined behavior: it is a data race and can corrupt the deque.
+type UnsafeDeque[T any] struct {
+	data    []T            // ring buffer; len(data) is the capacity
+	front   int            // index of the front element
+	length  int            // number of elements
+	initCap int            // initial capacity
+	growth  float64        // capacity multiplier applied when full (0 means 2)
+	maxCap  int            // hard capacity limit (0 means unbounded)
+	maxLen  int            // historical maximum length reported by MaxLen
+	stats   unsafeCounters // operation counters reported by Stats
+}
+
+// unsafeCounters holds the plain counters behind an UnsafeDeque's DequeStats.
+type unsafeCounters struct {
+	pushFront int64
+	pushBack  int64
+	popFront  int64
+	popBack   int64
+	insert    int64
+	remove    int64
+	resize    int64
+}
+
+// NewUnsafeDeque creates and initializes a new UnsafeDeque with optional initial capacity, like NewDeque.
+func NewUnsafeDeque[T any](initCap ...int) *UnsafeDeque[T] {
+	q := &UnsafeDeque[T]{}
+	capacity := 8
+	if len(initCap) > 0 && initCap[0] > 0 {
+		capacity = initCap[0]
+	}
+	q.Init(capacity)
+	return q
+}
+
+// Init initializes or resets the deque with a capacity of exactly n.
+// A non-positive n falls back to the default capacity of 8.
+// On a bounded deque the capacity is limited to the maximum capacity set by WithMaxCapacity.
+func (q *UnsafeDeque[T]) Init(n int) {
+	capacity := 8
+	if n > 0 {
+		capacity = n
+	}
+	if q.maxCap > 0 && capacity > q.maxCap {
+		capacity = q.maxCap
+	}
+	q.initCap = capacity
+	q.data = make([]T, capacity)
+	q.front = 0
+	q.length = 0
+}
+
+// Len returns the number of elements in the deque.
+func (q *UnsafeDeque[T]) Len() int {
+	return q.length
+}
+
+// Empty returns true if the deque contains no elements.
+func (q *UnsafeDeque[T]) Empty() bool {
+	return q.length == 0
+}
+
+// Capacity returns the current capacity of the deque.
+func (q *UnsafeDeque[T]) Capacity() int {
+	return len(q.data)
+}
+
+// slot maps logical index i (0 <= i < capacity) to its position in the ring buffer.
+func (q *UnsafeDeque[T]) slot(i int) int {
+	i += q.front
+	if i >= len(q.data) {
+		i -= len(q.data)
+	}
+	return i
+}
+
+// runs returns the elements as at most two slices of the ring buffer in front-to-back order;
+// the second one is empty unless the elements wrap around the end of the buffer.
+func (q *UnsafeDeque[T]) runs() (head, tail []T) {
+	end := min(q.front+q.length, len(q.data))
+	return q.data[q.front:end], q.data[:q.length-(end-q.front)]
+}
+
+// resize moves the elements to the start of a new ring buffer of newCap slots.
+func (q *UnsafeDeque[T]) resize(newCap int) {
+	data := make([]T, newCap)
+	head, tail := q.runs()
+	copy(data[copy(data, head):], tail)
+	q.data = data
+	q.front = 0
+}
+
+// grow enlarges the ring buffer by the growth factor, capped at maxCap.
+// Returns false if the deque is already at its maximum capacity.
+func (q *UnsafeDeque[T]) grow() bool {
+	capacity := len(q.data)
+	if q.maxCap > 0 && capacity >= q.maxCap {
+		return false
+	}
+
+	factor := q.growth
+	if factor <= 1 {
+		factor = 2
+	}
+	newCap := int(float64(capacity) * factor)
+	if capacity == 0 {
+		newCap = max(q.initCap, 8)
+	} else if newCap <= capacity {
+		newCap = capacity + 1
+	}
+	if q.maxCap > 0 && newCap > q.maxCap {
+		newCap = q.maxCap
+	}
+
+	q.resize(newCap)
+	q.stats.resize++
+	return true
+}
+
+// reserve makes room for n more elements, resizing the ring buffer at most once, to the first capacity along
+// the growth sequence that fits them all. Returns false without resizing if they would not fit within maxCap.
+func (q *UnsafeDeque[T]) reserve(n int) bool {
+	needed := q.length + n
+	if q.maxCap > 0 && needed > q.maxCap {
+		return false
+	}
+	if needed <= len(q.data) {
+		return true
+	}
+
+	factor := q.growth
+	if factor <= 1 {
+		factor = 2
+	}
+	newCap := max(len(q.data), q.initCap, 1)
+	for newCap < needed {
+		newCap = max(int(float64(newCap)*factor), newCap+1)
+	}
+	if q.maxCap > 0 && newCap > q.maxCap {
+		newCap = q.maxCap
+	}
+	q.resize(newCap)
+	q.stats.resize++
+	return true
+}
+
+// fullMessage describes a push onto a deque that is full at its maximum capacity.
+func (q *UnsafeDeque[T]) fullMessage() string {
+	return fmt.Sprintf("Deque: push on full deque, max capacity %d", q.maxCap)
+}
+
+// observeLen raises the recorded maximum length to the current length if it exceeds it.
+func (q *UnsafeDeque[T]) observeLen() {
+	if q.length > q.maxLen {
+		q.maxLen = q.length
+	}
+}
+
+// PushBack adds an element to the back of the deque.
+// Panics if the deque is full and already at the maximum capacity set by WithMaxCapacity.
+func (q *UnsafeDeque[T]) PushBack(val T) {
+	if !q.pushBack(val) {
+		panic(q.fullMessage())
+	}
+}
+
+// PushBackOrDiscard adds an element to the back of the deque unless the deque is full at the maximum
+// capacity set by WithMaxCapacity, in which case val is discarded. Returns true if val was pushed.
+func (q *UnsafeDeque[T]) PushBackOrDiscard(val T) bool {
+	return q.pushBack(val)
+}
+
+// pushBack adds val to the back, growing the deque if needed.
+// Returns false without pushing if the deque is full at its maximum capacity.
+func (q *UnsafeDeque[T]) pushBack(val T) bool {
+	if q.length == len(q.data) && !q.grow() {
+		return false
+	}
+	q.data[q.slot(q.length)] = val
+	q.length++
+	q.observeLen()
+	q.stats.pushBack++
+	return true
+}
+
+// PushFront adds an element to the front of the deque.
+// Panics if the deque is full and already at the maximum capacity set by WithMaxCapacity.
+func (q *UnsafeDeque[T]) PushFront(val T) {
+	if !q.pushFront(val) {
+		panic(q.fullMessage())
+	}
+}
+
+// PushFrontOrDiscard adds an element to the front of the deque unless the deque is full at the maximum
+// capacity set by WithMaxCapacity, in which case val is discarded. Returns true if val was pushed.
+func (q *UnsafeDeque[T]) PushFrontOrDiscard(val T) bool {
+	return q.pushFront(val)
+}
+
+// pushFront adds val to the front, growing the deque if needed.
+// Returns false without pushing if the deque is full at its maximum capacity.
+func (q *UnsafeDeque[T]) pushFront(val T) bool {
+	if q.length == len(q.data) && !q.grow() {
+		return false
+	}
+	q.front--
+	if q.front < 0 {
+		q.front += len(q.data)
+	}
+	q.data[q.front] = val
+	q.length++
+	q.observeLen()
+	q.stats.pushFront++
+	return true
+}
+
+// PopFront removes and returns the front element.
+// The slot is cleared, so the deque keeps no reference to the popped element.
+func (q *UnsafeDeque[T]) PopFront() (T, bool) {
+	var zero T
+	if q.length == 0 {
+		return zero, false
+	}
+	val := q.data[q.front]
+	q.data[q.front] = zero
+	q.front = q.slot(1)
+	q.length--
+	q.stats.popFront++
+	return val, true
+}
+
+// PopBack removes and returns the back element.
+// The slot is cleared, so the deque keeps no reference to the popped element.
+func (q *UnsafeDeque[T]) PopBack() (T, bool) {
+	var zero T
+	if q.length == 0 {
+		return zero, false
+	}
+	pos := q.slot(q.length - 1)
+	val := q.data[pos]
+	q.data[pos] = zero
+	q.length--
+	q.stats.popBack++
+	return val, true
+}
+
+// ErrFull is returned by UnsafeDeque.PushBackWithContext when the deque is full at its maximum capacity.
+var ErrFull = errors.New("Deque: deque is full")
+
+// ErrEmpty is returned by UnsafeDeque.PopFrontWithContext when the deque is empty.
+var ErrEmpty = errors.New("Deque: deque is empty")
+
+// PushBackWithContext adds an element to the back of the deque like PushBackOrDiscard, returning ctx.Err()
+// without pushing if ctx is already done. No other goroutine can free space in an UnsafeDeque, so waiting could
+// never succeed: on a deque that is full at the maximum capacity it returns ErrFull at once.
+func (q *UnsafeDeque[T]) PushBackWithContext(ctx context.Context, val T) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if !q.pushBack(val) {
+		return ErrFull
+	}
+	return nil
+}
+
+// PopFrontWithContext removes and returns the front element, returning ctx.Err() without popping if ctx is
+// already done. No other goroutine can push onto an UnsafeDeque, so waiting could never succeed: on an empty
+// deque it returns ErrEmpty at once.
+func (q *UnsafeDeque[T]) PopFrontWithContext(ctx context.Context) (T, error) {
+	var zero T
+	if err := ctx.Err(); err != nil {
+		return zero, err
+	}
+	val, ok := q.PopFront()
+	if !ok {
+		return zero, ErrEmpty
+	}
+	return val, nil
+}
+
+// PopFrontIf removes and returns the front element only if pred reports true for it.
+func (q *UnsafeDeque[T]) PopFrontIf(pred func(T) bool) (T, bool) {
+	if q.length == 0 || !pred(q.data[q.front]) {
+		var zero T
+		return zero, false
+	}
+	return q.PopFront()
+}
+
+// PopBackIf removes and returns the back element only if pred reports true for it.
+func (q *UnsafeDeque[T]) PopBackIf(pred func(T) bool) (T, bool) {
+	if q.length == 0 || !pred(q.data[q.slot(q.length-1)]) {
+		var zero T
+		return zero, false
+	}
+	return q.PopBack()
+}
+
+// PopNFront removes up to n elements from the front of the deque and returns them in front-to-back order.
+// Fewer than n are returned if the deque is shorter.
+func (q *UnsafeDeque[T]) PopNFront(n int) []T {
+	result := make([]T, max(min(n, q.length), 0))
+	for i := range result {
+		result[i], _ = q.PopFront()
+	}
+	return result
+}
+
+// PopNBack removes up to n elements from the back of the deque and returns them in back-to-front order,
+// so the most recently pushed element comes first. Fewer than n are returned if the deque is shorter.
+func (q *UnsafeDeque[T]) PopNBack(n int) []T {
+	result := make([]T, max(min(n, q.length), 0))
+	for i := range result {
+		result[i], _ = q.PopBack()
+	}
+	return result
+}
+
+// PopFrontWhile pops elements from the front for as long as pred reports true for the current front element,
+// and returns them in front-to-back order. Returns an empty, non-nil slice if the front element does not match.
+func (q *UnsafeDeque[T]) PopFrontWhile(pred func(T) bool) []T {
+	result := []T{}
+	for {
+		val, ok := q.PopFrontIf(pred)
+		if !ok {
+			return result
+		}
+		result = append(result, val)
+	}
+}
+
+// PopBackWhile pops elements from the back for as long as pred reports true for the current back element,
+// and returns them in back-to-front order. Returns an empty, non-nil slice if the back element does not match.
+func (q *UnsafeDeque[T]) PopBackWhile(pred func(T) bool) []T {
+	result := []T{}
+	for {
+		val, ok := q.PopBackIf(pred)
+		if !ok {
+			return result
+		}
+		result = append(result, val)
+	}
+}
+
+// PeekFrontN returns copies of up to n elements from the front of the deque in front-to-back order
+// without removing them.
+func (q *UnsafeDeque[T]) PeekFrontN(n int) []T {
+	result := make([]T, max(min(n, q.length), 0))
+	for i := range result {
+		result[i] = q.data[q.slot(i)]
+	}
+	return result
+}
+
+// PeekBackN returns copies of up to n elements from the back of the deque in back-to-front order,
+// matching the order PopNBack would return them, without removing them.
+func (q *UnsafeDeque[T]) PeekBackN(n int) []T {
+	result := make([]T, max(min(n, q.length), 0))
+	for i := range result {
+		result[i] = q.data[q.slot(q.length-1-i)]
+	}
+	return result
+}
+
+// Front returns the front element without removing it.
+func (q *UnsafeDeque[T]) Front() (T, bool) {
+	return q.At(0)
+}
+
+// Back returns the back element without removing it.
+func (q *UnsafeDeque[T]) Back() (T, bool) {
+	return q.At(-1)
+}
+
+// At returns the element at the specified index. Negative indices count from the back.
+func (q *UnsafeDeque[T]) At(index int) (T, bool) {
+	if index < 0 {
+		index += q.length
+	}
+	if index < 0 || index >= q.length {
+		var zero T
+		return zero, false
+	}
+	return q.data[q.slot(index)], true
+}
+
+// Set sets the element at the specified index to the given value. Negative indices count from the back.
+// Returns false if index is out of range.
+func (q *UnsafeDeque[T]) Set(index int, value T) bool {
+	if index < 0 {
+		index += q.length
+	}
+	if index < 0 || index >= q.length {
+		return false
+	}
+	q.data[q.slot(index)] = value
+	return true
+}
+
+// TrySet replaces the element at the specified index with value only if it currently equals old according to eq.
+// Returns false if the index is out of range or the slot does not hold old.
+func (q *UnsafeDeque[T]) TrySet(index int, old, value T, eq func(a, b T) bool) bool {
+	if index < 0 {
+		index += q.length
+	}
+	if index < 0 || index >= q.length {
+		return false
+	}
+	pos := q.slot(index)
+	if !eq(q.data[pos], old) {
+		return false
+	}
+	q.data[pos] = value
+	return true
+}
+
+// GetAndSet replaces the element at index with newVal and returns the element it replaced.
+// Negative indices count from the back. Returns false and leaves the deque unchanged if index is out of range.
+func (q *UnsafeDeque[T]) GetAndSet(index int, newVal T) (old T, ok bool) {
+	if index < 0 {
+		index += q.length
+	}
+	if index < 0 || index >= q.length {
+		return old, false
+	}
+	pos := q.slot(index)
+	old, q.data[pos] = q.data[pos], newVal
+	return old, true
+}
+
+// Swap swaps the elements at the specified indices. Negative indices count from the back.
+// Returns false if i == j or either index is out of range.
+func (q *UnsafeDeque[T]) Swap(i, j int) bool {
+	if i == j {
+		return false
+	}
+	if i < 0 {
+		i += q.length
+	}
+	if j < 0 {
+		j += q.length
+	}
+	if i < 0 || i >= q.length || j < 0 || j >= q.length {
+		return false
+	}
+	pi, pj := q.slot(i), q.slot(j)
+	q.data[pi], q.data[pj] = q.data[pj], q.data[pi]
+	return true
+}
+
+// reverseRange reverses the elements at the logical indices [from, to).
+func (q *UnsafeDeque[T]) reverseRange(from, to int) {
+	for i, j := from, to-1; i < j; i, j = i+1, j-1 {
+		pi, pj := q.slot(i), q.slot(j)
+		q.data[pi], q.data[pj] = q.data[pj], q.data[pi]
+	}
+}
+
+// Rotate rotates the deque by n positions to the right (positive n) or left (negative n).
+func (q *UnsafeDeque[T]) Rotate(n int) {
+	if q.length <= 1 {
+		return
+	}
+	n %= q.length
+	if n < 0 {
+		n += q.length
+	}
+	if n == 0 {
+		return
+	}
+	q.reverseRange(0, q.length)
+	q.reverseRange(0, n)
+	q.reverseRange(n, q.length)
+}
+
+// Reverse reverses the order of elements in the deque.
+func (q *UnsafeDeque[T]) Reverse() {
+	q.reverseRange(0, q.length)
+}
+
+// insertAt inserts val before the element at logical index (0 <= index <= Len()), shifting whichever side of the
+// deque holds fewer elements. Grows the ring buffer if it is full and returns false without inserting if the
+// deque is already at its maximum capacity.
+func (q *UnsafeDeque[T]) insertAt(index int, val T) bool {
+	if q.length == len(q.data) && !q.grow() {
+		return false
+	}
+	capacity := len(q.data)
+	if index < q.length/2 {
+		// Move the elements before index one slot towards the front
+		q.front = (q.front - 1 + capacity) % capacity
+		for i := 0; i < index; i++ {
+			q.data[q.slot(i)] = q.data[q.slot(i+1)]
+		}
+	} else {
+		// Move the elements from index onwards one slot towards the back
+		for i := q.length; i > index; i-- {
+			q.data[q.slot(i)] = q.data[q.slot(i-1)]
+		}
+	}
+	q.data[q.slot(index)] = val
+	q.length++
+	q.observeLen()
+	return true
+}
+
+// removeAt removes and returns the element at logical index (0 <= index < Len()), shifting whichever side of the
+// deque holds fewer elements and zeroing the vacated slot.
+func (q *UnsafeDeque[T]) removeAt(index int) T {
+	var zero T
+	val := q.data[q.slot(index)]
+	if index < q.length/2 {
+		// Close the gap by moving the elements before index one slot towards the back
+		for i := index; i > 0; i-- {
+			q.data[q.slot(i)] = q.data[q.slot(i-1)]
+		}
+		q.data[q.front] = zero
+		q.front = q.slot(1)
+	} else {
+		// Close the gap by moving the elements after index one slot towards the front
+		for i := index; i < q.length-1; i++ {
+			q.data[q.slot(i)] = q.data[q.slot(i+1)]
+		}
+		q.data[q.slot(q.length-1)] = zero
+	}
+	q.length--
+	return val
+}
+
+// Insert inserts val before the element currently at index, so that val ends up at index, shifting whichever
+// side of the deque holds fewer elements. Index Len() appends at the back, and negative indices count from the back.
+// Returns false and leaves the deque unchanged if index is out of range or the deque is full at the maximum
+// capacity set by WithMaxCapacity.
+func (q *UnsafeDeque[T]) Insert(index int, val T) bool {
+	if index < 0 {
+		index += q.length
+	}
+	if index < 0 || index > q.length {
+		return false
+	}
+	if !q.insertAt(index, val) {
+		return false
+	}
+	q.stats.insert++
+	return true
+}
+
+// InsertSlice inserts vals, in order, before the element currently at index. The ring buffer is resized at most
+// once and the shorter side of the deque is shifted by len(vals) slots. Index handling and the result are as for
+// Insert; inserting an empty slice at a valid index returns true.
+func (q *UnsafeDeque[T]) InsertSlice(index int, vals []T) bool {
+	if index < 0 {
+		index += q.length
+	}
+	if index < 0 || index > q.length {
+		return false
+	}
+	if len(vals) == 0 {
+		return true
+	}
+	if !q.reserve(len(vals)) {
+		return false
+	}
+
+	capacity := len(q.data)
+	k := len(vals)
+	if index < q.length/2 {
+		// Move the elements before index k slots towards the front
+		q.front = (q.front - k%capacity + capacity) % capacity
+		for i := 0; i < index; i++ {
+			q.data[q.slot(i)] = q.data[q.slot(i+k)]
+		}
+	} else {
+		// Move the elements from index onwards k slots towards the back
+		for i := q.length - 1; i >= index; i-- {
+			q.data[q.slot(i+k)] = q.data[q.slot(i)]
+		}
+	}
+	for j, val := range vals {
+		q.data[q.slot(index+j)] = val
+	}
+	q.length += k
+	q.observeLen()
+	q.stats.insert += int64(k)
+	return true
+}
+
+// Remove removes and returns the element at index, shifting whichever side of the deque holds fewer elements
+// to close the gap. Negative indices count from the back. Returns false if index is out of range.
+func (q *UnsafeDeque[T]) Remove(index int) (T, bool) {
+	if index < 0 {
+		index += q.length
+	}
+	if index < 0 || index >= q.length {
+		var zero T
+		return zero, false
+	}
+	q.stats.remove++
+	return q.removeAt(index), true
+}
+
+// RemoveRange removes the elements in the half-open range [from, to), shifting whichever side of the range holds
+// fewer elements and zeroing the vacated slots. Negative indices count from the back. Returns false and leaves the
+// deque unchanged unless 0 <= from <= to <= Len() after adjustment; an empty range returns true.
+func (q *UnsafeDeque[T]) RemoveRange(from, to int) bool {
+	if from < 0 {
+		from += q.length
+	}
+	if to < 0 {
+		to += q.length
+	}
+	if from < 0 || from > to || to > q.length {
+		return false
+	}
+	k := to - from
+	if k == 0 {
+		return true
+	}
+
+	var zero T
+	if from < q.length-to {
+		// Close the gap by moving the elements before from k slots towards the back
+		for i := from - 1; i >= 0; i-- {
+			q.data[q.slot(i+k)] = q.data[q.slot(i)]
+		}
+		for i := 0; i < k; i++ {
+			q.data[q.slot(i)] = zero
+		}
+		q.front = q.slot(k)
+	} else {
+		// Close the gap by moving the elements from to onwards k slots towards the front
+		for i := to; i < q.length; i++ {
+			q.data[q.slot(i-k)] = q.data[q.slot(i)]
+		}
+		for i := q.length - k; i < q.length; i++ {
+			q.data[q.slot(i)] = zero
+		}
+	}
+	q.length -= k
+	q.stats.remove += int64(k)
+	return true
+}
+
+// MoveToFront moves the element at index to the front of the deque, shifting the elements before it back by one.
+// Negative indices count from the back. Returns false and leaves the deque unchanged if index is out of range.
+func (q *UnsafeDeque[T]) MoveToFront(index int) bool {
+	if index < 0 {
+		index += q.length
+	}
+	if index < 0 || index >= q.length {
+		return false
+	}
+	// Removing first frees a slot, so the insert can never need to grow the deque
+	q.insertAt(0, q.removeAt(index))
+	return true
+}
+
+// MoveToBack moves the element at index to the back of the deque, shifting the elements after it forward by one.
+// Negative indices count from the back. Returns false and leaves the deque unchanged if index is out of range.
+func (q *UnsafeDeque[T]) MoveToBack(index int) bool {
+	if index < 0 {
+		index += q.length
+	}
+	if index < 0 || index >= q.length {
+		return false
+	}
+	// The back index is taken before removing, which shortens the deque
+	back := q.length - 1
+	q.insertAt(back, q.removeAt(index))
+	return true
+}
+
+// Clear removes all elements, keeping the capacity.
+func (q *UnsafeDeque[T]) Clear() {
+	q.stats.remove += int64(q.length)
+	clear(q.data)
+	q.front = 0
+	q.length = 0
+}
+
+// Reset removes all elements, reallocates the ring buffer at the deque's initial capacity and clears MaxLen.
+func (q *UnsafeDeque[T]) Reset() {
+	q.stats.remove += int64(q.length)
+	q.Init(q.initCap)
+	q.maxLen = 0
+}
+
+// ShrinkToFit reduces capacity to fit the current size, never below the initial capacity.
+func (q *UnsafeDeque[T]) ShrinkToFit() {
+	if q.length == 0 {
+		q.Init(q.initCap)
+		return
+	}
+	if q.length == len(q.data) {
+		return
+	}
+	q.resize(max(q.length, q.initCap))
+}
+
+// AppendFromSlice appends all elements of s to the back of the deque, in order. The ring buffer is resized at most
+// once, to the first capacity along the growth sequence that fits them all.
+// Panics, leaving the deque unchanged, if the elements do not fit within the maximum capacity set by WithMaxCapacity.
+func (q *UnsafeDeque[T]) AppendFromSlice(s []T) {
+	if len(s) == 0 {
+		return
+	}
+	if !q.reserve(len(s)) {
+		panic(q.fullMessage())
+	}
+	for i, val := range s {
+		q.data[q.slot(q.length+i)] = val
+	}
+	q.length += len(s)
+	q.observeLen()
+	q.stats.pushBack += int64(len(s))
+}
+
+// Extend appends all elements of other, in front-to-back order, to the back of the deque, copying them directly
+// between the ring buffers after at most one resize. other is unchanged and may be the deque itself.
+// Panics, leaving the deque unchanged, if the elements do not fit within the maximum capacity set by WithMaxCapacity.
+func (q *UnsafeDeque[T]) Extend(other *UnsafeDeque[T]) {
+	n := other.length
+	if !q.reserve(n) {
+		panic(q.fullMessage())
+	}
+	for i := 0; i < n; i++ {
+		q.data[q.slot(q.length+i)] = other.data[other.slot(i)]
+	}
+	q.length += n
+	q.observeLen()
+	q.stats.pushBack += int64(n)
+}
+
+// Prepend inserts all elements of other at the front of the deque, keeping their order, so that other's front
+// becomes the deque's front. Copying, the capacity limit and the handling of other are as for Extend.
+func (q *UnsafeDeque[T]) Prepend(other *UnsafeDeque[T]) {
+	n := other.length
+	if !q.reserve(n) {
+		panic(q.fullMessage())
+	}
+	// The destination slots lie before q's front, so other is read before front moves even when it is q itself
+	capacity := len(q.data)
+	front := (q.front - n%capacity + capacity) % capacity
+	for i := 0; i < n; i++ {
+		q.data[(front+i)%capacity] = other.data[other.slot(i)]
+	}
+	q.front = front
+	q.length += n
+	q.observeLen()
+	q.stats.pushFront += int64(n)
+}
+
+// Copy creates a new independent copy of the deque.
+func (q *UnsafeDeque[T]) Copy() *UnsafeDeque[T] {
+	c := NewUnsafeDeque[T](len(q.data))
+	c.growth = q.growth
+	c.maxCap = q.maxCap
+	c.maxLen = q.length
+	if q.length > 0 {
+		c.data = q.ToSlice()
+		c.length = q.length
+	}
+	return c
+}
+
+// COWSnapshot returns a frozen-in-time copy of the deque with the same capacity and settings.
+// An UnsafeDeque does not track shared storage, so unlike Deque.COWSnapshot the elements are copied eagerly in O(n).
+func (q *UnsafeDeque[T]) COWSnapshot() *UnsafeDeque[T] {
+	snap := &UnsafeDeque[T]{initCap: q.initCap, growth: q.growth, maxCap: q.maxCap, length: q.length, maxLen: q.length}
+	snap.data = make([]T, len(q.data))
+	head, tail := q.runs()
+	copy(snap.data[copy(snap.data, head):], tail)
+	return snap
+}
+
+// Split returns two new, independent deques: left holds the elements [0, index) and right holds [index, Len()).
+// Both share the receiver's initial capacity, growth factor and maximum capacity; the receiver is unchanged.
+// Negative indices count from the back. Returns nil, nil, false if index is out of range.
+func (q *UnsafeDeque[T]) Split(index int) (left, right *UnsafeDeque[T], ok bool) {
+	if index < 0 {
+		index += q.length
+	}
+	if index < 0 || index > q.length {
+		return nil, nil, false
+	}
+	elems := q.ToSlice()
+	// The right half is copied so the two deques never share a backing array
+	return q.derive(elems[:index:index]), q.derive(append([]T(nil), elems[index:]...)), true
+}
+
+// derive creates an UnsafeDeque holding elems, with the same initial capacity, growth factor and maximum capacity
+// as q, taking ownership of elems' backing array when it is large enough. Panics if elems do not fit within the
+// maximum capacity.
+func (q *UnsafeDeque[T]) derive(elems []T) *UnsafeDeque[T] {
+	if q.maxCap > 0 && len(elems) > q.maxCap {
+		panic(fmt.Sprintf("Deque: %d elements exceed max capacity %d", len(elems), q.maxCap))
+	}
+	capacity := max(len(elems), q.initCap, 1)
+	if q.maxCap > 0 && capacity > q.maxCap {
+		capacity = q.maxCap
+	}
+	if cap(elems) < capacity {
+		elems = append(make([]T, 0, capacity), elems...)
+	}
+	return &UnsafeDeque[T]{
+		data:    elems[:capacity:capacity],
+		length:  len(elems),
+		initCap: q.initCap,
+		growth:  q.growth,
+		maxCap:  q.maxCap,
+		maxLen:  len(elems),
+	}
+}
+
+// Filter returns a new deque holding, in front-to-back order, the elements for which pred reports true.
+// The result has the same initial capacity, growth factor and maximum capacity as the receiver, which is unchanged.
+func (q *UnsafeDeque[T]) Filter(pred func(T) bool) *UnsafeDeque[T] {
+	var kept []T
+	for i := 0; i < q.length; i++ {
+		if val := q.data[q.slot(i)]; pred(val) {
+			kept = append(kept, val)
+		}
+	}
+	return q.derive(kept)
+}
+
+// FilterInPlace removes the elements for which pred reports false, keeping the order of the rest.
+// The survivors are compacted towards the front within the existing ring buffer and the vacated slots are zeroed.
+func (q *UnsafeDeque[T]) FilterInPlace(pred func(T) bool) {
+	kept := 0
+	for i := 0; i < q.length; i++ {
+		if val := q.data[q.slot(i)]; pred(val) {
+			q.data[q.slot(kept)] = val
+			kept++
+		}
+	}
+	q.truncate(kept)
+}
+
+// truncate drops the elements from logical index n onwards, zeroing their slots and counting them as removed.
+func (q *UnsafeDeque[T]) truncate(n int) {
+	var zero T
+	for i := n; i < q.length; i++ {
+		q.data[q.slot(i)] = zero
+	}
+	q.stats.remove += int64(q.length - n)
+	q.length = n
+}
+
+// Compact removes consecutive duplicates in place, keeping the first element of every run of equal elements,
+// like slices.CompactFunc. Each element is compared with eq against the last element kept.
+// If the deque ends up using less than half of its capacity, the ring buffer is shrunk to fit
+// (never below the initial capacity).
+func (q *UnsafeDeque[T]) Compact(eq func(T, T) bool) {
+	if q.length < 2 {
+		return
+	}
+	kept := 1
+	for i := 1; i < q.length; i++ {
+		val := q.data[q.slot(i)]
+		if !eq(q.data[q.slot(kept-1)], val) {
+			q.data[q.slot(kept)] = val
+			kept++
+		}
+	}
+	q.truncate(kept)
+
+	if newCap := max(kept, q.initCap); kept < len(q.data)/2 && newCap < len(q.data) {
+		q.resize(newCap)
+	}
+}
+
+// Unique returns a new deque holding the elements with all duplicates removed, keeping the first occurrence
+// of each value in front-to-back order. Duplicates are detected with eq by a linear scan of the values kept
+// so far, so the cost is O(n²) in the worst case. The result has the same initial capacity, growth factor and
+// maximum capacity as the receiver, which is unchanged.
+func (q *UnsafeDeque[T]) Unique(eq func(a, b T) bool) *UnsafeDeque[T] {
+	var kept []T
+	for i := 0; i < q.length; i++ {
+		val := q.data[q.slot(i)]
+		if !slices.ContainsFunc(kept, func(k T) bool { return eq(k, val) }) {
+			kept = append(kept, val)
+		}
+	}
+	return q.derive(kept)
+}
+
+// MapInPlace replaces every element of the deque with the result of fn, in front-to-back order.
+func (q *UnsafeDeque[T]) MapInPlace(fn func(T) T) {
+	for i := 0; i < q.length; i++ {
+		pos := q.slot(i)
+		q.data[pos] = fn(q.data[pos])
+	}
+}
+
+// Find returns the index and value of the first element, scanning front to back, for which pred reports true.
+// If no element matches, index is -1 and found is false.
+func (q *UnsafeDeque[T]) Find(pred func(T) bool) (index int, val T, found bool) {
+	for i := 0; i < q.length; i++ {
+		if v := q.data[q.slot(i)]; pred(v) {
+			return i, v, true
+		}
+	}
+	return -1, val, false
+}
+
+// FindLast is like Find but scans back to front, returning the last matching element.
+func (q *UnsafeDeque[T]) FindLast(pred func(T) bool) (index int, val T, found bool) {
+	for i := q.length - 1; i >= 0; i-- {
+		if v := q.data[q.slot(i)]; pred(v) {
+			return i, v, true
+		}
+	}
+	return -1, val, false
+}
+
+// FindAll returns the logical indices, in ascending order, of all elements for which pred reports true.
+// Returns an empty, non-nil slice if none match.
+func (q *UnsafeDeque[T]) FindAll(pred func(T) bool) []int {
+	indices := []int{}
+	for i := 0; i < q.length; i++ {
+		if pred(q.data[q.slot(i)]) {
+			indices = append(indices, i)
+		}
+	}
+	return indices
+}
+
+// Any reports whether pred is true for at least one element. The scan stops at the first match.
+func (q *UnsafeDeque[T]) Any(pred func(T) bool) bool {
+	for i := 0; i < q.length; i++ {
+		if pred(q.data[q.slot(i)]) {
+			return true
+		}
+	}
+	return false
+}
+
+// All reports whether pred is true for every element; it is true for an empty deque.
+// The scan stops at the first element for which pred is false.
+func (q *UnsafeDeque[T]) All(pred func(T) bool) bool {
+	return !q.Any(func(v T) bool { return !pred(v) })
+}
+
+// None reports whether pred is false for every element; it is true for an empty deque.
+// The scan stops at the first element for which pred is true.
+func (q *UnsafeDeque[T]) None(pred func(T) bool) bool {
+	return !q.Any(pred)
+}
+
+// Count returns the number of elements for which pred is true.
+func (q *UnsafeDeque[T]) Count(pred func(T) bool) int {
+	n := 0
+	for i := 0; i < q.length; i++ {
+		if pred(q.data[q.slot(i)]) {
+			n++
+		}
+	}
+	return n
+}
+
+// CountEqual returns the number of elements equal to val according to eq.
+func (q *UnsafeDeque[T]) CountEqual(val T, eq func(T, T) bool) int {
+	return q.Count(func(v T) bool { return eq(v, val) })
+}
+
+// Equal reports whether q and other have the same length and eq holds for every pair of elements at the
+// same position.
+func (q *UnsafeDeque[T]) Equal(other *UnsafeDeque[T], eq func(a, b T) bool) bool {
+	if q == other {
+		return true
+	}
+	if q.length != other.length {
+		return false
+	}
+	for i := 0; i < q.length; i++ {
+		if !eq(q.data[q.slot(i)], other.data[other.slot(i)]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Windows returns a function that yields the overlapping windows of size consecutive elements, in front-to-back
+// order, like Deque.Windows. The elements are copied when Windows is called, so later changes to the deque
+// are not seen. Panics if size is not positive.
+func (q *UnsafeDeque[T]) Windows(size int) func() ([]T, bool) {
+	if size <= 0 {
+		panic(fmt.Sprintf("Deque: invalid window size %d", size))
+	}
+	elems := q.ToSlice()
+	pos := 0
+	return func() ([]T, bool) {
+		if pos+size > len(elems) {
+			return nil, false
+		}
+		window := slices.Clone(elems[pos : pos+size])
+		pos++
+		return window, true
+	}
+}
+
+// Chunks returns a function that yields consecutive, non-overlapping chunks of size elements, in front-to-back
+// order, like Deque.Chunks. The elements are copied when Chunks is called, so later changes to the deque
+// are not seen. Panics if size is not positive.
+func (q *UnsafeDeque[T]) Chunks(size int) func() ([]T, bool) {
+	if size <= 0 {
+		panic(fmt.Sprintf("Deque: invalid chunk size %d", size))
+	}
+	elems := q.ToSlice()
+	pos := 0
+	return func() ([]T, bool) {
+		if pos >= len(elems) {
+			return nil, false
+		}
+		end := min(pos+size, len(elems))
+		chunk := slices.Clone(elems[pos:end])
+		pos = end
+		return chunk, true
+	}
+}
+
+// search returns the smallest logical index i in [0, Len()] for which pred(At(i)) is true,
+// assuming pred is false for a prefix of the deque and true for the rest.
+func (q *UnsafeDeque[T]) search(pred func(T) bool) int {
+	lo, hi := 0, q.length
+	for lo < hi {
+		mid := int(uint(lo+hi) >> 1)
+		if pred(q.data[q.slot(mid)]) {
+			hi = mid
+		} else {
+			lo = mid + 1
+		}
+	}
+	return lo
+}
+
+// Sort sorts the elements of the deque in place according to less, which must be a strict weak ordering.
+// The sort is not guaranteed to be stable. Afterwards the elements are linearized at the start of the ring buffer.
+func (q *UnsafeDeque[T]) Sort(less func(a, b T) bool) {
+	if q.front+q.length <= len(q.data) {
+		if q.front > 0 {
+			copy(q.data, q.data[q.front:q.front+q.length])
+			clear(q.data[max(q.length, q.front) : q.front+q.length])
+		}
+	} else {
+		tmp := q.ToSlice()
+		copy(q.data, tmp)
+		clear(q.data[q.length:])
+	}
+	q.front = 0
+	slices.SortFunc(q.data[:q.length], lessToCmp(less))
+}
+
+// SortedInsert inserts val into the deque, which must be sorted according to less, keeping it sorted,
+// and returns the index at which val was inserted. val is placed after any elements equal to it.
+// Panics if the deque is full and already at the maximum capacity set by WithMaxCapacity.
+func (q *UnsafeDeque[T]) SortedInsert(val T, less func(a, b T) bool) int {
+	index := q.search(func(x T) bool { return less(val, x) })
+	if !q.insertAt(index, val) {
+		panic(q.fullMessage())
+	}
+	q.stats.insert++
+	return index
+}
+
+// SortedRemove removes the first element equal to val from the deque, which must be sorted according to cmp.
+// Returns true if an element was removed.
+func (q *UnsafeDeque[T]) SortedRemove(val T, cmp func(a, b T) int) bool {
+	index, found := q.BinarySearch(val, cmp)
+	if found {
+		q.removeAt(index)
+		q.stats.remove++
+	}
+	return found
+}
+
+// BinarySearch searches for target in the deque, which must be sorted in ascending order according to cmp.
+// It returns the index of the first element equal to target, or the index where target would be inserted
+// to keep the deque sorted, and whether target was found.
+func (q *UnsafeDeque[T]) BinarySearch(target T, cmp func(a, b T) int) (index int, found bool) {
+	index = q.LowerBound(target, cmp)
+	return index, index < q.length && cmp(q.data[q.slot(index)], target) == 0
+}
+
+// LowerBound returns the index of the first element not less than target in the deque, which must be sorted
+// in ascending order according to cmp, or Len() if there is none.
+func (q *UnsafeDeque[T]) LowerBound(target T, cmp func(a, b T) int) int {
+	return q.search(func(x T) bool { return cmp(x, target) >= 0 })
+}
+
+// UpperBound returns the index of the first element greater than target in the deque, which must be sorted
+// in ascending order according to cmp, or Len() if there is none.
+func (q *UnsafeDeque[T]) UpperBound(target T, cmp func(a, b T) int) int {
+	return q.search(func(x T) bool { return cmp(x, target) > 0 })
+}
+
+// ToSlice returns a copy of the elements in front-to-back order.
+func (q *UnsafeDeque[T]) ToSlice() []T {
+	head, tail := q.runs()
+	return append(append(make([]T, 0, q.length), head...), tail...)
+}
+
+// ForEach calls fn with the index and value of every element in front-to-back order.
+// fn must not modify the deque.
+func (q *UnsafeDeque[T]) ForEach(fn func(int, T)) {
+	for i := 0; i < q.length; i++ {
+		fn(i, q.data[q.slot(i)])
+	}
+}
+
+// Drain returns an iterator that pops and yields elements from the front one at a time until the deque is empty.
+// Breaking out of the loop leaves the remaining elements in the deque.
+func (q *UnsafeDeque[T]) Drain() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for {
+			val, ok := q.PopFront()
+			if !ok || !yield(val) {
+				return
+			}
+		}
+	}
+}
+
+// newIterator creates an iterator over the current elements of q positioned at pos, moving by step on Next.
+func (q *UnsafeDeque[T]) newIterator(pos func(length int) int, step int) *Iterator[T] {
+	return &Iterator[T]{data: q.data, front: q.front, length: q.length, pos: pos(q.length), step: step}
+}
+
+// Begin returns a forward iterator positioned at the front element (invalid if the deque is empty).
+func (q *UnsafeDeque[T]) Begin() *Iterator[T] {
+	return q.newIterator(func(int) int { return 0 }, 1)
+}
+
+// End returns a forward iterator positioned just past the back element.
+func (q *UnsafeDeque[T]) End() *Iterator[T] {
+	return q.newIterator(func(length int) int { return length }, 1)
+}
+
+// RBegin returns a reverse iterator positioned at the back element (invalid if the deque is empty).
+func (q *UnsafeDeque[T]) RBegin() *Iterator[T] {
+	return q.newIterator(func(length int) int { return length - 1 }, -1)
+}
+
+// REnd returns a reverse iterator positioned just before the front element.
+func (q *UnsafeDeque[T]) REnd() *Iterator[T] {
+	return q.newIterator(func(int) int { return -1 }, -1)
+}
+
+// Stats returns a snapshot of the deque's operation counters together with its current length and capacity.
+func (q *UnsafeDeque[T]) Stats() DequeStats {
+	return DequeStats{
+		PushFrontCount: q.stats.pushFront,
+		PushBackCount:  q.stats.pushBack,
+		PopFrontCount:  q.stats.popFront,
+		PopBackCount:   q.stats.popBack,
+		InsertCount:    q.stats.insert,
+		RemoveCount:    q.stats.remove,
+		ResizeCount:    q.stats.resize,
+		CurrentLen:     int64(q.length),
+		CurrentCap:     int64(len(q.data)),
+	}
+}
+
+// ResetStats zeroes all operation counters reported by Stats.
+func (q *UnsafeDeque[T]) ResetStats() {
+	q.stats = unsafeCounters{}
+}
+
+// MaxLen returns the largest length the deque has reached since it was created or last Reset.
+func (q *UnsafeDeque[T]) MaxLen() int {
+	return q.maxLen
+}
+
+// ResetMaxLen restarts MaxLen tracking from the current length.
+func (q *UnsafeDeque[T]) ResetMaxLen() {
+	q.maxLen = q.length
+}
+
+// IsContiguous reports whether the elements occupy a single unwrapped run of the ring buffer.
+func (q *UnsafeDeque[T]) IsContiguous() bool {
+	return q.front+q.length <= len(q.data)
+}
+
+// Debug returns a dump of the deque's internal ring-buffer state in the format of Deque.Debug.
+// The output format is intended for humans only and may change without notice.
+func (q *UnsafeDeque[T]) Debug() string {
+	capacity := len(q.data)
+	back := 0
+	if capacity > 0 {
+		back = (q.front + q.length) % capacity
+	}
+
+	var b strings.Builder
+	_, _ = fmt.Fprintf(&b, "front=%d back=%d len=%d cap=%d\ndata=[", q.front, back, q.length, capacity)
+	for i := 0; i < capacity; i++ {
+		if i > 0 {
+			b.WriteByte(' ')
+		}
+		if (i-q.front+capacity)%capacity < q.length {
+			b.WriteString(fmt.Sprint(q.data[i]))
+		} else {
+			b.WriteString("<empty>")
+		}
+	}
+	b.WriteByte(']')
+	return b.String()
+}
+
+// Format implements the fmt.Formatter interface with the same width and precision rules as Deque.
+func (q *UnsafeDeque[T]) Format(f fmt.State, verb rune) {
+	formatElems(f, verb, q.length, func(i int) T { return q.data[q.slot(i)] })
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler using the binary format of Deque.MarshalBinary,
+// so a Deque can decode what an UnsafeDeque encodes and vice versa.
+func (q *UnsafeDeque[T]) MarshalBinary() ([]byte, error) {
+	b, err := codec.Encode(q.length, q.initCap, func(i int) T { return q.data[q.slot(i)] })
+	if err != nil {
+		return nil, fmt.Errorf("Deque: %w", err)
+	}
+	return b, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler like Deque.UnmarshalBinary, leaving the deque unchanged
+// on error.
+func (q *UnsafeDeque[T]) UnmarshalBinary(b []byte) error {
+	elems, initCap, err := codec.Decode[T](b, q.maxCap)
+	if err != nil {
+		return fmt.Errorf("Deque: %w", err)
+	}
+	data := elems[:cap(elems)]
+	if len(data) == 0 {
+		// Empty payload without a capacity hint: fall back to the default capacity of 8
+		capacity := 8
+		if q.maxCap > 0 {
+			capacity = min(capacity, q.maxCap)
+		}
+		data = make([]T, capacity)
+	}
+
+	q.initCap = len(data)
+	if initCap > 0 {
+		q.initCap = initCap
+	}
+	if q.maxCap > 0 && q.initCap > q.maxCap {
+		q.initCap = q.maxCap
+	}
+	q.data = data
+	q.front = 0
+	q.length = len(elems)
+	q.observeLen()
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler, encoding the elements as a JSON array in front-to-back order.
+func (q *UnsafeDeque[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(q.ToSlice())
+}
+
+// UnmarshalJSON implements json.Unmarshaler like Deque.UnmarshalJSON, replacing the contents of the deque with
+// the elements of a JSON array. The deque is left unchanged on error or if the elements exceed the maximum
+// capacity set by WithMaxCapacity. A JSON null empties the deque.
+func (q *UnsafeDeque[T]) UnmarshalJSON(b []byte) error {
+	var elems []T
+	if err := json.Unmarshal(b, &elems); err != nil {
+		return err
+	}
+	if q.maxCap > 0 && len(elems) > q.maxCap {
+		return fmt.Errorf("Deque: %d elements exceed max capacity %d", len(elems), q.maxCap)
+	}
+	if q.initCap <= 0 {
+		q.initCap = 8
+	}
+	capacity := max(q.initCap, len(elems))
+	if q.maxCap > 0 && capacity > q.maxCap {
+		capacity = q.maxCap
+	}
+	q.data = make([]T, capacity)
+	copy(q.data, elems)
+	q.front = 0
+	q.length = len(elems)
+	q.observeLen()
+	return nil
+}
@@ -0,0 +1,195 @@
+package ExpiryMap
+
+import (
+	"container/heap"
+	"sync"
+	"time"
+)
+
+// ExpiryMap is a thread-safe map whose entries expire after a per-key time-to-live.
+// Expired entries are removed by a background goroutine that runs every cleanup interval,
+// and are also evicted lazily when they are looked up, so Get never returns an expired value.
+type ExpiryMap[K comparable, V any] struct {
+	mu      sync.RWMutex       // guards entries and expiry
+	entries map[K]*entry[K, V] // live entries by key
+	expiry  expiryQueue[K, V]  // entries with a TTL, ordered by expiry time
+	done    chan struct{}      // closed by Close to stop the cleanup goroutine
+	once    sync.Once          // makes Close idempotent
+}
+
+// entry is a stored value together with its expiry time.
+type entry[K comparable, V any] struct {
+	key       K
+	value     V
+	expiresAt time.Time // zero if the entry never expires
+	index     int       // position in the expiry queue, -1 if not queued
+}
+
+// expiryQueue is a min-heap of entries ordered by expiry time, implementing container/heap.Interface.
+type expiryQueue[K comparable, V any] []*entry[K, V]
+
+func (q expiryQueue[K, V]) Len() int           { return len(q) }
+func (q expiryQueue[K, V]) Less(i, j int) bool { return q[i].expiresAt.Before(q[j].expiresAt) }
+func (q expiryQueue[K, V]) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+	q[i].index = i
+	q[j].index = j
+}
+func (q *expiryQueue[K, V]) Push(x any) {
+	e := x.(*entry[K, V])
+	e.index = len(*q)
+	*q = append(*q, e)
+}
+func (q *expiryQueue[K, V]) Pop() any {
+	old := *q
+	n := len(old)
+	e := old[n-1]
+	old[n-1] = nil
+	e.index = -1
+	*q = old[:n-1]
+	return e
+}
+
+// NewExpiryMap creates an empty ExpiryMap whose background goroutine removes expired entries
+// every cleanupInterval. A non-positive interval disables the goroutine; expired entries are then
+// removed lazily by Get and on every Set. Call Close to stop the goroutine when the map is no longer needed.
+func NewExpiryMap[K comparable, V any](cleanupInterval time.Duration) *ExpiryMap[K, V] {
+	m := &ExpiryMap[K, V]{
+		entries: make(map[K]*entry[K, V]),
+		done:    make(chan struct{}),
+	}
+	if cleanupInterval > 0 {
+		go m.cleanupLoop(cleanupInterval)
+	}
+	return m
+}
+
+// cleanupLoop removes expired entries every interval until Close is called.
+func (m *ExpiryMap[K, V]) cleanupLoop(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			m.mu.Lock()
+			m.removeExpiredLocked(time.Now())
+			m.mu.Unlock()
+		case <-m.done:
+			return
+		}
+	}
+}
+
+// removeExpiredLocked removes every entry that has expired at now (must be called with write lock held).
+func (m *ExpiryMap[K, V]) removeExpiredLocked(now time.Time) {
+	for len(m.expiry) > 0 && !m.expiry[0].expiresAt.After(now) {
+		e := heap.Pop(&m.expiry).(*entry[K, V])
+		delete(m.entries, e.key)
+	}
+}
+
+// expired reports whether e has expired at now.
+func (e *entry[K, V]) expired(now time.Time) bool {
+	return !e.expiresAt.IsZero() && !e.expiresAt.After(now)
+}
+
+// Set stores value under key, replacing any previous value and TTL.
+// The entry expires ttl after the call; a non-positive ttl means the entry never expires.
+func (m *ExpiryMap[K, V]) Set(key K, value V, ttl time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	m.removeExpiredLocked(now)
+
+	var expiresAt time.Time
+	if ttl > 0 {
+		expiresAt = now.Add(ttl)
+	}
+
+	e, ok := m.entries[key]
+	if !ok {
+		e = &entry[K, V]{key: key, index: -1}
+		m.entries[key] = e
+	}
+	e.value = value
+	e.expiresAt = expiresAt
+
+	switch {
+	case expiresAt.IsZero() && e.index >= 0:
+		heap.Remove(&m.expiry, e.index)
+	case !expiresAt.IsZero() && e.index >= 0:
+		heap.Fix(&m.expiry, e.index)
+	case !expiresAt.IsZero():
+		heap.Push(&m.expiry, e)
+	}
+}
+
+// Get returns the value stored under key. Expired entries are never returned;
+// an expired entry found here is removed immediately instead of waiting for the cleanup goroutine.
+func (m *ExpiryMap[K, V]) Get(key K) (V, bool) {
+	var zero V
+	now := time.Now()
+
+	m.mu.RLock()
+	e, ok := m.entries[key]
+	if ok && !e.expired(now) {
+		value := e.value
+		m.mu.RUnlock()
+		return value, true
+	}
+	m.mu.RUnlock()
+	if !ok {
+		return zero, false
+	}
+
+	// Lazy eviction: re-check under the write lock, the entry may have been replaced meanwhile.
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	e, ok = m.entries[key]
+	if !ok {
+		return zero, false
+	}
+	if e.expired(now) {
+		m.removeLocked(e)
+		return zero, false
+	}
+	return e.value, true
+}
+
+// removeLocked removes e from the map and the expiry queue (must be called with write lock held).
+func (m *ExpiryMap[K, V]) removeLocked(e *entry[K, V]) {
+	delete(m.entries, e.key)
+	if e.index >= 0 {
+		heap.Remove(&m.expiry, e.index)
+	}
+}
+
+// Delete removes key from the map. Returns true if an unexpired entry was removed.
+func (m *ExpiryMap[K, V]) Delete(key K) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	e, ok := m.entries[key]
+	if !ok {
+		return false
+	}
+	m.removeLocked(e)
+	return !e.expired(time.Now())
+}
+
+// Len returns the number of entries in the map.
+// Entries that have expired but not yet been removed by cleanup or a lookup are included.
+func (m *ExpiryMap[K, V]) Len() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return len(m.entries)
+}
+
+// Close stops the background cleanup goroutine. The map remains usable afterwards,
+// relying on lazy eviction only. Calling Close more than once is safe.
+func (m *ExpiryMap[K, V]) Close() {
+	m.once.Do(func() {
+		close(m.done)
+	})
+}
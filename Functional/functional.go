@@ -0,0 +1,83 @@
+package Functional
+
+import (
+	"GoSTL/Iterator"
+	"GoSTL/Tuple"
+)
+
+// Map applies fn to every element of iter and returns the results in iteration order.
+func Map[T, U any](iter Iterator.Iterator[T], fn func(T) U) []U {
+	var out []U
+	for iter.Next() {
+		out = append(out, fn(iter.Value()))
+	}
+	return out
+}
+
+// Filter returns the elements of iter for which pred returns true, in iteration order.
+func Filter[T any](iter Iterator.Iterator[T], pred func(T) bool) []T {
+	var out []T
+	for iter.Next() {
+		if v := iter.Value(); pred(v) {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// Reduce folds the elements of iter into an accumulator, starting from seed.
+func Reduce[T, Acc any](iter Iterator.Iterator[T], seed Acc, fn func(Acc, T) Acc) Acc {
+	acc := seed
+	for iter.Next() {
+		acc = fn(acc, iter.Value())
+	}
+	return acc
+}
+
+// ForEach calls fn for every element of iter.
+func ForEach[T any](iter Iterator.Iterator[T], fn func(T)) {
+	for iter.Next() {
+		fn(iter.Value())
+	}
+}
+
+// Zip combines a and b position by position, stopping at the shorter of the two.
+func Zip[T, U any](a Iterator.Iterator[T], b Iterator.Iterator[U]) Iterator.Iterator[Tuple.Pair[T, U]] {
+	return Iterator.ZipIter(a, b)
+}
+
+// Take returns at most the first n elements of iter.
+// Elements after the n-th are not consumed.
+func Take[T any](iter Iterator.Iterator[T], n int) []T {
+	var out []T
+	for len(out) < n && iter.Next() {
+		out = append(out, iter.Value())
+	}
+	return out
+}
+
+// dropIter skips a fixed number of leading elements of an underlying iterator.
+type dropIter[T any] struct {
+	it Iterator.Iterator[T]
+	n  int
+}
+
+// Drop returns an Iterator over the elements of iter after skipping the first n.
+// The elements are skipped lazily on the first call to Next.
+func Drop[T any](iter Iterator.Iterator[T], n int) Iterator.Iterator[T] {
+	return &dropIter[T]{it: iter, n: n}
+}
+
+func (d *dropIter[T]) Next() bool {
+	for ; d.n > 0; d.n-- {
+		if !d.it.Next() {
+			d.n = 0
+			return false
+		}
+	}
+	return d.it.Next()
+}
+
+func (d *dropIter[T]) Value() T {
+	return d.it.Value()
+}
@@ -0,0 +1,235 @@
+package HashMap
+
+import (
+	"hash/maphash"
+	"sync"
+
+	"GoSTL/Tuple"
+)
+
+// maxLoadFactor is the load factor above which the table doubles before inserting.
+const maxLoadFactor = 0.75
+
+// Pair is a key-value entry as returned by Entries.
+type Pair[K, V any] = Tuple.KeyValue[K, V]
+
+// HashMap is a thread-safe open-addressing hash map using Robin Hood linear probing.
+// On insertion an entry that has probed further than the resident of a slot takes that slot and the resident
+// moves on, which keeps probe sequences short and uniform; deletion shifts the following entries back instead
+// of leaving tombstones. Reads take a shared lock; writes take an exclusive lock.
+type HashMap[K comparable, V any] struct {
+	mu    sync.RWMutex // guards all fields below
+	slots []slot[K, V] // table, length is a power of two
+	size  int          // number of stored entries
+	seed  maphash.Seed // hash seed
+}
+
+// slot is a table entry. dist is one more than the entry's distance from its home slot, 0 for an empty slot.
+type slot[K comparable, V any] struct {
+	key   K
+	value V
+	dist  int
+}
+
+// NewHashMap creates an empty map with an optional capacity hint, the number of entries it can hold
+// before resizing. The table size is rounded up to a power of two of at least 8.
+func NewHashMap[K comparable, V any](initCap ...int) *HashMap[K, V] {
+	n := 0
+	if len(initCap) > 0 {
+		n = initCap[0]
+	}
+	size := 8
+	for float64(size)*maxLoadFactor < float64(n) {
+		size *= 2
+	}
+	return &HashMap[K, V]{
+		slots: make([]slot[K, V], size),
+		seed:  maphash.MakeSeed(),
+	}
+}
+
+// home returns the home slot index of key.
+func (m *HashMap[K, V]) home(key K) int {
+	return int(maphash.Comparable(m.seed, key) & uint64(len(m.slots)-1))
+}
+
+// find returns the index of the slot holding key, or -1 (must be called with lock held).
+// The probe stops early at an entry closer to its home than key would be, since Robin Hood
+// insertion would have placed key before it.
+func (m *HashMap[K, V]) find(key K) int {
+	mask := len(m.slots) - 1
+	for i, dist := m.home(key), 1; ; i, dist = (i+1)&mask, dist+1 {
+		s := &m.slots[i]
+		if s.dist < dist {
+			return -1
+		}
+		if s.dist == dist && s.key == key {
+			return i
+		}
+	}
+}
+
+// insert places a key known to be absent (must be called with lock held and a free slot available).
+func (m *HashMap[K, V]) insert(key K, value V) {
+	mask := len(m.slots) - 1
+	cur := slot[K, V]{key: key, value: value, dist: 1}
+	for i := m.home(key); ; i = (i + 1) & mask {
+		s := &m.slots[i]
+		if s.dist == 0 {
+			*s = cur
+			m.size++
+			return
+		}
+		if s.dist < cur.dist {
+			*s, cur = cur, *s
+		}
+		cur.dist++
+	}
+}
+
+// resize rehashes every entry into a table of newSize slots (must be called with lock held).
+func (m *HashMap[K, V]) resize(newSize int) {
+	old := m.slots
+	m.slots = make([]slot[K, V], newSize)
+	m.size = 0
+	for _, s := range old {
+		if s.dist > 0 {
+			m.insert(s.key, s.value)
+		}
+	}
+}
+
+// Put stores val under key, replacing any existing value.
+func (m *HashMap[K, V]) Put(key K, val V) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if i := m.find(key); i >= 0 {
+		m.slots[i].value = val
+		return
+	}
+	if float64(m.size+1) > float64(len(m.slots))*maxLoadFactor {
+		m.resize(len(m.slots) * 2)
+	}
+	m.insert(key, val)
+}
+
+// Get returns the value stored under key and whether it was present.
+func (m *HashMap[K, V]) Get(key K) (V, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if i := m.find(key); i >= 0 {
+		return m.slots[i].value, true
+	}
+	var zero V
+	return zero, false
+}
+
+// ContainsKey reports whether key is present in the map.
+func (m *HashMap[K, V]) ContainsKey(key K) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.find(key) >= 0
+}
+
+// Delete removes key from the map. Returns true if it was present.
+// The entries following it in the probe run are shifted back by one slot, so no tombstone is left behind.
+func (m *HashMap[K, V]) Delete(key K) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	i := m.find(key)
+	if i < 0 {
+		return false
+	}
+	mask := len(m.slots) - 1
+	for {
+		next := (i + 1) & mask
+		if m.slots[next].dist <= 1 {
+			m.slots[i] = slot[K, V]{}
+			break
+		}
+		m.slots[i] = m.slots[next]
+		m.slots[i].dist--
+		i = next
+	}
+	m.size--
+	return true
+}
+
+// Len returns the number of entries in the map.
+func (m *HashMap[K, V]) Len() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.size
+}
+
+// Clear removes all entries, keeping the table size.
+func (m *HashMap[K, V]) Clear() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	clear(m.slots)
+	m.size = 0
+}
+
+// Copy creates a new independent map holding the same entries.
+func (m *HashMap[K, V]) Copy() *HashMap[K, V] {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return &HashMap[K, V]{
+		slots: append([]slot[K, V](nil), m.slots...),
+		size:  m.size,
+		seed:  m.seed,
+	}
+}
+
+// Keys returns the keys of the map in unspecified order.
+func (m *HashMap[K, V]) Keys() []K {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	keys := make([]K, 0, m.size)
+	for _, s := range m.slots {
+		if s.dist > 0 {
+			keys = append(keys, s.key)
+		}
+	}
+	return keys
+}
+
+// Values returns the values of the map in unspecified order.
+func (m *HashMap[K, V]) Values() []V {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	values := make([]V, 0, m.size)
+	for _, s := range m.slots {
+		if s.dist > 0 {
+			values = append(values, s.value)
+		}
+	}
+	return values
+}
+
+// Entries returns the key-value pairs of the map in unspecified order.
+func (m *HashMap[K, V]) Entries() []Pair[K, V] {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	entries := make([]Pair[K, V], 0, m.size)
+	for _, s := range m.slots {
+		if s.dist > 0 {
+			entries = append(entries, Pair[K, V]{Key: s.key, Value: s.value})
+		}
+	}
+	return entries
+}
+
+// ForEach calls fn with every entry of the map in unspecified order.
+// It iterates over a snapshot taken under the read lock, so fn may modify the map.
+func (m *HashMap[K, V]) ForEach(fn func(K, V)) {
+	for _, e := range m.Entries() {
+		fn(e.Key, e.Value)
+	}
+}
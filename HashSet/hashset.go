@@ -0,0 +1,126 @@
+package HashSet
+
+import (
+	"sync"
+
+	"GoSTL/HashMap"
+)
+
+// HashSet is a thread-safe set of comparable values backed by a HashMap with empty values.
+// Single-element operations are O(1) on average. The set operations build a new set and read each operand
+// under its own lock, so each operand is seen in a consistent state; they may be called with other == s.
+type HashSet[T comparable] struct {
+	mu sync.RWMutex                  // makes multi-step operations on the set atomic
+	m  *HashMap.HashMap[T, struct{}] // elements, stored as keys
+}
+
+// NewHashSet creates an empty set with an optional capacity hint, the number of elements it can hold
+// before resizing.
+func NewHashSet[T comparable](initCap ...int) *HashSet[T] {
+	return &HashSet[T]{m: HashMap.NewHashMap[T, struct{}](initCap...)}
+}
+
+// Add adds val to the set. Adding a value already present has no effect.
+func (s *HashSet[T]) Add(val T) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.m.Put(val, struct{}{})
+}
+
+// Remove removes val from the set. Returns true if it was present.
+func (s *HashSet[T]) Remove(val T) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.m.Delete(val)
+}
+
+// Contains reports whether val is in the set.
+func (s *HashSet[T]) Contains(val T) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.m.ContainsKey(val)
+}
+
+// Len returns the number of elements in the set.
+func (s *HashSet[T]) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.m.Len()
+}
+
+// Clear removes all elements.
+func (s *HashSet[T]) Clear() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.m.Clear()
+}
+
+// Copy creates a new independent set holding the same elements.
+func (s *HashSet[T]) Copy() *HashSet[T] {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return &HashSet[T]{m: s.m.Copy()}
+}
+
+// ToSlice returns the elements of the set in unspecified order.
+func (s *HashSet[T]) ToSlice() []T {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.m.Keys()
+}
+
+// ForEach calls fn with every element of the set in unspecified order.
+// It iterates over a snapshot, so fn may modify the set.
+func (s *HashSet[T]) ForEach(fn func(T)) {
+	for _, val := range s.ToSlice() {
+		fn(val)
+	}
+}
+
+// Union returns a new set holding the elements that are in s, in other, or in both.
+func (s *HashSet[T]) Union(other *HashSet[T]) *HashSet[T] {
+	out := s.Copy()
+	for _, val := range other.ToSlice() {
+		out.m.Put(val, struct{}{})
+	}
+	return out
+}
+
+// Intersection returns a new set holding the elements that are in both s and other.
+func (s *HashSet[T]) Intersection(other *HashSet[T]) *HashSet[T] {
+	vals := s.ToSlice()
+	out := NewHashSet[T]()
+	other.mu.RLock()
+	defer other.mu.RUnlock()
+	for _, val := range vals {
+		if other.m.ContainsKey(val) {
+			out.m.Put(val, struct{}{})
+		}
+	}
+	return out
+}
+
+// Difference returns a new set holding the elements of s that are not in other.
+func (s *HashSet[T]) Difference(other *HashSet[T]) *HashSet[T] {
+	out := s.Copy()
+	for _, val := range other.ToSlice() {
+		out.m.Delete(val)
+	}
+	return out
+}
+
+// IsSubset reports whether every element of s is also in other. The empty set is a subset of every set.
+func (s *HashSet[T]) IsSubset(other *HashSet[T]) bool {
+	vals := s.ToSlice()
+	other.mu.RLock()
+	defer other.mu.RUnlock()
+	if len(vals) > other.m.Len() {
+		return false
+	}
+	for _, val := range vals {
+		if !other.m.ContainsKey(val) {
+			return false
+		}
+	}
+	return true
+}
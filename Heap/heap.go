@@ -0,0 +1,154 @@
+package Heap
+
+import (
+	"sync"
+	"unsafe"
+)
+
+// Heap is a thread-safe binary heap ordered by a less function: the element for which less
+// reports true against every other element is at the top. With less(a, b) = a < b it is a min-heap.
+type Heap[T any] struct {
+	data []T               // heap-ordered elements
+	less func(a, b T) bool // ordering; the least element is at data[0]
+	mu   sync.Mutex        // guards data
+}
+
+// MinHeap is an alias of Heap; a Heap is a min-heap with respect to its less function.
+type MinHeap[T any] = Heap[T]
+
+// NewHeap creates an empty heap ordered by less with an optional initial capacity.
+func NewHeap[T any](less func(a, b T) bool, initCap ...int) *Heap[T] {
+	capacity := 8
+	if len(initCap) > 0 && initCap[0] > 0 {
+		capacity = initCap[0]
+	}
+	return &Heap[T]{data: make([]T, 0, capacity), less: less}
+}
+
+// BuildHeap heapifies data in place in O(n) using Floyd's algorithm and returns a heap that takes ownership of it.
+// The caller must not use data afterwards, as the heap reorders and modifies it.
+func BuildHeap[T any](data []T, less func(a, b T) bool) *Heap[T] {
+	h := &Heap[T]{data: data, less: less}
+	h.heapify()
+	return h
+}
+
+// MergeHeaps returns a new heap holding all elements of a and b, built in O(n) by concatenating both
+// backing arrays and heapifying the result. The new heap uses a's ordering; a and b are left unchanged.
+func MergeHeaps[T any](a, b *MinHeap[T]) *MinHeap[T] {
+	if a == b {
+		a.mu.Lock()
+		defer a.mu.Unlock()
+	} else {
+		// Lock in address order so concurrent merges of the same pair cannot deadlock
+		first, second := a, b
+		if uintptr(unsafe.Pointer(first)) > uintptr(unsafe.Pointer(second)) {
+			first, second = second, first
+		}
+		first.mu.Lock()
+		defer first.mu.Unlock()
+		second.mu.Lock()
+		defer second.mu.Unlock()
+	}
+
+	data := make([]T, 0, len(a.data)+len(b.data))
+	data = append(data, a.data...)
+	data = append(data, b.data...)
+	return BuildHeap(data, a.less)
+}
+
+// heapify restores the heap property over all of data (must be called with lock held or before publication).
+func (h *Heap[T]) heapify() {
+	for i := len(h.data)/2 - 1; i >= 0; i-- {
+		h.down(i)
+	}
+}
+
+// up moves the element at i towards the root until its parent is not greater.
+func (h *Heap[T]) up(i int) {
+	for i > 0 {
+		parent := (i - 1) / 2
+		if !h.less(h.data[i], h.data[parent]) {
+			return
+		}
+		h.data[i], h.data[parent] = h.data[parent], h.data[i]
+		i = parent
+	}
+}
+
+// down moves the element at i towards the leaves until neither child is smaller.
+func (h *Heap[T]) down(i int) {
+	n := len(h.data)
+	for {
+		smallest := i
+		if l := 2*i + 1; l < n && h.less(h.data[l], h.data[smallest]) {
+			smallest = l
+		}
+		if r := 2*i + 2; r < n && h.less(h.data[r], h.data[smallest]) {
+			smallest = r
+		}
+		if smallest == i {
+			return
+		}
+		h.data[i], h.data[smallest] = h.data[smallest], h.data[i]
+		i = smallest
+	}
+}
+
+// Push adds val to the heap in O(log n).
+func (h *Heap[T]) Push(val T) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.data = append(h.data, val)
+	h.up(len(h.data) - 1)
+}
+
+// Pop removes and returns the least element in O(log n). Returns false if the heap is empty.
+func (h *Heap[T]) Pop() (T, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	var zero T
+	n := len(h.data)
+	if n == 0 {
+		return zero, false
+	}
+	top := h.data[0]
+	h.data[0] = h.data[n-1]
+	h.data[n-1] = zero // release the reference for GC
+	h.data = h.data[:n-1]
+	h.down(0)
+	return top, true
+}
+
+// Peek returns the least element without removing it. Returns false if the heap is empty.
+func (h *Heap[T]) Peek() (T, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	var zero T
+	if len(h.data) == 0 {
+		return zero, false
+	}
+	return h.data[0], true
+}
+
+// Len returns the number of elements in the heap.
+func (h *Heap[T]) Len() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.data)
+}
+
+// Empty returns true if the heap contains no elements.
+func (h *Heap[T]) Empty() bool {
+	return h.Len() == 0
+}
+
+// Clear removes all elements while keeping the allocated capacity.
+func (h *Heap[T]) Clear() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	clear(h.data)
+	h.data = h.data[:0]
+}
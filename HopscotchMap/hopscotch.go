@@ -0,0 +1,228 @@
+package HopscotchMap
+
+import (
+	"hash/maphash"
+	"iter"
+	"math/bits"
+	"sync"
+)
+
+// neighbourhood is the hopscotch neighbourhood size H: every key is stored within H buckets of its home bucket.
+const neighbourhood = 32
+
+// maxLoadFactor is the load factor above which the table doubles before inserting.
+const maxLoadFactor = 0.9
+
+// HopscotchMap is a thread-safe open-addressing hash map using hopscotch hashing.
+// Each key lives within a fixed neighbourhood of H=32 buckets from its home bucket, and every home bucket
+// keeps a bitmap of which neighbourhood slots hold its keys, so lookups touch at most H adjacent buckets.
+// Reads take a shared lock; writes take an exclusive lock.
+type HopscotchMap[K comparable, V any] struct {
+	mu      sync.RWMutex   // guards all fields below
+	buckets []bucket[K, V] // table, length is a power of two
+	size    int            // number of stored entries
+	seed    maphash.Seed   // hash seed
+}
+
+// bucket is a table slot. hop describes the slot as a home bucket: bit i is set when the key
+// stored i buckets further on hashes to this bucket.
+type bucket[K comparable, V any] struct {
+	key   K
+	value V
+	hop   uint32 // neighbourhood bitmap of this home bucket
+	used  bool   // whether key and value hold an entry
+}
+
+// NewHopscotchMap creates an empty map able to hold about initCap entries before resizing.
+// The table size is rounded up to a power of two of at least the neighbourhood size of 32.
+func NewHopscotchMap[K comparable, V any](initCap int) *HopscotchMap[K, V] {
+	size := neighbourhood
+	for float64(size)*maxLoadFactor < float64(initCap) {
+		size *= 2
+	}
+	return &HopscotchMap[K, V]{
+		buckets: make([]bucket[K, V], size),
+		seed:    maphash.MakeSeed(),
+	}
+}
+
+// home returns the home bucket index of key.
+func (m *HopscotchMap[K, V]) home(key K) int {
+	return int(maphash.Comparable(m.seed, key) & uint64(len(m.buckets)-1))
+}
+
+// find returns the index of the bucket holding key, or -1 (must be called with lock held).
+func (m *HopscotchMap[K, V]) find(key K) int {
+	mask := len(m.buckets) - 1
+	h := m.home(key)
+	for hop := m.buckets[h].hop; hop != 0; hop &= hop - 1 {
+		i := (h + bits.TrailingZeros32(hop)) & mask
+		if m.buckets[i].key == key {
+			return i
+		}
+	}
+	return -1
+}
+
+// Get returns the value stored under key and whether it was present.
+func (m *HopscotchMap[K, V]) Get(key K) (V, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if i := m.find(key); i >= 0 {
+		return m.buckets[i].value, true
+	}
+	var zero V
+	return zero, false
+}
+
+// Put stores value under key. Returns true if key was newly added and false if an existing value was replaced.
+func (m *HopscotchMap[K, V]) Put(key K, value V) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if i := m.find(key); i >= 0 {
+		m.buckets[i].value = value
+		return false
+	}
+	if float64(m.size+1) > float64(len(m.buckets))*maxLoadFactor {
+		m.resize(len(m.buckets) * 2)
+	}
+	for !m.insert(key, value) {
+		m.resize(len(m.buckets) * 2)
+	}
+	m.size++
+	return true
+}
+
+// insert places a key known to be absent, hopping entries towards the free slot until it falls within
+// the key's neighbourhood. Returns false if no such arrangement exists and the table must grow
+// (must be called with lock held).
+func (m *HopscotchMap[K, V]) insert(key K, value V) bool {
+	mask := len(m.buckets) - 1
+	h := m.home(key)
+
+	// Linear probe for the nearest free bucket
+	free := -1
+	for d := 0; d < len(m.buckets); d++ {
+		if i := (h + d) & mask; !m.buckets[i].used {
+			free = i
+			break
+		}
+	}
+	if free < 0 {
+		return false
+	}
+
+	// Move the free bucket back into the neighbourhood by displacing entries that may legally move into it
+	for (free-h)&mask >= neighbourhood {
+		moved := false
+		for d := neighbourhood - 1; d > 0 && !moved; d-- {
+			candidate := (free - d) & mask
+			// Only entries homed at candidate and stored before free may move; offset i stays below H
+			for hop := m.buckets[candidate].hop; hop != 0; hop &= hop - 1 {
+				i := bits.TrailingZeros32(hop)
+				if i >= d {
+					break
+				}
+				src := (candidate + i) & mask
+				m.buckets[free] = bucket[K, V]{
+					key:   m.buckets[src].key,
+					value: m.buckets[src].value,
+					hop:   m.buckets[free].hop,
+					used:  true,
+				}
+				m.buckets[candidate].hop = m.buckets[candidate].hop&^(1<<i) | 1<<d
+				m.clearEntry(src)
+				free = src
+				moved = true
+				break
+			}
+		}
+		if !moved {
+			return false
+		}
+	}
+
+	b := &m.buckets[free]
+	b.key, b.value, b.used = key, value, true
+	m.buckets[h].hop |= 1 << ((free - h) & mask)
+	return true
+}
+
+// clearEntry zeroes the entry in bucket i, keeping its neighbourhood bitmap.
+func (m *HopscotchMap[K, V]) clearEntry(i int) {
+	m.buckets[i] = bucket[K, V]{hop: m.buckets[i].hop}
+}
+
+// resize rehashes every entry into a table of at least newSize buckets (must be called with lock held).
+func (m *HopscotchMap[K, V]) resize(newSize int) {
+	old := m.buckets
+	for {
+		m.buckets = make([]bucket[K, V], newSize)
+		ok := true
+		for i := range old {
+			if old[i].used && !m.insert(old[i].key, old[i].value) {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			return
+		}
+		newSize *= 2
+	}
+}
+
+// Delete removes key from the map. Returns true if it was present.
+func (m *HopscotchMap[K, V]) Delete(key K) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	i := m.find(key)
+	if i < 0 {
+		return false
+	}
+	h := m.home(key)
+	m.buckets[h].hop &^= 1 << ((i - h) & (len(m.buckets) - 1))
+	m.clearEntry(i)
+	m.size--
+	return true
+}
+
+// Len returns the number of entries in the map.
+func (m *HopscotchMap[K, V]) Len() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.size
+}
+
+// LoadFactor returns the ratio of stored entries to table buckets.
+func (m *HopscotchMap[K, V]) LoadFactor() float64 {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return float64(m.size) / float64(len(m.buckets))
+}
+
+// All returns an iterator over the map's entries in unspecified order.
+// It iterates over a snapshot taken under the read lock, so the loop body may modify the map.
+func (m *HopscotchMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		m.mu.RLock()
+		keys := make([]K, 0, m.size)
+		values := make([]V, 0, m.size)
+		for i := range m.buckets {
+			if m.buckets[i].used {
+				keys = append(keys, m.buckets[i].key)
+				values = append(values, m.buckets[i].value)
+			}
+		}
+		m.mu.RUnlock()
+
+		for i, k := range keys {
+			if !yield(k, values[i]) {
+				return
+			}
+		}
+	}
+}
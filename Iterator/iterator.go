@@ -0,0 +1,180 @@
+package Iterator
+
+import (
+	"iter"
+	"runtime"
+
+	"GoSTL/Tuple"
+)
+
+// Iterator is the iteration protocol shared by the GoSTL containers.
+// Next advances to the next element and reports whether one is available;
+// Value returns the element at the current position and is only valid after Next returned true.
+type Iterator[T any] interface {
+	Next() bool
+	Value() T
+}
+
+// IndexedIterator is an Iterator that also reports the 0-based position of the current element.
+type IndexedIterator[T any] interface {
+	Iterator[T]
+	Index() int
+}
+
+// sliceIter iterates over the elements of a slice.
+type sliceIter[T any] struct {
+	data []T
+	pos  int
+}
+
+// FromSlice returns an IndexedIterator over the elements of s in order.
+// The slice is not copied, so it must not be modified during iteration.
+func FromSlice[T any](s []T) IndexedIterator[T] {
+	return &sliceIter[T]{data: s, pos: -1}
+}
+
+func (it *sliceIter[T]) Next() bool {
+	if it.pos+1 >= len(it.data) {
+		it.pos = len(it.data)
+		return false
+	}
+	it.pos++
+	return true
+}
+
+func (it *sliceIter[T]) Value() T {
+	return it.data[it.pos]
+}
+
+func (it *sliceIter[T]) Index() int {
+	return it.pos
+}
+
+// seqIter pulls the elements of an iter.Seq one at a time.
+type seqIter[T any] struct {
+	next func() (T, bool)
+	cur  T
+}
+
+// FromSeq returns an Iterator over the elements of seq, so that containers exposing range-over-func iterators
+// can be passed wherever an Iterator is expected. seq runs as a coroutine that is resumed by every call to Next.
+// If the iterator is abandoned before it is exhausted, seq is stopped when the iterator is garbage collected,
+// so seq should not hold a lock while it yields; the iterators returned by GoSTL containers never do.
+func FromSeq[T any](seq iter.Seq[T]) Iterator[T] {
+	next, stop := iter.Pull(seq)
+	it := &seqIter[T]{next: next}
+	runtime.AddCleanup(it, func(stop func()) { stop() }, stop)
+	return it
+}
+
+func (s *seqIter[T]) Next() bool {
+	v, ok := s.next()
+	s.cur = v
+	return ok
+}
+
+func (s *seqIter[T]) Value() T {
+	return s.cur
+}
+
+// FromSeq2 returns an Iterator over the pairs of seq as key-value entries, like FromSeq.
+func FromSeq2[K, V any](seq iter.Seq2[K, V]) Iterator[Tuple.KeyValue[K, V]] {
+	return FromSeq(func(yield func(Tuple.KeyValue[K, V]) bool) {
+		for k, v := range seq {
+			if !yield(Tuple.KeyValue[K, V]{Key: k, Value: v}) {
+				return
+			}
+		}
+	})
+}
+
+// mapIter applies fn to every element of an underlying iterator.
+type mapIter[T, U any] struct {
+	it Iterator[T]
+	fn func(T) U
+}
+
+// MapIter returns an Iterator yielding fn(v) for every element v of it.
+// fn is called lazily, once per call to Value.
+func MapIter[T, U any](it Iterator[T], fn func(T) U) Iterator[U] {
+	return &mapIter[T, U]{it: it, fn: fn}
+}
+
+func (m *mapIter[T, U]) Next() bool {
+	return m.it.Next()
+}
+
+func (m *mapIter[T, U]) Value() U {
+	return m.fn(m.it.Value())
+}
+
+// filterIter skips the elements of an underlying iterator that don't satisfy pred.
+type filterIter[T any] struct {
+	it   Iterator[T]
+	pred func(T) bool
+	cur  T
+}
+
+// FilterIter returns an Iterator yielding only the elements of it for which pred returns true.
+func FilterIter[T any](it Iterator[T], pred func(T) bool) Iterator[T] {
+	return &filterIter[T]{it: it, pred: pred}
+}
+
+func (f *filterIter[T]) Next() bool {
+	for f.it.Next() {
+		v := f.it.Value()
+		if f.pred(v) {
+			f.cur = v
+			return true
+		}
+	}
+	return false
+}
+
+func (f *filterIter[T]) Value() T {
+	return f.cur
+}
+
+// zipIter walks two iterators in lockstep.
+type zipIter[T, U any] struct {
+	a Iterator[T]
+	b Iterator[U]
+}
+
+// ZipIter returns an Iterator of pairs combining the elements of a and b position by position.
+// Iteration stops as soon as either iterator is exhausted.
+func ZipIter[T, U any](a Iterator[T], b Iterator[U]) Iterator[Tuple.Pair[T, U]] {
+	return &zipIter[T, U]{a: a, b: b}
+}
+
+func (z *zipIter[T, U]) Next() bool {
+	return z.a.Next() && z.b.Next()
+}
+
+func (z *zipIter[T, U]) Value() Tuple.Pair[T, U] {
+	return Tuple.NewPair(z.a.Value(), z.b.Value())
+}
+
+// chainIter walks several iterators one after another.
+type chainIter[T any] struct {
+	iters []Iterator[T]
+}
+
+// ChainIter returns an Iterator yielding all elements of iters in sequence.
+func ChainIter[T any](iters ...Iterator[T]) Iterator[T] {
+	return &chainIter[T]{iters: iters}
+}
+
+func (c *chainIter[T]) Next() bool {
+	for len(c.iters) > 0 {
+		if c.iters[0].Next() {
+			return true
+		}
+		c.iters = c.iters[1:]
+	}
+	return false
+}
+
+func (c *chainIter[T]) Value() T {
+	return c.iters[0].Value()
+}
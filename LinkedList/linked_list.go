@@ -0,0 +1,421 @@
+package LinkedList
+
+import (
+	"fmt"
+	"io"
+	"iter"
+	"strings"
+	"sync"
+	"unsafe"
+)
+
+// LinkedList is a thread-safe doubly linked list with O(1) insertion and removal anywhere, given a node.
+// It is a ring around a sentinel node, so the list never has to special-case its ends.
+// Every operation, including walking with Node.Next and Node.Prev, is guarded by the list's mutex.
+type LinkedList[T any] struct {
+	root   Node[T]    // sentinel: root.next is the front, root.prev is the back
+	length int        // number of elements, excluding the sentinel
+	mu     sync.Mutex // guards all links and length
+}
+
+// Node is an element of a LinkedList.
+// Value may be read and written freely, but it is not protected by the list's mutex.
+type Node[T any] struct {
+	Value T              // the stored element
+	next  *Node[T]       // following node, the sentinel at the back
+	prev  *Node[T]       // preceding node, the sentinel at the front
+	list  *LinkedList[T] // owning list, nil once removed
+}
+
+// NewLinkedList creates an empty list.
+func NewLinkedList[T any]() *LinkedList[T] {
+	l := &LinkedList[T]{}
+	l.init()
+	return l
+}
+
+// init makes the list empty (must be called with lock held or before publication).
+func (l *LinkedList[T]) init() {
+	l.root.next = &l.root
+	l.root.prev = &l.root
+	l.length = 0
+}
+
+// Next returns the node after n, or nil if n is the back node or no longer in a list.
+func (n *Node[T]) Next() *Node[T] {
+	l := n.list
+	if l == nil {
+		return nil
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if n.list == nil || n.next == &l.root {
+		return nil
+	}
+	return n.next
+}
+
+// Prev returns the node before n, or nil if n is the front node or no longer in a list.
+func (n *Node[T]) Prev() *Node[T] {
+	l := n.list
+	if l == nil {
+		return nil
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if n.list == nil || n.prev == &l.root {
+		return nil
+	}
+	return n.prev
+}
+
+// insertAfter links a new node holding val after at and returns it (must be called with lock held).
+func (l *LinkedList[T]) insertAfter(at *Node[T], val T) *Node[T] {
+	n := &Node[T]{Value: val, prev: at, next: at.next, list: l}
+	at.next.prev = n
+	at.next = n
+	l.length++
+	return n
+}
+
+// unlink removes n from the list and clears its links so it retains nothing (must be called with lock held).
+func (l *LinkedList[T]) unlink(n *Node[T]) {
+	n.prev.next = n.next
+	n.next.prev = n.prev
+	n.next, n.prev, n.list = nil, nil, nil
+	l.length--
+}
+
+// PushFront adds val at the front of the list and returns its node.
+func (l *LinkedList[T]) PushFront(val T) *Node[T] {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.insertAfter(&l.root, val)
+}
+
+// PushBack adds val at the back of the list and returns its node.
+func (l *LinkedList[T]) PushBack(val T) *Node[T] {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.insertAfter(l.root.prev, val)
+}
+
+// PopFront removes and returns the front element.
+func (l *LinkedList[T]) PopFront() (T, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var zero T
+	if l.length == 0 {
+		return zero, false
+	}
+	n := l.root.next
+	l.unlink(n)
+	return n.Value, true
+}
+
+// PopBack removes and returns the back element.
+func (l *LinkedList[T]) PopBack() (T, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var zero T
+	if l.length == 0 {
+		return zero, false
+	}
+	n := l.root.prev
+	l.unlink(n)
+	return n.Value, true
+}
+
+// InsertAfter inserts val immediately after node and returns the new node.
+// Returns nil and leaves the list unchanged if node does not belong to this list.
+func (l *LinkedList[T]) InsertAfter(node *Node[T], val T) *Node[T] {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if node == nil || node.list != l {
+		return nil
+	}
+	return l.insertAfter(node, val)
+}
+
+// InsertBefore inserts val immediately before node and returns the new node.
+// Returns nil and leaves the list unchanged if node does not belong to this list.
+func (l *LinkedList[T]) InsertBefore(node *Node[T], val T) *Node[T] {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if node == nil || node.list != l {
+		return nil
+	}
+	return l.insertAfter(node.prev, val)
+}
+
+// Remove removes node from the list in O(1). Returns false if node does not belong to this list.
+func (l *LinkedList[T]) Remove(node *Node[T]) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if node == nil || node.list != l {
+		return false
+	}
+	l.unlink(node)
+	return true
+}
+
+// lockPair locks the mutexes of a and b in address order, so that concurrent splices between the same two lists
+// cannot deadlock, and returns a function that unlocks them. a and b may be the same list.
+func lockPair[T any](a, b *LinkedList[T]) (unlock func()) {
+	if a == b {
+		a.mu.Lock()
+		return a.mu.Unlock
+	}
+	if uintptr(unsafe.Pointer(a)) > uintptr(unsafe.Pointer(b)) {
+		a, b = b, a
+	}
+	a.mu.Lock()
+	b.mu.Lock()
+	return func() {
+		b.mu.Unlock()
+		a.mu.Unlock()
+	}
+}
+
+// Splice cuts the run of count nodes starting at src out of the list holding src, which may be l or another list,
+// and inserts it immediately after dst in l, or at the front of l if dst is nil. The nodes are relinked, not
+// copied, so they stay valid, and the lengths of both lists are updated. The relinking is O(1); the run is walked
+// once to find its end and, when it moves to another list, to record its new owner.
+// Returns false and changes nothing if count is not positive, src is not in a list, dst does not belong to l,
+// fewer than count nodes start at src, or dst is part of the run.
+func (l *LinkedList[T]) Splice(dst, src *Node[T], count int) bool {
+	if src == nil || count <= 0 {
+		return false
+	}
+	from := src.list
+	if from == nil {
+		return false
+	}
+	unlock := lockPair(l, from)
+	defer unlock()
+
+	at := &l.root
+	if dst != nil {
+		if dst.list != l {
+			return false
+		}
+		at = dst
+	}
+	if src.list != from {
+		return false // moved by a concurrent operation before the locks were taken
+	}
+	last := src
+	for i := 0; ; i++ {
+		if last == at {
+			return false
+		}
+		if i == count-1 {
+			break
+		}
+		if last = last.next; last == &from.root {
+			return false
+		}
+	}
+
+	src.prev.next = last.next
+	last.next.prev = src.prev
+	src.prev = at
+	last.next = at.next
+	at.next.prev = last
+	at.next = src
+	if from != l {
+		for n := src; ; n = n.next {
+			n.list = l
+			if n == last {
+				break
+			}
+		}
+		from.length -= count
+		l.length += count
+	}
+	return true
+}
+
+// Front returns the front node, or nil if the list is empty.
+func (l *LinkedList[T]) Front() *Node[T] {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.length == 0 {
+		return nil
+	}
+	return l.root.next
+}
+
+// Back returns the back node, or nil if the list is empty.
+func (l *LinkedList[T]) Back() *Node[T] {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.length == 0 {
+		return nil
+	}
+	return l.root.prev
+}
+
+// Len returns the number of elements in the list.
+func (l *LinkedList[T]) Len() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.length
+}
+
+// Empty returns true if the list contains no elements.
+func (l *LinkedList[T]) Empty() bool {
+	return l.Len() == 0
+}
+
+// Clear removes all elements. Nodes obtained earlier are detached and their Next and Prev return nil.
+func (l *LinkedList[T]) Clear() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for n := l.root.next; n != &l.root; {
+		next := n.next
+		n.next, n.prev, n.list = nil, nil, nil
+		n = next
+	}
+	l.init()
+}
+
+// Copy creates a new independent list holding the same values in the same order.
+func (l *LinkedList[T]) Copy() *LinkedList[T] {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	c := NewLinkedList[T]()
+	for n := l.root.next; n != &l.root; n = n.next {
+		c.insertAfter(c.root.prev, n.Value)
+	}
+	return c
+}
+
+// Reverse reverses the order of the elements in place in O(n). Existing nodes stay valid.
+func (l *LinkedList[T]) Reverse() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	n := &l.root
+	for {
+		n.next, n.prev = n.prev, n.next
+		n = n.prev // the old next
+		if n == &l.root {
+			return
+		}
+	}
+}
+
+// Sort sorts the list in place into the order given by less, keeping equal elements in their original order. It is a bottom-up merge sort: runs of 1, 2, 4, ... nodes are merged pairwise by relinking
+// their pointers, in O(n log n) time with O(1) extra memory and no recursion. Existing nodes stay valid.
+// less is called under the list's mutex, so it must not use the list.
+func (l *LinkedList[T]) Sort(less func(a, b T) bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.length < 2 {
+		return
+	}
+
+	// Sort the nodes as a nil-terminated singly linked chain, then restore the prev links and the ring
+	head := l.root.next
+	l.root.prev.next = nil
+	for width := 1; ; width *= 2 {
+		var tail *Node[T]
+		p, merges := head, 0
+		head = nil
+		for p != nil {
+			merges++
+			q, psize := p, 0
+			for psize < width && q != nil {
+				q = q.next
+				psize++
+			}
+			qsize := width
+			for psize > 0 || (qsize > 0 && q != nil) {
+				var n *Node[T]
+				if psize > 0 && (qsize == 0 || q == nil || !less(q.Value, p.Value)) {
+					n, p = p, p.next
+					psize--
+				} else {
+					n, q = q, q.next
+					qsize--
+				}
+				if tail == nil {
+					head = n
+				} else {
+					tail.next = n
+				}
+				tail = n
+			}
+			p = q
+		}
+		tail.next = nil
+		if merges <= 1 {
+			break
+		}
+	}
+
+	prev := &l.root
+	for n := head; n != nil; n = n.next {
+		n.prev = prev
+		prev.next = n
+		prev = n
+	}
+	prev.next = &l.root
+	l.root.prev = prev
+}
+
+// ToSlice returns a copy of the values from front to back.
+func (l *LinkedList[T]) ToSlice() []T {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	out := make([]T, 0, l.length)
+	for n := l.root.next; n != &l.root; n = n.next {
+		out = append(out, n.Value)
+	}
+	return out
+}
+
+// All returns an iterator over the values from front to back.
+// It iterates over a snapshot taken when iteration starts, so the loop body may modify the list.
+func (l *LinkedList[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, val := range l.ToSlice() {
+			if !yield(val) {
+				return
+			}
+		}
+	}
+}
+
+// ForEach calls fn with the index and value of every element from front to back.
+// It iterates over a snapshot, so fn may safely modify the list.
+func (l *LinkedList[T]) ForEach(fn func(int, T)) {
+	for i, val := range l.ToSlice() {
+		fn(i, val)
+	}
+}
+
+// Format implements the fmt.Formatter interface, printing the values from front to back like a slice.
+func (l *LinkedList[T]) Format(f fmt.State, verb rune) {
+	switch verb {
+	case 'v', 's':
+		var b strings.Builder
+		b.WriteByte('[')
+		for i, val := range l.ToSlice() {
+			if i > 0 {
+				b.WriteByte(' ')
+			}
+			b.WriteString(fmt.Sprint(val))
+		}
+		b.WriteByte(']')
+		_, _ = io.WriteString(f, b.String())
+	default:
+		_, _ = fmt.Fprintf(f, "%%!%c(linkedlist)", verb)
+	}
+}
@@ -0,0 +1,108 @@
+package LockFreeQueue
+
+import (
+	"sync/atomic"
+
+	"GoSTL/internal/nodepool"
+)
+
+// LockFreeQueue is a Michael-Scott non-blocking FIFO queue: a singly linked list with a sentinel node,
+// whose head and tail pointers are advanced with compare-and-swap. Enqueue and Dequeue never take a lock,
+// and any goroutine that finds the tail lagging behind helps advance it, so no operation waits on another.
+// Dequeued nodes are recycled through a sync.Pool when no other operation is in flight, which makes a
+// single-goroutine enqueue/dequeue cycle allocation-free; see package GoSTL/internal/nodepool for why this is ABA-safe.
+//
+// The value of the most recently dequeued element stays referenced by the sentinel node until the next
+// Dequeue, because other goroutines may still be reading it. The zero value is an empty queue ready to use.
+type LockFreeQueue[T any] struct {
+	head  atomic.Pointer[node[T]] // sentinel; the front element is head.next
+	tail  atomic.Pointer[node[T]] // last node, or briefly the one before it
+	nodes nodepool.Pool[node[T]]  // recycled nodes
+}
+
+// node is a list cell; value is fixed before the node is published and next is set once with a CAS.
+type node[T any] struct {
+	value T
+	next  atomic.Pointer[node[T]]
+}
+
+// NewLockFreeQueue creates an empty queue.
+func NewLockFreeQueue[T any]() *LockFreeQueue[T] {
+	q := &LockFreeQueue[T]{}
+	q.init()
+	return q
+}
+
+// init installs the sentinel node of a zero-value queue. Concurrent callers agree on a single sentinel:
+// head is set first, and no operation moves head before tail has been set as well.
+func (q *LockFreeQueue[T]) init() {
+	if q.tail.Load() != nil {
+		return
+	}
+	q.head.CompareAndSwap(nil, &node[T]{})
+	q.tail.CompareAndSwap(nil, q.head.Load())
+}
+
+// Enqueue adds val to the back of the queue.
+func (q *LockFreeQueue[T]) Enqueue(val T) {
+	q.init()
+	q.nodes.Enter()
+	defer q.nodes.Exit()
+	n := q.nodes.Get()
+	n.value = val
+	for {
+		tail := q.tail.Load()
+		next := tail.next.Load()
+		if tail != q.tail.Load() {
+			continue // tail moved while we read it
+		}
+		if next != nil {
+			// Tail is lagging: help the other enqueuer finish before retrying
+			q.tail.CompareAndSwap(tail, next)
+			continue
+		}
+		if tail.next.CompareAndSwap(nil, n) {
+			// Linked in; swinging the tail may fail if someone helped already, which is fine
+			q.tail.CompareAndSwap(tail, n)
+			return
+		}
+	}
+}
+
+// Dequeue removes and returns the front element. Returns false if the queue is empty.
+func (q *LockFreeQueue[T]) Dequeue() (T, bool) {
+	q.init()
+	q.nodes.Enter()
+	defer q.nodes.Exit()
+	for {
+		head := q.head.Load()
+		tail := q.tail.Load()
+		next := head.next.Load()
+		if head != q.head.Load() {
+			continue // head moved while we read it
+		}
+		if next == nil {
+			var zero T
+			return zero, false
+		}
+		if head == tail {
+			// An enqueue is half done: advance the tail before removing the node behind it
+			q.tail.CompareAndSwap(tail, next)
+			continue
+		}
+		val := next.value
+		if q.head.CompareAndSwap(head, next) {
+			// The old sentinel is unlinked; next takes its place
+			q.nodes.Retire(head)
+			return val, true
+		}
+	}
+}
+
+// IsEmpty reports whether the queue has no elements at the moment of the call.
+func (q *LockFreeQueue[T]) IsEmpty() bool {
+	q.init()
+	q.nodes.Enter()
+	defer q.nodes.Exit()
+	return q.head.Load().next.Load() == nil
+}
@@ -0,0 +1,77 @@
+package LockFreeStack
+
+import (
+	"sync/atomic"
+
+	"GoSTL/internal/nodepool"
+)
+
+// LockFreeStack is a Treiber stack: a singly linked list whose head is replaced with a compare-and-swap,
+// so Push and Pop never block and never take a lock.
+//
+// Nodes are recycled through a sync.Pool when a Pop finds no other operation in flight, which makes a
+// single-goroutine push/pop cycle allocation-free; see package GoSTL/internal/nodepool for why this is ABA-safe.
+type LockFreeStack[T any] struct {
+	head  atomic.Pointer[node[T]] // top of the stack, nil when empty
+	nodes nodepool.Pool[node[T]]  // recycled nodes
+}
+
+// node is a list cell; value and next are fixed before the node is published.
+type node[T any] struct {
+	value T
+	next  *node[T]
+}
+
+// NewLockFreeStack creates an empty stack. The zero value is also ready to use.
+func NewLockFreeStack[T any]() *LockFreeStack[T] {
+	return &LockFreeStack[T]{}
+}
+
+// Push adds val to the top of the stack.
+func (s *LockFreeStack[T]) Push(val T) {
+	s.nodes.Enter()
+	defer s.nodes.Exit()
+	n := s.nodes.Get()
+	n.value = val
+	for {
+		n.next = s.head.Load()
+		if s.head.CompareAndSwap(n.next, n) {
+			return
+		}
+	}
+}
+
+// Pop removes and returns the top element. Returns false if the stack is empty.
+func (s *LockFreeStack[T]) Pop() (T, bool) {
+	s.nodes.Enter()
+	defer s.nodes.Exit()
+	for {
+		top := s.head.Load()
+		if top == nil {
+			var zero T
+			return zero, false
+		}
+		if s.head.CompareAndSwap(top, top.next) {
+			val := top.value
+			s.nodes.Retire(top)
+			return val, true
+		}
+	}
+}
+
+// Peek returns the top element without removing it. Returns false if the stack is empty.
+func (s *LockFreeStack[T]) Peek() (T, bool) {
+	s.nodes.Enter()
+	defer s.nodes.Exit()
+	top := s.head.Load()
+	if top == nil {
+		var zero T
+		return zero, false
+	}
+	return top.value, true
+}
+
+// IsEmpty reports whether the stack has no elements at the moment of the call.
+func (s *LockFreeStack[T]) IsEmpty() bool {
+	return s.head.Load() == nil
+}
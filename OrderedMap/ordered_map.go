@@ -0,0 +1,158 @@
+package OrderedMap
+
+import (
+	"sync"
+
+	"GoSTL/Deque"
+	"GoSTL/HashMap"
+)
+
+// OrderedMap is a thread-safe hash map that remembers the order in which keys were first inserted.
+// Values live in a HashMap for O(1) lookup and the keys are kept in insertion order in a Deque.
+// A secondary map from each key to its position in the deque lets Delete find the key in O(1); the slot is
+// marked dead rather than shifted out, dead slots at the front are popped, and the deque is compacted once
+// more than half of it is dead, so deletion is O(1) amortized.
+type OrderedMap[K comparable, V any] struct {
+	mu     sync.RWMutex               // guards all fields below
+	values *HashMap.HashMap[K, V]     // value of every key
+	pos    *HashMap.HashMap[K, int]   // position of every key, counted from the first slot ever pushed
+	order  *Deque.Deque[orderSlot[K]] // keys in insertion order, including dead slots
+	base   int                        // position of the deque's front slot
+	dead   int                        // number of dead slots in the deque
+}
+
+// orderSlot is a key in the insertion order; live is false once the key has been deleted.
+type orderSlot[K any] struct {
+	key  K
+	live bool
+}
+
+// NewOrderedMap creates an empty map.
+func NewOrderedMap[K comparable, V any]() *OrderedMap[K, V] {
+	return &OrderedMap[K, V]{
+		values: HashMap.NewHashMap[K, V](),
+		pos:    HashMap.NewHashMap[K, int](),
+		order:  Deque.NewDeque[orderSlot[K]](),
+	}
+}
+
+// Put stores val under key. A new key is appended to the insertion order; replacing the value of an existing
+// key keeps its position.
+func (m *OrderedMap[K, V]) Put(key K, val V) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if !m.values.ContainsKey(key) {
+		m.pos.Put(key, m.base+m.order.Len())
+		m.order.PushBack(orderSlot[K]{key: key, live: true})
+	}
+	m.values.Put(key, val)
+}
+
+// Get returns the value stored under key and whether it was present.
+func (m *OrderedMap[K, V]) Get(key K) (V, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.values.Get(key)
+}
+
+// Delete removes key from the map and from the insertion order. Returns true if it was present.
+func (m *OrderedMap[K, V]) Delete(key K) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	p, ok := m.pos.Get(key)
+	if !ok {
+		return false
+	}
+	m.values.Delete(key)
+	m.pos.Delete(key)
+	m.order.Set(p-m.base, orderSlot[K]{})
+	m.dead++
+
+	for front, ok := m.order.Front(); ok && !front.live; front, ok = m.order.Front() {
+		m.order.PopFront()
+		m.base++
+		m.dead--
+	}
+	if m.dead > m.order.Len()/2 {
+		m.compact()
+	}
+	return true
+}
+
+// compact drops the dead slots from the deque and renumbers the positions of the remaining keys
+// (must be called with lock held).
+func (m *OrderedMap[K, V]) compact() {
+	m.order.FilterInPlace(func(s orderSlot[K]) bool { return s.live })
+	m.order.ForEach(func(i int, s orderSlot[K]) {
+		m.pos.Put(s.key, i)
+	})
+	m.base = 0
+	m.dead = 0
+}
+
+// Len returns the number of entries in the map.
+func (m *OrderedMap[K, V]) Len() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.values.Len()
+}
+
+// Clear removes all entries.
+func (m *OrderedMap[K, V]) Clear() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.values.Clear()
+	m.pos.Clear()
+	m.order.Clear()
+	m.base = 0
+	m.dead = 0
+}
+
+// Keys returns the keys of the map in insertion order.
+func (m *OrderedMap[K, V]) Keys() []K {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	keys := make([]K, 0, m.values.Len())
+	m.order.ForEach(func(_ int, s orderSlot[K]) {
+		if s.live {
+			keys = append(keys, s.key)
+		}
+	})
+	return keys
+}
+
+// Values returns the values of the map in the insertion order of their keys.
+func (m *OrderedMap[K, V]) Values() []V {
+	entries := m.Entries()
+	values := make([]V, len(entries))
+	for i, e := range entries {
+		values[i] = e.Value
+	}
+	return values
+}
+
+// Entries returns the key-value pairs of the map in insertion order.
+func (m *OrderedMap[K, V]) Entries() []HashMap.Pair[K, V] {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	entries := make([]HashMap.Pair[K, V], 0, m.values.Len())
+	m.order.ForEach(func(_ int, s orderSlot[K]) {
+		if s.live {
+			val, _ := m.values.Get(s.key)
+			entries = append(entries, HashMap.Pair[K, V]{Key: s.key, Value: val})
+		}
+	})
+	return entries
+}
+
+// ForEach calls fn with every entry of the map in insertion order.
+// It iterates over a snapshot, so fn may modify the map.
+func (m *OrderedMap[K, V]) ForEach(fn func(K, V)) {
+	for _, e := range m.Entries() {
+		fn(e.Key, e.Value)
+	}
+}
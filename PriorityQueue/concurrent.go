@@ -0,0 +1,209 @@
+package PriorityQueue
+
+import (
+	"context"
+	"sync"
+)
+
+// ConcurrentPriorityQueue is a thread-safe priority queue for many concurrent producers and consumers.
+// Peek, Len and Empty take a read lock, so readers never wait for each other; Push, Pop, Update and Remove take
+// the write lock. Push returns a Handle that identifies the element for Update and Remove; a handle is
+// invalidated under the same write lock that pops or removes its element, so it can never reach another element.
+// BlockingPop waits for an element without spinning.
+type ConcurrentPriorityQueue[T any] struct {
+	items  []*Handle[T]     // heap-ordered handles, guarded by mu
+	cmp    func(a, b T) int // ordering, same sign convention as cmp.Compare
+	mu     sync.RWMutex     // guards items, pushed and the fields of every handle
+	pushed chan struct{}    // closed at the next push to wake BlockingPop, nil if none wait
+}
+
+// Handle identifies an element of a ConcurrentPriorityQueue. It stays valid until the element is popped or removed.
+type Handle[T any] struct {
+	val   T                           // the element
+	index int                         // position in the heap, -1 once the element has left the queue
+	queue *ConcurrentPriorityQueue[T] // owning queue
+}
+
+// NewConcurrentPriorityQueue creates an empty concurrent priority queue ordered by cmp, with an optional initial
+// capacity. cmp follows the same convention as for NewPriorityQueue.
+func NewConcurrentPriorityQueue[T any](cmp func(a, b T) int, initCap ...int) *ConcurrentPriorityQueue[T] {
+	capacity := 8
+	if len(initCap) > 0 && initCap[0] > 0 {
+		capacity = initCap[0]
+	}
+	return &ConcurrentPriorityQueue[T]{items: make([]*Handle[T], 0, capacity), cmp: cmp}
+}
+
+// less reports whether the element at i should be popped before the one at j (must be called with lock held).
+func (pq *ConcurrentPriorityQueue[T]) less(i, j int) bool {
+	return pq.cmp(pq.items[i].val, pq.items[j].val) < 0
+}
+
+// swap exchanges the handles at i and j and updates their positions (must be called with lock held).
+func (pq *ConcurrentPriorityQueue[T]) swap(i, j int) {
+	pq.items[i], pq.items[j] = pq.items[j], pq.items[i]
+	pq.items[i].index = i
+	pq.items[j].index = j
+}
+
+// up moves the element at i towards the root (must be called with lock held).
+func (pq *ConcurrentPriorityQueue[T]) up(i int) {
+	for i > 0 {
+		parent := (i - 1) / 2
+		if !pq.less(i, parent) {
+			return
+		}
+		pq.swap(i, parent)
+		i = parent
+	}
+}
+
+// down moves the element at i towards the leaves and reports whether it moved (must be called with lock held).
+func (pq *ConcurrentPriorityQueue[T]) down(i int) bool {
+	start, n := i, len(pq.items)
+	for {
+		first := i
+		if l := 2*i + 1; l < n && pq.less(l, first) {
+			first = l
+		}
+		if r := 2*i + 2; r < n && pq.less(r, first) {
+			first = r
+		}
+		if first == i {
+			return i != start
+		}
+		pq.swap(i, first)
+		i = first
+	}
+}
+
+// removeAt takes the element at i out of the heap and invalidates its handle (must be called with lock held).
+func (pq *ConcurrentPriorityQueue[T]) removeAt(i int) T {
+	n := len(pq.items) - 1
+	if i != n {
+		pq.swap(i, n)
+	}
+	h := pq.items[n]
+	pq.items[n] = nil // release the handle for GC
+	pq.items = pq.items[:n]
+	if i != n && !pq.down(i) {
+		pq.up(i)
+	}
+	h.index = -1
+	return h.val
+}
+
+// Push adds val to the queue in O(log n) and returns a handle to it, which may be ignored.
+// Goroutines waiting in BlockingPop are woken.
+func (pq *ConcurrentPriorityQueue[T]) Push(val T) *Handle[T] {
+	pq.mu.Lock()
+	defer pq.mu.Unlock()
+
+	h := &Handle[T]{val: val, index: len(pq.items), queue: pq}
+	pq.items = append(pq.items, h)
+	pq.up(h.index)
+	if pq.pushed != nil {
+		close(pq.pushed)
+		pq.pushed = nil
+	}
+	return h
+}
+
+// Pop removes and returns the highest-priority element in O(log n). Returns false if the queue is empty.
+func (pq *ConcurrentPriorityQueue[T]) Pop() (T, bool) {
+	pq.mu.Lock()
+	defer pq.mu.Unlock()
+
+	if len(pq.items) == 0 {
+		var zero T
+		return zero, false
+	}
+	return pq.removeAt(0), true
+}
+
+// TryPop is Pop: it never blocks and returns false if the queue is empty. It is the non-blocking
+// counterpart of BlockingPop.
+func (pq *ConcurrentPriorityQueue[T]) TryPop() (T, bool) {
+	return pq.Pop()
+}
+
+// BlockingPop removes and returns the highest-priority element, blocking while the queue is empty until another
+// goroutine pushes or ctx is done, in which case it returns ctx.Err(). Nothing is popped if ctx is already done.
+func (pq *ConcurrentPriorityQueue[T]) BlockingPop(ctx context.Context) (T, error) {
+	var zero T
+	for {
+		if err := ctx.Err(); err != nil {
+			return zero, err
+		}
+		pq.mu.Lock()
+		if len(pq.items) > 0 {
+			val := pq.removeAt(0)
+			pq.mu.Unlock()
+			return val, nil
+		}
+		if pq.pushed == nil {
+			pq.pushed = make(chan struct{})
+		}
+		pushed := pq.pushed
+		pq.mu.Unlock()
+
+		select {
+		case <-pushed:
+		case <-ctx.Done():
+			return zero, ctx.Err()
+		}
+	}
+}
+
+// Peek returns the highest-priority element without removing it. Returns false if the queue is empty.
+func (pq *ConcurrentPriorityQueue[T]) Peek() (T, bool) {
+	pq.mu.RLock()
+	defer pq.mu.RUnlock()
+
+	if len(pq.items) == 0 {
+		var zero T
+		return zero, false
+	}
+	return pq.items[0].val, true
+}
+
+// Update replaces the element identified by h with val and restores the heap order in O(log n).
+// Returns false, changing nothing, if h's element has been popped or removed or h belongs to another queue.
+func (pq *ConcurrentPriorityQueue[T]) Update(h *Handle[T], val T) bool {
+	pq.mu.Lock()
+	defer pq.mu.Unlock()
+
+	if h == nil || h.queue != pq || h.index < 0 {
+		return false
+	}
+	h.val = val
+	if !pq.down(h.index) {
+		pq.up(h.index)
+	}
+	return true
+}
+
+// Remove removes the element identified by h in O(log n) and returns it, invalidating h.
+// Returns false if h's element has already been popped or removed or h belongs to another queue.
+func (pq *ConcurrentPriorityQueue[T]) Remove(h *Handle[T]) (T, bool) {
+	pq.mu.Lock()
+	defer pq.mu.Unlock()
+
+	if h == nil || h.queue != pq || h.index < 0 {
+		var zero T
+		return zero, false
+	}
+	return pq.removeAt(h.index), true
+}
+
+// Len returns the number of elements in the queue.
+func (pq *ConcurrentPriorityQueue[T]) Len() int {
+	pq.mu.RLock()
+	defer pq.mu.RUnlock()
+	return len(pq.items)
+}
+
+// Empty returns true if the queue contains no elements.
+func (pq *ConcurrentPriorityQueue[T]) Empty() bool {
+	return pq.Len() == 0
+}
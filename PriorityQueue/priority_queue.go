@@ -0,0 +1,178 @@
+package PriorityQueue
+
+import (
+	"cmp"
+	"slices"
+	"sync"
+	"sync/atomic"
+)
+
+// PriorityQueue is a thread-safe priority queue backed by an array-based binary heap.
+// The element with the lowest priority according to cmp is popped first; invert cmp for a max-heap.
+type PriorityQueue[T any] struct {
+	data    []T              // heap-ordered elements, guarded by mu
+	cmp     func(a, b T) int // ordering, same sign convention as cmp.Compare
+	length  atomic.Int32     // len(data), readable without the lock
+	mu      sync.Mutex       // guards data
+	initCap int              // initial capacity
+}
+
+// NewPriorityQueue creates an empty priority queue ordered by cmp, with an optional initial capacity.
+// cmp returns a negative number when a should be popped before b, zero when they are equal and a positive number otherwise.
+func NewPriorityQueue[T any](cmp func(a, b T) int, initCap ...int) *PriorityQueue[T] {
+	capacity := 8
+	if len(initCap) > 0 && initCap[0] > 0 {
+		capacity = initCap[0]
+	}
+	return &PriorityQueue[T]{data: make([]T, 0, capacity), cmp: cmp, initCap: capacity}
+}
+
+// NewMinHeap creates a priority queue that pops the smallest element first.
+func NewMinHeap[T cmp.Ordered](initCap ...int) *PriorityQueue[T] {
+	return NewPriorityQueue(cmp.Compare[T], initCap...)
+}
+
+// NewMaxHeap creates a priority queue that pops the largest element first.
+func NewMaxHeap[T cmp.Ordered](initCap ...int) *PriorityQueue[T] {
+	return NewPriorityQueue(func(a, b T) int { return cmp.Compare(b, a) }, initCap...)
+}
+
+// up moves the element at i towards the root (must be called with lock held).
+func (pq *PriorityQueue[T]) up(i int) {
+	for i > 0 {
+		parent := (i - 1) / 2
+		if pq.cmp(pq.data[i], pq.data[parent]) >= 0 {
+			return
+		}
+		pq.data[i], pq.data[parent] = pq.data[parent], pq.data[i]
+		i = parent
+	}
+}
+
+// down moves the element at i towards the leaves (must be called with lock held).
+func (pq *PriorityQueue[T]) down(i int) {
+	n := len(pq.data)
+	for {
+		first := i
+		if l := 2*i + 1; l < n && pq.cmp(pq.data[l], pq.data[first]) < 0 {
+			first = l
+		}
+		if r := 2*i + 2; r < n && pq.cmp(pq.data[r], pq.data[first]) < 0 {
+			first = r
+		}
+		if first == i {
+			return
+		}
+		pq.data[i], pq.data[first] = pq.data[first], pq.data[i]
+		i = first
+	}
+}
+
+// Push adds val to the queue in O(log n).
+func (pq *PriorityQueue[T]) Push(val T) {
+	pq.mu.Lock()
+	defer pq.mu.Unlock()
+	pq.data = append(pq.data, val)
+	pq.up(len(pq.data) - 1)
+	pq.length.Store(int32(len(pq.data)))
+}
+
+// Pop removes and returns the highest-priority element in O(log n). Returns false if the queue is empty.
+func (pq *PriorityQueue[T]) Pop() (T, bool) {
+	var zero T
+	if pq.length.Load() == 0 {
+		return zero, false
+	}
+
+	pq.mu.Lock()
+	defer pq.mu.Unlock()
+	n := len(pq.data)
+	if n == 0 {
+		return zero, false
+	}
+	top := pq.data[0]
+	pq.data[0] = pq.data[n-1]
+	pq.data[n-1] = zero // release the reference for GC
+	pq.data = pq.data[:n-1]
+	pq.down(0)
+	pq.length.Store(int32(n - 1))
+	return top, true
+}
+
+// Peek returns the highest-priority element without removing it. Returns false if the queue is empty.
+func (pq *PriorityQueue[T]) Peek() (T, bool) {
+	pq.mu.Lock()
+	defer pq.mu.Unlock()
+
+	var zero T
+	if len(pq.data) == 0 {
+		return zero, false
+	}
+	return pq.data[0], true
+}
+
+// Len returns the number of elements in the queue.
+func (pq *PriorityQueue[T]) Len() int {
+	return int(pq.length.Load())
+}
+
+// Empty returns true if the queue contains no elements.
+func (pq *PriorityQueue[T]) Empty() bool {
+	return pq.length.Load() == 0
+}
+
+// Capacity returns the capacity of the backing array.
+func (pq *PriorityQueue[T]) Capacity() int {
+	pq.mu.Lock()
+	defer pq.mu.Unlock()
+	return cap(pq.data)
+}
+
+// Clear removes all elements while keeping the allocated capacity.
+func (pq *PriorityQueue[T]) Clear() {
+	pq.mu.Lock()
+	defer pq.mu.Unlock()
+	clear(pq.data)
+	pq.data = pq.data[:0]
+	pq.length.Store(0)
+}
+
+// Copy creates a new independent copy of the queue with the same ordering.
+func (pq *PriorityQueue[T]) Copy() *PriorityQueue[T] {
+	pq.mu.Lock()
+	defer pq.mu.Unlock()
+
+	c := &PriorityQueue[T]{data: slices.Clone(pq.data), cmp: pq.cmp, initCap: pq.initCap}
+	c.length.Store(int32(len(c.data)))
+	return c
+}
+
+// ShrinkToFit reduces the capacity of the backing array to the number of elements,
+// but not below the initial capacity.
+func (pq *PriorityQueue[T]) ShrinkToFit() {
+	pq.mu.Lock()
+	defer pq.mu.Unlock()
+
+	newCap := max(len(pq.data), pq.initCap)
+	if newCap < cap(pq.data) {
+		pq.data = append(make([]T, 0, newCap), pq.data...)
+	}
+}
+
+// ToSlice returns the elements in the order they would be popped. It costs O(n log n).
+func (pq *PriorityQueue[T]) ToSlice() []T {
+	pq.mu.Lock()
+	out := slices.Clone(pq.data)
+	pq.mu.Unlock()
+
+	slices.SortStableFunc(out, pq.cmp)
+	return out
+}
+
+// ForEach calls fn with the index and value of every element in the order they would be popped.
+// It iterates over a sorted copy, so fn may safely use the queue.
+func (pq *PriorityQueue[T]) ForEach(fn func(int, T)) {
+	for i, val := range pq.ToSlice() {
+		fn(i, val)
+	}
+}
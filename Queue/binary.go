@@ -0,0 +1,24 @@
+package queue
+
+import "GoSTL/Deque"
+
+// MarshalBinary implements encoding.BinaryMarshaler using the underlying deque's binary format,
+// which holds the elements from front to back and the initial capacity.
+func (q *Queue[T]) MarshalBinary() ([]byte, error) {
+	return q.d.MarshalBinary()
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler, replacing the contents of the queue with the elements
+// encoded by MarshalBinary. The queue is left unchanged on error.
+// A zero Queue is initialized with the default capacity first.
+func (q *Queue[T]) UnmarshalBinary(b []byte) error {
+	if q.d == nil {
+		d := Deque.NewDeque[T]()
+		if err := d.UnmarshalBinary(b); err != nil {
+			return err
+		}
+		q.d = d
+		return nil
+	}
+	return q.d.UnmarshalBinary(b)
+}
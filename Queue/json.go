@@ -0,0 +1,23 @@
+package queue
+
+import "GoSTL/Deque"
+
+// MarshalJSON implements json.Marshaler, encoding the elements as a JSON array from front to back.
+func (q *Queue[T]) MarshalJSON() ([]byte, error) {
+	return q.d.MarshalJSON()
+}
+
+// UnmarshalJSON implements json.Unmarshaler, replacing the contents of the queue with the elements of a JSON array,
+// the first element becoming the front. The queue is left unchanged on error.
+// A zero Queue, such as a struct field, is initialized with the default capacity first.
+func (q *Queue[T]) UnmarshalJSON(b []byte) error {
+	if q.d == nil {
+		d := Deque.NewDeque[T]()
+		if err := d.UnmarshalJSON(b); err != nil {
+			return err
+		}
+		q.d = d
+		return nil
+	}
+	return q.d.UnmarshalJSON(b)
+}
@@ -0,0 +1,44 @@
+package queue
+
+import "GoSTL/Deque"
+
+// QueueOption configures a Queue created by NewQueue or NewQueueFrom.
+type QueueOption func(*queueConfig)
+
+// queueConfig collects the settings applied by QueueOption values.
+type queueConfig struct {
+	deque []Deque.DequeOption // options forwarded to the underlying deque
+}
+
+// WithInitialCapacity sets the initial capacity of the queue.
+// It is ignored if it is not positive or smaller than the data passed to NewQueueFrom.
+func WithInitialCapacity(n int) QueueOption {
+	return func(c *queueConfig) {
+		c.deque = append(c.deque, Deque.WithCapacity(n))
+	}
+}
+
+// WithMaxCapacity sets a hard limit on the capacity of the queue; 0 means unbounded.
+// Pushing onto a queue that is full at n panics.
+func WithMaxCapacity(n int) QueueOption {
+	return func(c *queueConfig) {
+		c.deque = append(c.deque, Deque.WithMaxCapacity(n))
+	}
+}
+
+// WithGrowthFactor sets the multiplier applied to the capacity whenever the queue is full.
+// Factors not greater than 1 are ignored and the default factor of 2 is used.
+func WithGrowthFactor(f float64) QueueOption {
+	return func(c *queueConfig) {
+		c.deque = append(c.deque, Deque.WithGrowthFactor(f))
+	}
+}
+
+// newQueueFromOptions builds the queue described by opts, holding a copy of data.
+func newQueueFromOptions[T any](data []T, opts []QueueOption) *Queue[T] {
+	var cfg queueConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return &Queue[T]{d: Deque.NewDequeWithData(data, cfg.deque...)}
+}
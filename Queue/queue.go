@@ -11,20 +11,32 @@ type Queue[T any] struct {
 	d *Deque.Deque[T] // underlying deque that stores the queue elements
 }
 
-// NewQueue creates and initializes a new Queue with an initial capacity of 8.
+// NewQueue creates and initializes a new Queue configured by opts.
+// Without options the queue is empty with an initial capacity of 8.
 // The queue uses a deque internally for efficient operations at both ends.
 // Returns a pointer to the newly created Queue.
-func NewQueue[T any]() *Queue[T] {
-	Q := &Queue[T]{d: Deque.NewDeque[T]()}
-	return Q
+func NewQueue[T any](opts ...QueueOption) *Queue[T] {
+	return newQueueFromOptions[T](nil, opts)
+}
+
+// NewQueueFrom creates a Queue holding a copy of vals, vals[0] being the front, configured by opts.
+// Panics if vals do not fit within the limit set by WithMaxCapacity.
+func NewQueueFrom[T any](vals []T, opts ...QueueOption) *Queue[T] {
+	return newQueueFromOptions(vals, opts)
 }
 
 // Init initializes or clears the queue with the specified initial capacity.
+// Capacities below 1 use the default of 8, and a bounded queue is limited to its maximum capacity.
 // This operation will remove all existing elements in the queue.
 func (q *Queue[T]) Init(n int) {
 	q.d.Init(n)
 }
 
+// Reset removes all elements and re-initializes the queue at its initial capacity.
+func (q *Queue[T]) Reset() {
+	q.d.Reset()
+}
+
 // Pop removes and returns the front element of the queue (FIFO operation).
 // Panics if the queue is empty.
 func (q *Queue[T]) Pop() (T, bool) {
@@ -42,6 +54,41 @@ func (q *Queue[T]) Push(value T) {
 	q.d.PushBack(value)
 }
 
+// Enqueue adds an element to the back of the queue.
+// It is an alias for Push, which is the canonical name.
+func (q *Queue[T]) Enqueue(value T) {
+	q.Push(value)
+}
+
+// Dequeue removes and returns the front element of the queue.
+// It is an alias for Pop, which is the canonical name.
+func (q *Queue[T]) Dequeue() (T, bool) {
+	return q.Pop()
+}
+
+// Peek returns the front element of the queue without removing it.
+// It is an alias for Front, which is the canonical name.
+func (q *Queue[T]) Peek() (T, bool) {
+	return q.Front()
+}
+
+// Back returns the most recently pushed element of the queue without removing it.
+func (q *Queue[T]) Back() (T, bool) {
+	return q.d.Back()
+}
+
+// PushFront adds an element to the front of the queue, so it is the next one popped.
+// This breaks strict FIFO order and is meant as an escape hatch for urgent elements.
+func (q *Queue[T]) PushFront(value T) {
+	q.d.PushFront(value)
+}
+
+// PopBack removes and returns the most recently pushed element of the queue.
+// This breaks strict FIFO order and is meant for draining or undoing pushes from the back.
+func (q *Queue[T]) PopBack() (T, bool) {
+	return q.d.PopBack()
+}
+
 // Len returns the number of elements in the queue.
 func (q *Queue[T]) Len() int {
 	return q.d.Len()
@@ -110,6 +157,30 @@ func (q *Queue[T]) Clear() {
 	q.d.Clear()
 }
 
+// Prioritise moves the element at index to the front of the queue, so it is the next one popped.
+// The elements that were ahead of it keep their order. Negative indices count from the back.
+// Returns false if index is out of range.
+func (q *Queue[T]) Prioritise(index int) bool {
+	return q.d.MoveToFront(index)
+}
+
+// Deprioritise moves the element at index to the back of the queue, so it is popped last.
+// The elements that were behind it keep their order. Negative indices count from the back.
+// Returns false if index is out of range.
+func (q *Queue[T]) Deprioritise(index int) bool {
+	return q.d.MoveToBack(index)
+}
+
+// ToSlice returns a copy of the queue's elements from front to back.
+func (q *Queue[T]) ToSlice() []T {
+	return q.d.ToSlice()
+}
+
+// ForEach calls fn with the index and value of every element from front to back.
+func (q *Queue[T]) ForEach(fn func(int, T)) {
+	q.d.ForEach(fn)
+}
+
 // String returns a string representation of the queue's elements.
 // The format is similar to a slice representation.
 func (q *Queue[T]) String() string {
@@ -0,0 +1,278 @@
+package SkipList
+
+import (
+	"math/rand/v2"
+	"sync"
+
+	"GoSTL/Tuple"
+)
+
+const (
+	maxLevel = 32 // maximum number of forward links per node
+	pBits    = 2  // a node reaches the next level with probability 1/(1<<pBits) = 0.25
+)
+
+// Pair is a key-value entry as returned by RangeQuery.
+type Pair[K, V any] = Tuple.KeyValue[K, V]
+
+// SkipList is a thread-safe map that keeps its keys sorted according to a comparator.
+// Each node is linked into a random number of levels, so lookups, insertions and deletions take expected
+// O(log n) time without any rebalancing. Every forward link also records how many entries it skips, which makes
+// Rank and ByRank O(log n) as well. Reads take a shared lock; writes take an exclusive lock.
+type SkipList[K comparable, V any] struct {
+	mu    sync.RWMutex     // guards all fields below
+	head  *node[K, V]      // sentinel whose forward links start every level
+	level int              // number of levels currently in use, at least 1
+	cmp   func(a, b K) int // key order
+	size  int              // number of entries
+}
+
+// node is a skip list node. next[i] is the following node on level i, and span[i] the number of level 0 steps
+// from this node to next[i], or past the last node if next[i] is nil.
+type node[K comparable, V any] struct {
+	key   K
+	value V
+	next  []*node[K, V]
+	span  []int
+}
+
+// NewSkipList creates an empty map ordered by cmp, which returns a negative number when a < b,
+// zero when a == b and a positive number when a > b.
+func NewSkipList[K comparable, V any](cmp func(K, K) int) *SkipList[K, V] {
+	return &SkipList[K, V]{
+		head:  &node[K, V]{next: make([]*node[K, V], maxLevel), span: make([]int, maxLevel)},
+		level: 1,
+		cmp:   cmp,
+	}
+}
+
+// randomLevel draws the number of levels for a new node from a geometric distribution with p = 0.25.
+// The 64 random bits are consumed two at a time, exactly enough for maxLevel levels.
+func randomLevel() int {
+	bits := rand.Uint64()
+	level := 1
+	for level < maxLevel && bits&(1<<pBits-1) == 0 {
+		level++
+		bits >>= pBits
+	}
+	return level
+}
+
+// findPredecessors fills update with the last node before key on every level in use, and rank with the number
+// of entries up to and including each of those nodes. It returns the first node whose key is not less than key,
+// or nil (must be called with lock held).
+func (s *SkipList[K, V]) findPredecessors(key K, update *[maxLevel]*node[K, V], rank *[maxLevel]int) *node[K, V] {
+	x, r := s.head, 0
+	for i := s.level - 1; i >= 0; i-- {
+		for x.next[i] != nil && s.cmp(x.next[i].key, key) < 0 {
+			r += x.span[i]
+			x = x.next[i]
+		}
+		update[i], rank[i] = x, r
+	}
+	return x.next[0]
+}
+
+// lowerBound returns the first node whose key is not less than key, or nil (must be called with lock held).
+func (s *SkipList[K, V]) lowerBound(key K) *node[K, V] {
+	x := s.head
+	for i := s.level - 1; i >= 0; i-- {
+		for x.next[i] != nil && s.cmp(x.next[i].key, key) < 0 {
+			x = x.next[i]
+		}
+	}
+	return x.next[0]
+}
+
+// Put stores val under key, replacing any existing value.
+func (s *SkipList[K, V]) Put(key K, val V) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var update [maxLevel]*node[K, V]
+	var rank [maxLevel]int
+	if x := s.findPredecessors(key, &update, &rank); x != nil && s.cmp(x.key, key) == 0 {
+		x.value = val
+		return
+	}
+
+	level := randomLevel()
+	for i := s.level; i < level; i++ {
+		update[i], rank[i] = s.head, 0
+		s.head.span[i] = s.size
+	}
+	s.level = max(s.level, level)
+
+	// The new node lands rank[0]+1 entries in; split each predecessor's span around it
+	n := &node[K, V]{key: key, value: val, next: make([]*node[K, V], level), span: make([]int, level)}
+	for i := 0; i < level; i++ {
+		n.next[i] = update[i].next[i]
+		update[i].next[i] = n
+		n.span[i] = update[i].span[i] - (rank[0] - rank[i])
+		update[i].span[i] = rank[0] - rank[i] + 1
+	}
+	for i := level; i < s.level; i++ {
+		update[i].span[i]++
+	}
+	s.size++
+}
+
+// Get returns the value stored under key.
+func (s *SkipList[K, V]) Get(key K) (V, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if x := s.lowerBound(key); x != nil && s.cmp(x.key, key) == 0 {
+		return x.value, true
+	}
+	var zero V
+	return zero, false
+}
+
+// Delete removes the entry for key. Returns true if it was present.
+func (s *SkipList[K, V]) Delete(key K) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var update [maxLevel]*node[K, V]
+	var rank [maxLevel]int
+	x := s.findPredecessors(key, &update, &rank)
+	if x == nil || s.cmp(x.key, key) != 0 {
+		return false
+	}
+	for i := 0; i < s.level; i++ {
+		if i < len(x.next) {
+			update[i].span[i] += x.span[i] - 1
+			update[i].next[i] = x.next[i]
+		} else {
+			update[i].span[i]--
+		}
+	}
+	for s.level > 1 && s.head.next[s.level-1] == nil {
+		s.level--
+	}
+	s.size--
+	return true
+}
+
+// Rank returns the 0-based position of key in ascending key order, and false if key is not present.
+func (s *SkipList[K, V]) Rank(key K) (int, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	x, r := s.head, 0
+	for i := s.level - 1; i >= 0; i-- {
+		for x.next[i] != nil && s.cmp(x.next[i].key, key) < 0 {
+			r += x.span[i]
+			x = x.next[i]
+		}
+	}
+	if x = x.next[0]; x != nil && s.cmp(x.key, key) == 0 {
+		return r, true
+	}
+	return 0, false
+}
+
+// ByRank returns the entry at the 0-based position rank in ascending key order, and false if rank is out of range.
+func (s *SkipList[K, V]) ByRank(rank int) (K, V, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if rank >= 0 && rank < s.size {
+		x, r := s.head, 0
+		for i := s.level - 1; i >= 0; i-- {
+			for x.next[i] != nil && r+x.span[i] <= rank+1 {
+				r += x.span[i]
+				x = x.next[i]
+			}
+			if r == rank+1 {
+				return x.key, x.value, true
+			}
+		}
+	}
+	var zeroK K
+	var zeroV V
+	return zeroK, zeroV, false
+}
+
+// RangeQuery returns the entries with lo <= key <= hi in ascending key order.
+// The result is empty if lo > hi.
+func (s *SkipList[K, V]) RangeQuery(lo, hi K) []Pair[K, V] {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	entries := make([]Pair[K, V], 0)
+	for x := s.lowerBound(lo); x != nil && s.cmp(x.key, hi) <= 0; x = x.next[0] {
+		entries = append(entries, Pair[K, V]{Key: x.key, Value: x.value})
+	}
+	return entries
+}
+
+// Min returns the entry with the smallest key.
+func (s *SkipList[K, V]) Min() (K, V, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if x := s.head.next[0]; x != nil {
+		return x.key, x.value, true
+	}
+	var zeroK K
+	var zeroV V
+	return zeroK, zeroV, false
+}
+
+// Max returns the entry with the largest key.
+func (s *SkipList[K, V]) Max() (K, V, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	x := s.head
+	for i := s.level - 1; i >= 0; i-- {
+		for x.next[i] != nil {
+			x = x.next[i]
+		}
+	}
+	if x != s.head {
+		return x.key, x.value, true
+	}
+	var zeroK K
+	var zeroV V
+	return zeroK, zeroV, false
+}
+
+// snapshot returns the entries in ascending key order.
+func (s *SkipList[K, V]) snapshot() []Pair[K, V] {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	entries := make([]Pair[K, V], 0, s.size)
+	for x := s.head.next[0]; x != nil; x = x.next[0] {
+		entries = append(entries, Pair[K, V]{Key: x.key, Value: x.value})
+	}
+	return entries
+}
+
+// ForEachAscending calls fn with every entry in ascending key order.
+// It iterates over a snapshot, so fn may modify the list.
+func (s *SkipList[K, V]) ForEachAscending(fn func(K, V)) {
+	for _, e := range s.snapshot() {
+		fn(e.Key, e.Value)
+	}
+}
+
+// Len returns the number of entries in the list.
+func (s *SkipList[K, V]) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.size
+}
+
+// Clear removes all entries.
+func (s *SkipList[K, V]) Clear() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	clear(s.head.next)
+	clear(s.head.span)
+	s.level = 1
+	s.size = 0
+}
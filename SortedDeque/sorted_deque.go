@@ -0,0 +1,114 @@
+package SortedDeque
+
+import (
+	"GoSTL/Deque"
+	"fmt"
+	"iter"
+	"sync"
+)
+
+// SortedDeque is a Deque that keeps its elements in ascending order according to a comparison function.
+// Inserts and removals binary-search for their position and shift the shorter side of the underlying deque,
+// so they cost O(log n) comparisons plus O(n) moves; lookups by index or value are O(1) and O(log n).
+type SortedDeque[T any] struct {
+	d   *Deque.Deque[T]  // underlying storage, always sorted by cmp
+	cmp func(a, b T) int // ordering: negative if a < b, zero if equal, positive if a > b
+	mu  sync.RWMutex     // makes multi-step searches atomic with respect to inserts and removals
+}
+
+// NewSortedDeque creates an empty SortedDeque ordered by cmp.
+func NewSortedDeque[T any](cmp func(a, b T) int) *SortedDeque[T] {
+	return &SortedDeque[T]{d: Deque.NewDeque[T](), cmp: cmp}
+}
+
+// search returns the smallest index whose element is not less than val (must be called with lock held).
+func (s *SortedDeque[T]) search(val T) int {
+	lo, hi := 0, s.d.Len()
+	for lo < hi {
+		mid := int(uint(lo+hi) >> 1)
+		x, _ := s.d.At(mid)
+		if s.cmp(x, val) < 0 {
+			lo = mid + 1
+		} else {
+			hi = mid
+		}
+	}
+	return lo
+}
+
+// Insert adds val, keeping the elements sorted, and returns the index at which it was inserted.
+// Elements equal to val stay in front of it.
+func (s *SortedDeque[T]) Insert(val T) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.d.SortedInsert(val, func(a, b T) bool { return s.cmp(a, b) < 0 })
+}
+
+// Remove removes one element equal to val. Returns true if an element was removed.
+func (s *SortedDeque[T]) Remove(val T) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.d.SortedRemove(val, s.cmp)
+}
+
+// At returns the element at the specified index in sorted order; negative indices count from the back.
+func (s *SortedDeque[T]) At(index int) (T, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.d.At(index)
+}
+
+// Front returns the smallest element.
+func (s *SortedDeque[T]) Front() (T, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.d.Front()
+}
+
+// Back returns the largest element.
+func (s *SortedDeque[T]) Back() (T, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.d.Back()
+}
+
+// Len returns the number of elements.
+func (s *SortedDeque[T]) Len() int {
+	return s.d.Len()
+}
+
+// Contains reports whether an element equal to val is present, in O(log n).
+func (s *SortedDeque[T]) Contains(val T) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	i := s.search(val)
+	x, ok := s.d.At(i)
+	return ok && s.cmp(x, val) == 0
+}
+
+// Range returns an iterator over the elements x with lo <= x < hi in ascending order.
+// The matching elements are copied when iteration starts, so the loop body may modify the SortedDeque.
+func (s *SortedDeque[T]) Range(lo, hi T) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		s.mu.RLock()
+		start, end := s.search(lo), s.search(hi)
+		var elems []T
+		for i := start; i < end; i++ {
+			x, _ := s.d.At(i)
+			elems = append(elems, x)
+		}
+		s.mu.RUnlock()
+
+		for _, x := range elems {
+			if !yield(x) {
+				return
+			}
+		}
+	}
+}
+
+// Format implements the fmt.Formatter interface, printing the elements in ascending order.
+func (s *SortedDeque[T]) Format(f fmt.State, verb rune) {
+	s.d.Format(f, verb)
+}
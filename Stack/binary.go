@@ -0,0 +1,42 @@
+package Stack
+
+import (
+	"fmt"
+	"sync/atomic"
+
+	"GoSTL/internal/codec"
+)
+
+// MarshalBinary implements encoding.BinaryMarshaler using the binary format shared by the GoSTL containers:
+// a fixed header (version byte, int64 length, int64 initial capacity, uint64 element type hash) followed by the
+// elements from top to bottom. Unused slots of the backing array are not written. Elements are encoded as for
+// Deque: element types the compact format cannot represent, such as pointers, maps and interfaces, go through
+// encoding/gob.
+func (s *Stack[T]) MarshalBinary() ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	top := int(atomic.LoadInt32(&s.top))
+	header := (*sliceHeader)(atomic.LoadPointer(&s.data))
+	b, err := codec.Encode(top, s.initCap, func(i int) T {
+		// Only called when top > 0, so the backing array exists even for a zero Stack
+		return (*[1 << 30]T)(header.data)[top-1-i]
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Stack: %w", err)
+	}
+	return b, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+// It replaces the contents of the stack with the elements encoded by MarshalBinary and restores the initial capacity,
+// limited to the capacity of a bounded stack. The push and pop hooks are not called.
+// The stack is left unchanged if the payload is malformed, was produced for a different element type or its
+// elements exceed the capacity of a bounded stack.
+func (s *Stack[T]) UnmarshalBinary(b []byte) error {
+	elems, initCap, err := codec.Decode[T](b, s.maxCap)
+	if err != nil {
+		return fmt.Errorf("Stack: %w", err)
+	}
+	return s.replace(elems, initCap)
+}
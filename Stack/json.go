@@ -0,0 +1,21 @@
+package Stack
+
+import "encoding/json"
+
+// MarshalJSON implements json.Marshaler, encoding the elements as a JSON array from top to bottom,
+// the same order as ToSlice. Errors from json.Marshal are returned as is.
+func (s *Stack[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.ToSlice())
+}
+
+// UnmarshalJSON implements json.Unmarshaler, replacing the contents of the stack with the elements of a JSON array
+// listed from top to bottom, as produced by MarshalJSON. The push and pop hooks are not called.
+// The stack is left unchanged on error or if the elements exceed the capacity of a bounded stack.
+// A JSON null empties the stack.
+func (s *Stack[T]) UnmarshalJSON(b []byte) error {
+	var elems []T
+	if err := json.Unmarshal(b, &elems); err != nil {
+		return err
+	}
+	return s.replace(elems, 0)
+}
@@ -3,6 +3,8 @@ package Stack
 import (
 	"fmt"
 	"io"
+	"iter"
+	"runtime"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -15,6 +17,12 @@ type Stack[T any] struct {
 	top     int32          // atomic stack pointer
 	mu      sync.Mutex     // only for resize operations
 	initCap int            // initial capacity
+	maxCap  int            // capacity limit of a bounded stack, 0 if unbounded
+	onPush  atomic.Value   // func(T) called after every push
+	onPop   atomic.Value   // func(T) called after every successful pop
+
+	pushing  atomic.Int32 // lock-free pushes between claiming a slot and writing it
+	draining atomic.Bool  // set by PopWhile to send pushes to the locked path
 }
 
 type sliceHeader struct {
@@ -35,12 +43,28 @@ func NewStack[T any](initCap ...int) *Stack[T] {
 	return q
 }
 
+// NewBoundedStack creates a stack that never grows beyond capacity elements.
+// Pushing onto a full bounded stack panics instead of resizing, and Resize panics on bounded stacks.
+// Panics if capacity is not positive.
+func NewBoundedStack[T any](capacity int) *Stack[T] {
+	if capacity <= 0 {
+		panic(fmt.Sprintf("Stack: invalid bounded stack capacity %d", capacity))
+	}
+	s := &Stack[T]{maxCap: capacity}
+	s.Init(capacity)
+	return s
+}
+
 // Init initializes or resets the stack with an initial capacity hint.
+// On a bounded stack the capacity is limited to the stack's maximum capacity.
 func (s *Stack[T]) Init(n int) {
 	capacity := 8
 	if n > capacity {
 		capacity = n
 	}
+	if s.maxCap > 0 && capacity > s.maxCap {
+		capacity = s.maxCap
+	}
 	s.initCap = capacity
 	data := make([]T, capacity)
 	header := (*sliceHeader)(unsafe.Pointer(&data))
@@ -49,89 +73,64 @@ func (s *Stack[T]) Init(n int) {
 }
 
 // Format implements the fmt.Formatter interface.
+// Elements are listed from top to bottom. With a width, e.g. %10v, only width/2 elements
+// (at least 3) are shown, followed by a count of the elements left out.
 func (s *Stack[T]) Format(f fmt.State, verb rune) {
 	switch verb {
 	case 'v', 's':
-		top := int(atomic.LoadInt32(&s.top))
-		if top == 0 {
-			_, _ = io.WriteString(f, "[]")
-			return
-		}
-
-		// Get display limit from width
-		limit := top
+		limit := 0
 		if width, ok := f.Width(); ok && width > 0 {
 			// Heuristic: show width/2 elements (minimum 3)
-			limit = width / 2
-			if limit < 3 {
-				limit = 3
-			}
-		}
-
-		// Ensure limit is valid
-		if limit > top {
-			limit = top
-		}
-
-		s.mu.Lock()
-		defer s.mu.Unlock()
-
-		header := (*sliceHeader)(atomic.LoadPointer(&s.data))
-		data := (*[1 << 30]T)(header.data)[:header.cap]
-
-		var b strings.Builder
-		b.WriteByte('[')
-
-		// Show elements from top (newest) to oldest
-		for i := 0; i < limit; i++ {
-			if i > 0 {
-				b.WriteByte(' ')
-			}
-			b.WriteString(fmt.Sprint(data[top-1-i]))
-		}
-
-		// Add ellipsis if we truncated
-		if limit < top {
-			b.WriteString(fmt.Sprintf(" ...+%d", top-limit))
+			limit = max(width/2, 3)
 		}
-
-		b.WriteByte(']')
-		_, _ = io.WriteString(f, b.String())
+		_, _ = io.WriteString(f, s.stringWithLimit(limit))
 	default:
 		_, _ = fmt.Fprintf(f, "%%!%c(stack)", verb)
 	}
 }
 
-// stringWithLimit generates the string representation with optional truncation.
+// String returns the elements from top to bottom, exactly as formatted by the %v verb.
+func (s *Stack[T]) String() string {
+	return s.stringWithLimit(0)
+}
+
+// StringN is like String but shows at most the top n elements, followed by " ...+K" when K elements are left out.
+// A non-positive n shows all elements.
+func (s *Stack[T]) StringN(n int) string {
+	return s.stringWithLimit(n)
+}
+
+// stringWithLimit generates the string representation with optional truncation; a non-positive limit shows everything.
 func (s *Stack[T]) stringWithLimit(limit int) string {
-	top := atomic.LoadInt32(&s.top)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	top := int(atomic.LoadInt32(&s.top))
 	if top == 0 {
 		return "[]"
 	}
 
-	s.mu.Lock()
-	defer s.mu.Unlock()
-
 	header := (*sliceHeader)(atomic.LoadPointer(&s.data))
 	data := (*[1 << 30]T)(header.data)[:header.cap]
 
 	var b strings.Builder
 	b.WriteByte('[')
 
-	showCount := int(top)
+	// Show elements from top (newest) to oldest
+	showCount := top
 	if limit > 0 && showCount > limit {
 		showCount = limit
 	}
-
-	for i := int(top) - 1; i >= int(top)-showCount; i-- {
-		if i != int(top)-1 {
+	for i := 0; i < showCount; i++ {
+		if i > 0 {
 			b.WriteByte(' ')
 		}
-		b.WriteString(fmt.Sprint(data[i]))
+		b.WriteString(fmt.Sprint(data[top-1-i]))
 	}
 
-	if limit > 0 && int(top) > limit {
-		_, _ = fmt.Fprintf(&b, " ...+%d", int(top)-limit)
+	// Add ellipsis if we truncated
+	if showCount < top {
+		_, _ = fmt.Fprintf(&b, " ...+%d", top-showCount)
 	}
 
 	b.WriteByte(']')
@@ -162,31 +161,69 @@ func (s *Stack[T]) Push(val T) {
 		header := (*sliceHeader)(atomic.LoadPointer(&s.data))
 
 		if int(top) < header.cap {
-			if atomic.CompareAndSwapInt32(&s.top, top, top+1) {
+			s.pushing.Add(1)
+			if !s.draining.Load() && atomic.CompareAndSwapInt32(&s.top, top, top+1) {
 				(*[1 << 30]T)(header.data)[top] = val
+				s.pushing.Add(-1)
+				s.callHook(&s.onPush, val)
 				return
 			}
-			continue
+			s.pushing.Add(-1)
+			if !s.draining.Load() {
+				continue
+			}
 		}
 
 		s.mu.Lock()
-		header = (*sliceHeader)(atomic.LoadPointer(&s.data))
-		if int(atomic.LoadInt32(&s.top)) == header.cap {
-			newCap := header.cap * 2
-			if newCap == 0 {
-				newCap = s.initCap
-			}
-			s.internalResize(newCap)
-			header = (*sliceHeader)(atomic.LoadPointer(&s.data))
+		if !s.pushLocked(val) {
+			s.mu.Unlock()
+			panic(s.fullMessage())
 		}
-		top = atomic.LoadInt32(&s.top)
-		(*[1 << 30]T)(header.data)[top] = val
-		atomic.StoreInt32(&s.top, top+1)
 		s.mu.Unlock()
+		s.callHook(&s.onPush, val)
 		return
 	}
 }
 
+// pushLocked pushes val, growing the backing array if it is full (must be called with lock held).
+// Returns false without pushing if a bounded stack is already at its maximum capacity.
+func (s *Stack[T]) pushLocked(val T) bool {
+	header := (*sliceHeader)(atomic.LoadPointer(&s.data))
+	if int(atomic.LoadInt32(&s.top)) == header.cap {
+		if s.maxCap > 0 && header.cap >= s.maxCap {
+			return false
+		}
+		newCap := header.cap * 2
+		if newCap == 0 {
+			newCap = s.initCap
+		}
+		if s.maxCap > 0 && newCap > s.maxCap {
+			newCap = s.maxCap
+		}
+		s.internalResize(newCap)
+		header = (*sliceHeader)(atomic.LoadPointer(&s.data))
+	}
+	top := atomic.LoadInt32(&s.top)
+	(*[1 << 30]T)(header.data)[top] = val
+	atomic.StoreInt32(&s.top, top+1)
+	return true
+}
+
+// fullMessage returns the panic message for a push onto a full bounded stack.
+func (s *Stack[T]) fullMessage() string {
+	return fmt.Sprintf("Stack: push on full bounded stack, capacity %d", s.maxCap)
+}
+
+// IsFull reports whether the stack is bounded and holds MaxCapacity elements. Unbounded stacks are never full.
+func (s *Stack[T]) IsFull() bool {
+	return s.maxCap > 0 && int(atomic.LoadInt32(&s.top)) >= s.maxCap
+}
+
+// MaxCapacity returns the capacity limit of a bounded stack, or 0 if the stack is unbounded.
+func (s *Stack[T]) MaxCapacity() int {
+	return s.maxCap
+}
+
 // Pop removes and returns the element from the top of the stack.
 func (s *Stack[T]) Pop() (T, bool) {
 	var zero T
@@ -198,9 +235,52 @@ func (s *Stack[T]) Pop() (T, bool) {
 
 		if atomic.CompareAndSwapInt32(&s.top, top, top-1) {
 			header := (*sliceHeader)(atomic.LoadPointer(&s.data))
-			return (*[1 << 30]T)(header.data)[top-1], true
+			val := (*[1 << 30]T)(header.data)[top-1]
+			s.callHook(&s.onPop, val)
+			return val, true
+		}
+	}
+}
+
+// PopWhile pops elements from the top for as long as pred reports true for the current top element,
+// and returns them in top-to-bottom order (the first element popped is at index 0).
+// The matching run is popped under a single mutex acquisition and the vacated slots are zeroed
+// so the stack no longer references them. The onPop hook is called for every popped element.
+// Returns an empty, non-nil slice if the top element does not match.
+func (s *Stack[T]) PopWhile(pred func(T) bool) []T {
+	s.mu.Lock()
+	// Lock-free pushes write their slot after claiming it, so a slot below the top may not hold its value yet,
+	// and a slot vacated here could be claimed again before it is zeroed. Send new pushes to the locked path
+	// and wait for those already claiming a slot to finish.
+	s.draining.Store(true)
+	for s.pushing.Load() != 0 {
+		runtime.Gosched()
+	}
+
+	var result []T
+	for {
+		top := int(atomic.LoadInt32(&s.top))
+		header := (*sliceHeader)(atomic.LoadPointer(&s.data))
+		data := (*[1 << 30]T)(header.data)[:header.cap]
+
+		result = []T{}
+		for i := top - 1; i >= 0 && pred(data[i]); i-- {
+			result = append(result, data[i])
 		}
+		n := len(result)
+		if atomic.CompareAndSwapInt32(&s.top, int32(top), int32(top-n)) {
+			clear(data[top-n : top])
+			break
+		}
+		// A lock-free pop moved the top; scan again
+	}
+	s.draining.Store(false)
+	s.mu.Unlock()
+
+	for _, val := range result {
+		s.callHook(&s.onPop, val)
 	}
+	return result
 }
 
 // Top returns the top element without removing it.
@@ -219,6 +299,11 @@ func (s *Stack[T]) Length() int {
 	return int(atomic.LoadInt32(&s.top))
 }
 
+// Len returns the number of elements in the stack. It is equivalent to Length.
+func (s *Stack[T]) Len() int {
+	return s.Length()
+}
+
 // Capacity returns the current capacity of the underlying storage.
 func (s *Stack[T]) Capacity() int {
 	header := (*sliceHeader)(atomic.LoadPointer(&s.data))
@@ -230,8 +315,11 @@ func (s *Stack[T]) Clear() {
 	atomic.StoreInt32(&s.top, 0)
 }
 
-// Resize changes the stack's capacity.
+// Resize changes the stack's capacity. Panics on a bounded stack, whose capacity is fixed.
 func (s *Stack[T]) Resize(newCap int) {
+	if s.maxCap > 0 {
+		panic(fmt.Sprintf("Stack: Resize called on bounded stack, capacity %d", s.maxCap))
+	}
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
@@ -315,7 +403,7 @@ func (s *Stack[T]) Copy() *Stack[T] {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	newStack := &Stack[T]{initCap: s.initCap}
+	newStack := &Stack[T]{initCap: s.initCap, maxCap: s.maxCap}
 	top := int(atomic.LoadInt32(&s.top))
 	header := (*sliceHeader)(atomic.LoadPointer(&s.data))
 	data := (*[1 << 30]T)(header.data)[:header.cap]
@@ -365,3 +453,179 @@ func (s *Stack[T]) Set(index int, val T) bool {
 	data[top-1-index] = val
 	return true
 }
+
+// SetOnPush registers fn to be called with the pushed value after every push.
+// The hook runs outside the stack's mutex and can be replaced at any time; passing nil disables it.
+func (s *Stack[T]) SetOnPush(fn func(val T)) {
+	s.onPush.Store(fn)
+}
+
+// SetOnPop registers fn to be called with the popped value after every successful pop.
+// The hook runs outside the stack's mutex and can be replaced at any time; passing nil disables it.
+func (s *Stack[T]) SetOnPop(fn func(val T)) {
+	s.onPop.Store(fn)
+}
+
+// callHook invokes the func(T) stored in hook, if any.
+func (s *Stack[T]) callHook(hook *atomic.Value, val T) {
+	if fn, _ := hook.Load().(func(T)); fn != nil {
+		fn(val)
+	}
+}
+
+// Debug returns a dump of the stack's internal state for debugging purposes:
+//
+//	top=N cap=C
+//	data=[slot0 slot1 ... slotC-1]
+//
+// Every slot of the backing array is listed bottom-up with its raw value, including slots at or above top,
+// so stale references left behind by pops are visible (a zeroed pointer slot prints as <nil>).
+// The output format is intended for humans only and may change without notice.
+func (s *Stack[T]) Debug() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	top := int(atomic.LoadInt32(&s.top))
+	header := (*sliceHeader)(atomic.LoadPointer(&s.data))
+	data := (*[1 << 30]T)(header.data)[:header.cap]
+
+	var b strings.Builder
+	_, _ = fmt.Fprintf(&b, "top=%d cap=%d\ndata=[", top, header.cap)
+	for i, val := range data {
+		if i > 0 {
+			b.WriteByte(' ')
+		}
+		b.WriteString(fmt.Sprint(val))
+	}
+	b.WriteByte(']')
+	return b.String()
+}
+
+// Drain returns an iterator that pops and yields elements from the top one at a time until the stack is empty.
+// Each step is a single Pop, so breaking out of the loop leaves the remaining elements in the stack
+// and elements pushed while iterating are yielded next.
+func (s *Stack[T]) Drain() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for {
+			val, ok := s.Pop()
+			if !ok || !yield(val) {
+				return
+			}
+		}
+	}
+}
+
+// All returns an iterator over the stack's elements from top to bottom, in the order ToSlice lists them.
+// It iterates over a snapshot taken when iteration starts, so the loop body may push to or pop from the stack.
+func (s *Stack[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, val := range s.ToSlice() {
+			if !yield(val) {
+				return
+			}
+		}
+	}
+}
+
+// PushUnique pushes val only if no element already in the stack is equal to it according to eq.
+// It scans from top to bottom under the mutex, so it costs O(n) per call; this is fine for small stacks
+// (under ~64 elements), while larger ones should track membership in a companion set instead.
+// Returns true if val was pushed.
+func (s *Stack[T]) PushUnique(val T, eq func(T, T) bool) bool {
+	s.mu.Lock()
+	top := int(atomic.LoadInt32(&s.top))
+	header := (*sliceHeader)(atomic.LoadPointer(&s.data))
+	data := (*[1 << 30]T)(header.data)[:header.cap]
+	for i := top - 1; i >= 0; i-- {
+		if eq(data[i], val) {
+			s.mu.Unlock()
+			return false
+		}
+	}
+	if !s.pushLocked(val) {
+		s.mu.Unlock()
+		panic(s.fullMessage())
+	}
+	s.mu.Unlock()
+	s.callHook(&s.onPush, val)
+	return true
+}
+
+// ToSlice returns a copy of the stack's elements ordered from top to bottom,
+// i.e. index 0 holds the element the next Pop would return.
+func (s *Stack[T]) ToSlice() []T {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	top := int(atomic.LoadInt32(&s.top))
+	header := (*sliceHeader)(atomic.LoadPointer(&s.data))
+	data := (*[1 << 30]T)(header.data)[:header.cap]
+
+	out := make([]T, top)
+	for i := range out {
+		out[i] = data[top-1-i]
+	}
+	return out
+}
+
+// FromSlice creates a stack holding a copy of s, with s[0] at the bottom and the last element on top,
+// so pushing the elements of s in order would produce the same stack.
+func FromSlice[T any](s []T) *Stack[T] {
+	st := NewStack[T](len(s))
+	header := (*sliceHeader)(atomic.LoadPointer(&st.data))
+	copy((*[1 << 30]T)(header.data)[:header.cap], s)
+	atomic.StoreInt32(&st.top, int32(len(s)))
+	return st
+}
+
+// replace replaces the contents of the stack with elems, listed from top to bottom, in a fresh backing array.
+// A positive initCap also replaces the initial capacity, limited to the capacity of a bounded stack.
+// Returns an error, leaving the stack unchanged, if elems exceed the capacity of a bounded stack.
+func (s *Stack[T]) replace(elems []T, initCap int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.maxCap > 0 && len(elems) > s.maxCap {
+		return fmt.Errorf("Stack: %d elements exceed bounded stack capacity %d", len(elems), s.maxCap)
+	}
+	if initCap > 0 {
+		s.initCap = initCap
+		if s.maxCap > 0 && initCap > s.maxCap {
+			s.initCap = s.maxCap
+		}
+	}
+	if s.initCap <= 0 {
+		s.initCap = 8
+	}
+	data := make([]T, max(s.initCap, len(elems)))
+	for i, val := range elems {
+		data[len(elems)-1-i] = val
+	}
+
+	header := (*sliceHeader)(unsafe.Pointer(&data))
+	atomic.StorePointer(&s.data, unsafe.Pointer(header))
+	atomic.StoreInt32(&s.top, int32(len(elems)))
+	return nil
+}
+
+// ForEach calls fn with the index and value of every element from top to bottom (index 0 is the top).
+// It iterates over a snapshot, so fn may safely push to or pop from the stack.
+func (s *Stack[T]) ForEach(fn func(int, T)) {
+	for i, val := range s.ToSlice() {
+		fn(i, val)
+	}
+}
+
+// ForEachBottom calls fn with the index and value of every element from bottom to top (index 0 is the bottom),
+// i.e. in the order they were pushed. Like ForEach, it iterates over a snapshot, so fn may safely push to or
+// pop from the stack.
+func (s *Stack[T]) ForEachBottom(fn func(int, T)) {
+	s.mu.Lock()
+	top := int(atomic.LoadInt32(&s.top))
+	header := (*sliceHeader)(atomic.LoadPointer(&s.data))
+	snapshot := append([]T(nil), (*[1 << 30]T)(header.data)[:top]...)
+	s.mu.Unlock()
+
+	for i, val := range snapshot {
+		fn(i, val)
+	}
+}
@@ -0,0 +1,82 @@
+package main_test
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"GoSTL/AVLTree"
+)
+
+func TestInsertRemoveContains(t *testing.T) {
+	tree := AVLTree.NewAVLTree[string](strings.Compare)
+	for _, v := range []string{"m", "c", "x", "a", "e", "c"} {
+		tree.Insert(v)
+	}
+	if tree.Len() != 5 || !tree.Contains("e") || tree.Contains("b") {
+		t.Fatalf("Unexpected tree %v", tree.InOrder())
+	}
+	if !tree.Remove("m") || tree.Remove("m") || tree.Contains("m") {
+		t.Error("Remove should succeed exactly once")
+	}
+	if got := tree.InOrder(); !slices.Equal(got, []string{"a", "c", "e", "x"}) {
+		t.Errorf("InOrder expected ascending order, got %v", got)
+	}
+	if v, ok := tree.Min(); !ok || v != "a" {
+		t.Errorf("Min expected a, got %s", v)
+	}
+	if v, ok := tree.Max(); !ok || v != "x" {
+		t.Errorf("Max expected x, got %s", v)
+	}
+}
+
+func TestCopyClear(t *testing.T) {
+	tree := AVLTree.NewAVLTree[int](cmp.Compare[int])
+	for i := 0; i < 100; i++ {
+		tree.Insert(i)
+	}
+	c := tree.Copy()
+	tree.Clear()
+	c.Remove(50)
+	if tree.Len() != 0 || len(tree.InOrder()) != 0 {
+		t.Error("Clear should remove every element")
+	}
+	if _, ok := tree.Min(); ok {
+		t.Error("Min of an empty tree should fail")
+	}
+	if _, ok := tree.Max(); ok {
+		t.Error("Max of an empty tree should fail")
+	}
+	if c.Len() != 99 || c.Contains(50) || !c.Contains(99) {
+		t.Error("Copy should be independent of the original")
+	}
+	tree.Insert(7)
+	if got := tree.InOrder(); !slices.Equal(got, []int{7}) {
+		t.Errorf("Tree should stay usable after Clear, got %v", got)
+	}
+}
+
+func TestConcurrentAccess(t *testing.T) {
+	tree := AVLTree.NewAVLTree[int](cmp.Compare[int])
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 500; i++ {
+				v := g*500 + i
+				tree.Insert(v)
+				tree.Contains(v)
+				if i%2 == 1 {
+					tree.Remove(v)
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+	if got := tree.InOrder(); len(got) != 2000 || !slices.IsSorted(got) {
+		t.Errorf("Expected 2000 sorted elements, got %d", len(got))
+	}
+}
@@ -0,0 +1,138 @@
+package main_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"GoSTL/BlockingQueue"
+)
+
+func TestTryPushPop(t *testing.T) {
+	q := BlockingQueue.NewBlockingQueue[int](3)
+	for i := 0; i < 3; i++ {
+		if !q.TryPush(i) {
+			t.Fatalf("TryPush(%d) should succeed", i)
+		}
+	}
+	if q.TryPush(3) || q.Len() != 3 || q.Cap() != 3 {
+		t.Fatal("TryPush on a full queue should fail")
+	}
+	for i := 0; i < 3; i++ {
+		if v, ok := q.TryPop(); !ok || v != i {
+			t.Fatalf("TryPop expected %d, got %d", i, v)
+		}
+	}
+	if _, ok := q.TryPop(); ok {
+		t.Error("TryPop on an empty queue should fail")
+	}
+}
+
+func TestBlocking(t *testing.T) {
+	q := BlockingQueue.NewBlockingQueue[string](1)
+	ctx := context.Background()
+	if err := q.Push(ctx, "a"); err != nil {
+		t.Fatal(err)
+	}
+
+	pushed := make(chan error)
+	go func() { pushed <- q.Push(ctx, "b") }()
+	select {
+	case <-pushed:
+		t.Fatal("Push on a full queue should block")
+	case <-time.After(20 * time.Millisecond):
+	}
+	if v, err := q.Pop(ctx); err != nil || v != "a" {
+		t.Fatalf("Pop expected a, got %q (%v)", v, err)
+	}
+	if err := <-pushed; err != nil {
+		t.Fatalf("Blocked Push should complete once a slot is free: %v", err)
+	}
+
+	popped := make(chan string)
+	q.TryPop()
+	go func() {
+		v, _ := q.Pop(ctx)
+		popped <- v
+	}()
+	time.Sleep(10 * time.Millisecond)
+	q.TryPush("c")
+	if v := <-popped; v != "c" {
+		t.Errorf("Blocked Pop expected c, got %q", v)
+	}
+}
+
+func TestCancellation(t *testing.T) {
+	q := BlockingQueue.NewBlockingQueue[int](1)
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := q.Pop(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Pop on an empty queue should time out, got %v", err)
+	}
+
+	q.TryPush(1)
+	cancelled, cancel2 := context.WithCancel(context.Background())
+	cancel2()
+	if err := q.Push(cancelled, 2); !errors.Is(err, context.Canceled) {
+		t.Errorf("Push with a cancelled context should fail, got %v", err)
+	}
+	if v, ok := q.TryPop(); !ok || v != 1 || q.Len() != 0 {
+		t.Error("A cancelled Push should not modify the queue")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("NewBlockingQueue(0) should panic")
+		}
+	}()
+	BlockingQueue.NewBlockingQueue[int](0)
+}
+
+func TestProducerConsumer(t *testing.T) {
+	q := BlockingQueue.NewBlockingQueue[int](4)
+	ctx := context.Background()
+	const producers, perProducer = 4, 500
+
+	var wg sync.WaitGroup
+	for p := 0; p < producers; p++ {
+		wg.Add(1)
+		go func(p int) {
+			defer wg.Done()
+			for i := 0; i < perProducer; i++ {
+				if err := q.Push(ctx, p*perProducer+i); err != nil {
+					t.Error(err)
+				}
+			}
+		}(p)
+	}
+
+	seen := make([]bool, producers*perProducer)
+	var mu sync.Mutex
+	var consumers sync.WaitGroup
+	for c := 0; c < 4; c++ {
+		consumers.Add(1)
+		go func() {
+			defer consumers.Done()
+			for i := 0; i < producers*perProducer/4; i++ {
+				v, err := q.Pop(ctx)
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				mu.Lock()
+				if seen[v] {
+					t.Errorf("Value %d popped twice", v)
+				}
+				seen[v] = true
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	consumers.Wait()
+	if q.Len() != 0 {
+		t.Errorf("Queue should be drained, %d elements left", q.Len())
+	}
+}
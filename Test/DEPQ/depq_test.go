@@ -0,0 +1,138 @@
+package main_test
+
+import (
+	"math/rand"
+	"slices"
+	"sync"
+	"testing"
+
+	"GoSTL/DEPQ"
+)
+
+func intLess(a, b int) bool { return a < b }
+
+func TestEmpty(t *testing.T) {
+	q := DEPQ.NewDEPQ(intLess)
+	if _, ok := q.PopMin(); ok {
+		t.Error("PopMin on an empty queue should fail")
+	}
+	if _, ok := q.PopMax(); ok {
+		t.Error("PopMax on an empty queue should fail")
+	}
+	if _, ok := q.PeekMin(); ok || q.Len() != 0 {
+		t.Error("PeekMin on an empty queue should fail")
+	}
+	if _, ok := q.PeekMax(); ok {
+		t.Error("PeekMax on an empty queue should fail")
+	}
+
+	q.Push(7)
+	if lo, _ := q.PeekMin(); lo != 7 {
+		t.Errorf("PeekMin expected 7, got %d", lo)
+	}
+	if hi, _ := q.PeekMax(); hi != 7 {
+		t.Errorf("A single element is both min and max, got %d", hi)
+	}
+	if v, ok := q.PopMax(); !ok || v != 7 || q.Len() != 0 {
+		t.Errorf("PopMax expected 7, got %d", v)
+	}
+}
+
+func TestPopBothEnds(t *testing.T) {
+	q := DEPQ.NewDEPQ(intLess)
+	for _, v := range rand.Perm(1000) {
+		q.Push(v)
+	}
+	if q.Len() != 1000 {
+		t.Fatalf("Expected length 1000, got %d", q.Len())
+	}
+	for lo, hi := 0, 999; lo <= hi; lo, hi = lo+1, hi-1 {
+		if v, _ := q.PeekMin(); v != lo {
+			t.Fatalf("PeekMin expected %d, got %d", lo, v)
+		}
+		if v, _ := q.PopMin(); v != lo {
+			t.Fatalf("PopMin expected %d, got %d", lo, v)
+		}
+		if v, _ := q.PeekMax(); v != hi {
+			t.Fatalf("PeekMax expected %d, got %d", hi, v)
+		}
+		if v, _ := q.PopMax(); v != hi {
+			t.Fatalf("PopMax expected %d, got %d", hi, v)
+		}
+	}
+	if q.Len() != 0 {
+		t.Errorf("Expected an empty queue, got length %d", q.Len())
+	}
+}
+
+func TestRandomOperations(t *testing.T) {
+	q := DEPQ.NewDEPQ(intLess)
+	var ref []int
+	r := rand.New(rand.NewSource(42))
+
+	for i := 0; i < 20000; i++ {
+		switch op := r.Intn(4); {
+		case op < 2:
+			v := r.Intn(100) // plenty of duplicates
+			q.Push(v)
+			ref = append(ref, v)
+			slices.Sort(ref)
+		case op == 2:
+			v, ok := q.PopMin()
+			if ok != (len(ref) > 0) || (ok && v != ref[0]) {
+				t.Fatalf("Step %d: PopMin returned (%d, %v), reference %v", i, v, ok, ref)
+			}
+			if ok {
+				ref = ref[1:]
+			}
+		default:
+			v, ok := q.PopMax()
+			if ok != (len(ref) > 0) || (ok && v != ref[len(ref)-1]) {
+				t.Fatalf("Step %d: PopMax returned (%d, %v), reference %v", i, v, ok, ref)
+			}
+			if ok {
+				ref = ref[:len(ref)-1]
+			}
+		}
+		if q.Len() != len(ref) {
+			t.Fatalf("Step %d: length %d, reference %d", i, q.Len(), len(ref))
+		}
+	}
+}
+
+func TestConcurrentPush(t *testing.T) {
+	q := DEPQ.NewDEPQ(intLess)
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 250; i++ {
+				q.Push(g*250 + i)
+			}
+		}(g)
+	}
+	wg.Wait()
+	if v, _ := q.PeekMin(); v != 0 {
+		t.Errorf("Expected min 0, got %d", v)
+	}
+	if v, _ := q.PeekMax(); v != 999 {
+		t.Errorf("Expected max 999, got %d", v)
+	}
+}
+
+func BenchmarkPushPopBothEnds(b *testing.B) {
+	q := DEPQ.NewDEPQ(intLess)
+	for i := 0; i < 1000; i++ {
+		q.Push(rand.Int())
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		q.Push(rand.Int())
+		if i%2 == 0 {
+			q.PopMin()
+		} else {
+			q.PopMax()
+		}
+	}
+}
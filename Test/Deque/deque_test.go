@@ -1,14 +1,25 @@
 package main
 
 import (
+	"cmp"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"math"
 	"math/rand"
 	"runtime"
+	"slices"
+	"strconv"
+	"strings"
 	"sync"
 	"testing"
 	"time"
 
 	"GoSTL/Deque"
+	iterator "GoSTL/Iterator"
+	"GoSTL/Tuple"
 )
 
 func TestNewDeque(t *testing.T) {
@@ -318,6 +329,18 @@ func TestRotate(t *testing.T) {
 			t.Errorf("After rotate(23), At(%d) expected %d, got %d", i, exp, val)
 		}
 	}
+
+	// Rotate a deque that wraps around the end of its backing array
+	w := Deque.NewDeque[int](8)
+	for i := 0; i < 6; i++ {
+		w.PushBack(i)
+	}
+	w.PopNFront(4)
+	w.AppendFromSlice([]int{6, 7, 8, 9})
+	w.Rotate(2)
+	if got := w.ToSlice(); !slices.Equal(got, []int{8, 9, 4, 5, 6, 7}) {
+		t.Errorf("Rotate on a wrapped deque expected [8 9 4 5 6 7], got %v", got)
+	}
 }
 
 func TestReverse(t *testing.T) {
@@ -534,40 +557,2908 @@ func TestFormat(t *testing.T) {
 	}
 }
 
-func BenchmarkPushPop(b *testing.B) {
+func TestTrySet(t *testing.T) {
 	q := Deque.NewDeque[int]()
-	b.ResetTimer()
+	for i := 0; i < 5; i++ {
+		q.PushBack(i)
+	}
+	eq := func(a, b int) bool { return a == b }
 
-	for i := 0; i < b.N; i++ {
+	if !q.TrySet(2, 2, 20, eq) {
+		t.Error("TrySet(2, 2, 20) should succeed")
+	}
+	if val, _ := q.At(2); val != 20 {
+		t.Errorf("Expected 20 at index 2, got %d", val)
+	}
+
+	// Stale expected value
+	if q.TrySet(2, 2, 30, eq) {
+		t.Error("TrySet with stale old value should fail")
+	}
+	if val, _ := q.At(2); val != 20 {
+		t.Errorf("Failed TrySet should not modify slot, got %d", val)
+	}
+
+	// Negative index
+	if !q.TrySet(-1, 4, 40, eq) {
+		t.Error("TrySet(-1, 4, 40) should succeed")
+	}
+	if val, _ := q.Back(); val != 40 {
+		t.Errorf("Expected back 40, got %d", val)
+	}
+
+	// Out of bounds
+	if q.TrySet(5, 0, 1, eq) || q.TrySet(-6, 0, 1, eq) {
+		t.Error("TrySet out of bounds should fail")
+	}
+}
+
+func TestPopIf(t *testing.T) {
+	q := Deque.NewDeque[int]()
+	isEven := func(v int) bool { return v%2 == 0 }
+
+	// Test empty
+	if _, ok := q.PopFrontIf(isEven); ok {
+		t.Error("PopFrontIf on empty deque should return false")
+	}
+	if _, ok := q.PopBackIf(isEven); ok {
+		t.Error("PopBackIf on empty deque should return false")
+	}
+
+	for i := 0; i < 5; i++ {
 		q.PushBack(i)
-		q.PopFront()
+	}
+
+	if val, ok := q.PopFrontIf(isEven); !ok || val != 0 {
+		t.Errorf("Expected (0, true), got (%d, %v)", val, ok)
+	}
+	if _, ok := q.PopFrontIf(isEven); ok {
+		t.Error("PopFrontIf should not pop odd front 1")
+	}
+	if val, ok := q.PopBackIf(isEven); !ok || val != 4 {
+		t.Errorf("Expected (4, true), got (%d, %v)", val, ok)
+	}
+	if _, ok := q.PopBackIf(isEven); ok {
+		t.Error("PopBackIf should not pop odd back 3")
+	}
+
+	expected := []int{1, 2, 3}
+	if q.Len() != len(expected) {
+		t.Fatalf("Expected length %d, got %d", len(expected), q.Len())
+	}
+	for i, exp := range expected {
+		if val, _ := q.At(i); val != exp {
+			t.Errorf("At(%d) expected %d, got %d", i, exp, val)
+		}
 	}
 }
 
-func BenchmarkConcurrentPushPop(b *testing.B) {
+func TestStats(t *testing.T) {
+	q := Deque.NewDeque[int](8)
+
+	for i := 0; i < 10; i++ {
+		q.PushBack(i)
+	}
+	for i := 0; i < 3; i++ {
+		q.PushFront(i)
+	}
+	q.PopFront()
+	q.PopBack()
+	q.PopBack()
+	q.PopFrontIf(func(int) bool { return true })
+
+	stats := q.Stats()
+	if stats.PushBackCount != 10 || stats.PushFrontCount != 3 {
+		t.Errorf("Expected 10 back and 3 front pushes, got %d and %d", stats.PushBackCount, stats.PushFrontCount)
+	}
+	if stats.PopFrontCount != 2 || stats.PopBackCount != 2 {
+		t.Errorf("Expected 2 front and 2 back pops, got %d and %d", stats.PopFrontCount, stats.PopBackCount)
+	}
+	if stats.ResizeCount != 1 {
+		t.Errorf("Expected 1 resize (8 -> 16), got %d", stats.ResizeCount)
+	}
+	if stats.CurrentLen != int64(q.Len()) || stats.CurrentCap != int64(q.Capacity()) {
+		t.Errorf("Expected len/cap %d/%d, got %d/%d", q.Len(), q.Capacity(), stats.CurrentLen, stats.CurrentCap)
+	}
+
+	// Failed pops are not counted
+	q.Clear()
+	q.PopFront()
+	q.PopBack()
+	if stats := q.Stats(); stats.PopFrontCount != 2 || stats.PopBackCount != 2 {
+		t.Errorf("Failed pops should not be counted, got %d and %d", stats.PopFrontCount, stats.PopBackCount)
+	}
+
+	// Clear counts the discarded elements as removed
+	if stats := q.Stats(); stats.RemoveCount != 9 {
+		t.Errorf("Clear should count its 9 elements as removed, got %d", stats.RemoveCount)
+	}
+
+	q.ResetStats()
+	stats = q.Stats()
+	if stats != (Deque.DequeStats{CurrentCap: stats.CurrentCap}) {
+		t.Errorf("Expected all counters zero after ResetStats, got %+v", stats)
+	}
+}
+
+func TestStatsCountElements(t *testing.T) {
+	check := func(name string, got Deque.DequeStats, want Deque.DequeStats) {
+		t.Helper()
+		got.ResizeCount, got.CurrentLen, got.CurrentCap = 0, 0, 0
+		if got != want {
+			t.Errorf("%s: expected %+v, got %+v", name, want, got)
+		}
+	}
+	even := func(v int) bool { return v%2 == 0 }
+
 	q := Deque.NewDeque[int]()
-	b.ResetTimer()
+	u := Deque.NewUnsafeDeque[int]()
+	q.AppendFromSlice([]int{0, 1, 2, 3})
+	u.AppendFromSlice([]int{0, 1, 2, 3})
+	q.Extend(Deque.FromSlice([]int{4, 5}))
+	u.Extend(Deque.NewUnsafeDequeWithData([]int{4, 5}))
+	q.Prepend(Deque.FromSlice([]int{-2, -1}))
+	u.Prepend(Deque.NewUnsafeDequeWithData([]int{-2, -1}))
+	want := Deque.DequeStats{PushBackCount: 6, PushFrontCount: 2}
+	check("bulk pushes", q.Stats(), want)
+	check("UnsafeDeque bulk pushes", u.Stats(), want)
+
+	q.PopNFront(2)
+	u.PopNFront(2)
+	q.PopFrontWhile(even) // 0
+	u.PopFrontWhile(even)
+	q.PopNBack(2)
+	u.PopNBack(2)
+	q.PopBackWhile(func(v int) bool { return v > 2 }) // 3
+	u.PopBackWhile(func(v int) bool { return v > 2 })
+	want.PopFrontCount, want.PopBackCount = 3, 3
+	check("bulk pops", q.Stats(), want)
+	check("UnsafeDeque bulk pops", u.Stats(), want)
+
+	// Positional methods count as inserts and removes, even at index 0 or Len()
+	q.Insert(0, 10)
+	u.Insert(0, 10)
+	q.InsertSlice(q.Len(), []int{11, 12, 13})
+	u.InsertSlice(u.Len(), []int{11, 12, 13})
+	q.Remove(0)
+	u.Remove(0)
+	q.RemoveRange(-2, q.Len())
+	u.RemoveRange(-2, u.Len())
+	q.FilterInPlace(even) // drops 1 and 11
+	u.FilterInPlace(even)
+	want.InsertCount, want.RemoveCount = 4, 5
+	check("positional", q.Stats(), want)
+	check("UnsafeDeque positional", u.Stats(), want)
+	if q.Len() != 1 || u.Len() != 1 {
+		t.Fatalf("Expected one element left, got %v and %v", q, u)
+	}
+
+	q.Reset()
+	u.Reset()
+	want.RemoveCount++
+	check("Reset", q.Stats(), want)
+	check("UnsafeDeque Reset", u.Stats(), want)
+}
 
-	b.RunParallel(func(pb *testing.PB) {
-		r := rand.New(rand.NewSource(time.Now().UnixNano()))
-		for pb.Next() {
-			if r.Intn(2) == 0 {
-				q.PushBack(1)
-			} else {
-				q.PopFront()
+func TestDebug(t *testing.T) {
+	q := Deque.NewDeque[int]()
+	expected := "front=0 back=0 len=0 cap=8\ndata=[<empty> <empty> <empty> <empty> <empty> <empty> <empty> <empty>]"
+	if s := q.Debug(); s != expected {
+		t.Errorf("Debug() expected %q, got %q", expected, s)
+	}
+
+	// Wrap around: front moves to slot 6, back wraps to slot 1
+	for i := 0; i < 8; i++ {
+		q.PushBack(i)
+	}
+	for i := 0; i < 6; i++ {
+		q.PopFront()
+	}
+	q.PushBack(8)
+	expected = "front=6 back=1 len=3 cap=8\ndata=[8 <empty> <empty> <empty> <empty> <empty> 6 7]"
+	if s := q.Debug(); s != expected {
+		t.Errorf("Debug() expected %q, got %q", expected, s)
+	}
+}
+
+func TestNewDequeWithData(t *testing.T) {
+	data := []int{1, 2, 3, 4, 5, 6, 7, 8, 9}
+	q := Deque.NewDequeWithData(data)
+	if q.Len() != len(data) {
+		t.Fatalf("Expected length %d, got %d", len(data), q.Len())
+	}
+	if q.Capacity() != 16 {
+		t.Errorf("Expected capacity rounded up to 16, got %d", q.Capacity())
+	}
+	for i, exp := range data {
+		if val, _ := q.At(i); val != exp {
+			t.Errorf("At(%d) expected %d, got %d", i, exp, val)
+		}
+	}
+
+	// The deque must not alias the input slice
+	data[0] = 100
+	if val, _ := q.Front(); val != 1 {
+		t.Errorf("Modifying input should not affect deque, got front %d", val)
+	}
+
+	// Explicit capacity large enough for the data
+	q = Deque.NewDequeWithData([]int{1, 2}, Deque.WithCapacity(5))
+	if q.Capacity() != 5 {
+		t.Errorf("Expected capacity 5, got %d", q.Capacity())
+	}
+
+	// Explicit capacity too small for the data is ignored
+	q = Deque.NewDequeWithData(data, Deque.WithCapacity(2))
+	if q.Capacity() != 16 {
+		t.Errorf("Expected capacity 16, got %d", q.Capacity())
+	}
+
+	// Exactly full buffer keeps working
+	q = Deque.NewDequeWithData([]int{1, 2, 3, 4}, Deque.WithCapacity(4))
+	q.PushBack(5)
+	q.PushFront(0)
+	for i := 0; i <= 5; i++ {
+		if val, _ := q.At(i); val != i {
+			t.Errorf("At(%d) expected %d, got %d", i, i, val)
+		}
+	}
+
+	// Empty data
+	q = Deque.NewDequeWithData[int](nil)
+	if !q.Empty() || q.Capacity() != 8 {
+		t.Errorf("Expected empty deque with capacity 8, got len %d cap %d", q.Len(), q.Capacity())
+	}
+}
+
+func TestGrowthFactor(t *testing.T) {
+	q := Deque.NewDequeWithData[int](nil, Deque.WithCapacity(10), Deque.WithGrowthFactor(1.5))
+	for i := 0; i < 11; i++ {
+		q.PushBack(i)
+	}
+	if q.Capacity() != 15 {
+		t.Errorf("Expected capacity 15 after growth, got %d", q.Capacity())
+	}
+
+	// Factors <= 1 fall back to doubling
+	q = Deque.NewDequeWithData[int](nil, Deque.WithCapacity(10), Deque.WithGrowthFactor(0.5))
+	for i := 0; i < 11; i++ {
+		q.PushBack(i)
+	}
+	if q.Capacity() != 20 {
+		t.Errorf("Expected capacity 20 after growth, got %d", q.Capacity())
+	}
+}
+
+func TestMaxCapacity(t *testing.T) {
+	q := Deque.NewDequeWithData[int](nil, Deque.WithCapacity(4), Deque.WithMaxCapacity(6))
+	for i := 0; i < 6; i++ {
+		q.PushBack(i)
+	}
+	if q.Capacity() != 6 {
+		t.Errorf("Capacity should be capped at 6, got %d", q.Capacity())
+	}
+
+	assertPanics := func(name string, fn func()) {
+		t.Helper()
+		defer func() {
+			if recover() == nil {
+				t.Errorf("%s on full bounded deque should panic", name)
 			}
+		}()
+		fn()
+	}
+	assertPanics("PushBack", func() { q.PushBack(6) })
+	assertPanics("PushFront", func() { q.PushFront(-1) })
+
+	// The deque stays usable after the panics
+	q.PopFront()
+	q.PushBack(6)
+	if q.Len() != 6 {
+		t.Errorf("Expected length 6, got %d", q.Len())
+	}
+
+	// Copies keep the limit
+	c := q.Copy()
+	assertPanics("PushBack on copy", func() { c.PushBack(7) })
+
+	// Data larger than the limit is rejected
+	assertPanics("NewDequeWithData", func() { Deque.NewDequeWithData([]int{1, 2, 3}, Deque.WithMaxCapacity(2)) })
+}
+
+func TestMarshalBinary(t *testing.T) {
+	// Wrapped ring buffer of ints
+	q := Deque.NewDeque[int]()
+	for i := 0; i < 8; i++ {
+		q.PushBack(i)
+	}
+	for i := 0; i < 5; i++ {
+		q.PopFront()
+	}
+	q.PushBack(8)
+	q.PushBack(-9)
+
+	b, err := q.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary failed: %v", err)
+	}
+	r := Deque.NewDeque[int]()
+	if err := r.UnmarshalBinary(b); err != nil {
+		t.Fatalf("UnmarshalBinary failed: %v", err)
+	}
+	expected := []int{5, 6, 7, 8, -9}
+	if r.Len() != len(expected) {
+		t.Fatalf("Expected length %d, got %d", len(expected), r.Len())
+	}
+	for i, exp := range expected {
+		if val, _ := r.At(i); val != exp {
+			t.Errorf("At(%d) expected %d, got %d", i, exp, val)
 		}
+	}
+	if b2, _ := r.MarshalBinary(); string(b2) != string(b) {
+		t.Error("Round trip should produce an identical encoding")
+	}
+
+	// Variable-length and composite elements
+	type record struct {
+		Name  string
+		Tags  []string
+		Raw   []byte
+		Score float64
+		Flags [2]bool
+	}
+	rq := Deque.NewDeque[record]()
+	rq.PushBack(record{Name: "a", Tags: []string{"x", ""}, Raw: []byte{1, 2}, Score: 1.5, Flags: [2]bool{true, false}})
+	rq.PushBack(record{Name: "", Tags: nil, Raw: []byte{}, Score: -2})
+	b, err = rq.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary failed: %v", err)
+	}
+	rr := Deque.NewDeque[record]()
+	if err := rr.UnmarshalBinary(b); err != nil {
+		t.Fatalf("UnmarshalBinary failed: %v", err)
+	}
+	first, _ := rr.At(0)
+	second, _ := rr.At(1)
+	if first.Name != "a" || len(first.Tags) != 2 || first.Tags[0] != "x" || first.Raw[1] != 2 ||
+		first.Score != 1.5 || !first.Flags[0] || first.Flags[1] {
+		t.Errorf("First record mismatch: %+v", first)
+	}
+	if second.Tags != nil || second.Raw == nil || len(second.Raw) != 0 || second.Score != -2 {
+		t.Errorf("Second record mismatch: %+v", second)
+	}
+
+	// Empty deque
+	b, err = Deque.NewDeque[string]().MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary of empty deque failed: %v", err)
+	}
+	sq := Deque.NewDeque[string]()
+	sq.PushBack("stale")
+	if err := sq.UnmarshalBinary(b); err != nil || !sq.Empty() {
+		t.Errorf("Expected empty deque, got len %d (err: %v)", sq.Len(), err)
+	}
+}
+
+func TestUnmarshalBinaryErrors(t *testing.T) {
+	q := Deque.NewDeque[int]()
+	q.PushBack(1)
+	q.PushBack(2)
+	b, _ := q.MarshalBinary()
+
+	// Element type mismatch
+	if err := Deque.NewDeque[string]().UnmarshalBinary(b); err == nil {
+		t.Error("UnmarshalBinary into a different element type should fail")
+	}
+
+	// Truncated payload leaves the target untouched
+	r := Deque.NewDeque[int]()
+	r.PushBack(42)
+	if err := r.UnmarshalBinary(b[:len(b)-1]); err == nil {
+		t.Error("UnmarshalBinary of truncated payload should fail")
+	}
+	if val, _ := r.Front(); r.Len() != 1 || val != 42 {
+		t.Error("Failed UnmarshalBinary should not modify the deque")
+	}
+
+	// Bad version
+	bad := append([]byte{}, b...)
+	bad[0] = 0xff
+	if err := r.UnmarshalBinary(bad); err == nil {
+		t.Error("UnmarshalBinary with unknown version should fail")
+	}
+
+	// Element types gob cannot encode
+	cq := Deque.NewDeque[chan int]()
+	cq.PushBack(make(chan int))
+	if _, err := cq.MarshalBinary(); err == nil {
+		t.Error("MarshalBinary of channel elements should fail")
+	}
+	pq := Deque.NewDeque[*int]()
+	pq.PushBack(nil)
+	if _, err := pq.MarshalBinary(); err == nil {
+		t.Error("MarshalBinary of a nil pointer element should fail")
+	}
+}
+
+func TestMarshalBinaryGob(t *testing.T) {
+	// Element types the compact format cannot represent are written with encoding/gob
+	type node struct {
+		Name   string
+		Attrs  map[string]int
+		Next   *int
+		hidden int
+	}
+	one := 1
+	q := Deque.NewDequeWithData([]node{
+		{Name: "a", Attrs: map[string]int{"x": 1}, Next: &one, hidden: 7},
+		{Name: "b"},
 	})
+	b, err := q.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary failed: %v", err)
+	}
+	r := Deque.NewDeque[node]()
+	if err := r.UnmarshalBinary(b); err != nil {
+		t.Fatalf("UnmarshalBinary failed: %v", err)
+	}
+	first, _ := r.At(0)
+	second, _ := r.At(1)
+	if r.Len() != 2 || first.Name != "a" || first.Attrs["x"] != 1 || *first.Next != 1 || first.hidden != 0 ||
+		second.Name != "b" || second.Next != nil {
+		t.Errorf("Round trip mismatch: %+v %+v", first, second)
+	}
+	if err := r.UnmarshalBinary(b[:len(b)-1]); err == nil || r.Len() != 2 {
+		t.Error("Truncated gob payload should fail and leave the deque unchanged")
+	}
+
+	// Interface elements keep their concrete types
+	anys := Deque.NewDequeWithData([]any{1, "two", 3.5})
+	b, err = anys.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary failed: %v", err)
+	}
+	back := Deque.NewUnsafeDeque[any]()
+	if err := back.UnmarshalBinary(b); err != nil {
+		t.Fatalf("UnmarshalBinary failed: %v", err)
+	}
+	if s := fmt.Sprintf("%#v", back.ToSlice()); s != `[]interface {}{1, "two", 3.5}` {
+		t.Errorf("Expected [1 two 3.5], got %s", s)
+	}
+
+	// A compact payload is not accepted for a gob element type and vice versa
+	ints, _ := Deque.NewDequeWithData([]int{1}).MarshalBinary()
+	if err := Deque.NewDeque[any]().UnmarshalBinary(ints); err == nil {
+		t.Error("UnmarshalBinary of an int payload into a Deque[any] should fail")
+	}
 }
 
-func BenchmarkRotate(b *testing.B) {
+func TestUnmarshalBinaryMaxCapacity(t *testing.T) {
+	src := Deque.NewDequeWithData([]int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, Deque.WithCapacity(32))
+	b, _ := src.MarshalBinary()
+
+	bounded := Deque.NewDequeWithData([]int{42}, Deque.WithMaxCapacity(4))
+	if err := bounded.UnmarshalBinary(b); err == nil {
+		t.Error("UnmarshalBinary beyond the maximum capacity should fail")
+	}
+	if s := fmt.Sprint(bounded); s != "[42]" || bounded.Capacity() != 4 {
+		t.Errorf("Failed UnmarshalBinary should not modify the deque, got %s with capacity %d", s, bounded.Capacity())
+	}
+
+	// A payload that fits is restored without exceeding the limit, and derived deques stay consistent
+	small := Deque.NewDequeWithData([]int{1, 2, 3}, Deque.WithCapacity(32))
+	b, _ = small.MarshalBinary()
+	if err := bounded.UnmarshalBinary(b); err != nil {
+		t.Fatalf("UnmarshalBinary failed: %v", err)
+	}
+	if bounded.Capacity() != 4 {
+		t.Errorf("Expected capacity capped at 4, got %d", bounded.Capacity())
+	}
+	bounded.Reset()
+	if bounded.Capacity() > 4 {
+		t.Errorf("Reset should not exceed the maximum capacity, got %d", bounded.Capacity())
+	}
+	if err := bounded.UnmarshalBinary(b); err != nil {
+		t.Fatalf("UnmarshalBinary failed: %v", err)
+	}
+	if s := fmt.Sprint(bounded.Filter(func(int) bool { return true })); s != "[1 2 3]" {
+		t.Errorf("Filter expected [1 2 3], got %s", s)
+	}
+}
+
+// TestUnmarshalBinaryCapacityHint checks that the initial capacity recorded in a payload cannot make a small
+// payload allocate a large backing array.
+func TestUnmarshalBinaryCapacityHint(t *testing.T) {
+	for _, hint := range []uint64{1 << 27, math.MaxInt32} {
+		b, _ := Deque.NewDeque[int64]().MarshalBinary()
+		binary.LittleEndian.PutUint64(b[9:], hint)
+
+		q := Deque.NewDeque[int64]()
+		if err := q.UnmarshalBinary(b); err != nil {
+			t.Fatalf("UnmarshalBinary failed: %v", err)
+		}
+		if q.Capacity() > 8 {
+			t.Errorf("Hint %d: expected capacity at most 8, got %d", hint, q.Capacity())
+		}
+		q.Clear()
+		if q.Capacity() > 8 {
+			t.Errorf("Hint %d: Clear should not grow to the hint, got capacity %d", hint, q.Capacity())
+		}
+
+		u := Deque.NewUnsafeDeque[int64]()
+		if err := u.UnmarshalBinary(b); err != nil {
+			t.Fatalf("UnmarshalBinary failed: %v", err)
+		}
+		if u.Capacity() > 8 {
+			t.Errorf("Hint %d: expected UnsafeDeque capacity at most 8, got %d", hint, u.Capacity())
+		}
+	}
+}
+
+func TestSortOrdered(t *testing.T) {
+	// Build a wrapped buffer so sorting has to cross the ring boundary
 	q := Deque.NewDeque[int]()
-	for i := 0; i < 1000; i++ {
+	for _, v := range []int{9, 9, 9, 9, 9, 5, 3, 8} {
+		q.PushBack(v)
+	}
+	for i := 0; i < 5; i++ {
+		q.PopFront()
+	}
+	for _, v := range []int{1, 7, -2, 3} {
+		q.PushBack(v)
+	}
+
+	Deque.SortOrdered(q)
+	expected := []int{-2, 1, 3, 3, 5, 7, 8}
+	if q.Len() != len(expected) {
+		t.Fatalf("Expected length %d, got %d", len(expected), q.Len())
+	}
+	for i, exp := range expected {
+		if val, _ := q.At(i); val != exp {
+			t.Errorf("At(%d) expected %d, got %d", i, exp, val)
+		}
+	}
+
+	// Strings and empty deque
+	sq := Deque.NewDequeWithData([]string{"pear", "apple", "fig"})
+	Deque.SortOrdered(sq)
+	if s := fmt.Sprint(sq); s != "[apple fig pear]" {
+		t.Errorf("Expected [apple fig pear], got %s", s)
+	}
+	Deque.SortOrdered(Deque.NewDeque[float64]())
+}
+
+func TestBinarySearchOrdered(t *testing.T) {
+	q := Deque.NewDeque[int]()
+	for i := 0; i < 6; i++ {
+		q.PushBack(0)
+	}
+	for i := 0; i < 6; i++ {
+		q.PopFront()
+	}
+	// Wrapped: 10 20 30 40 50 with front at slot 6
+	for i := 1; i <= 5; i++ {
+		q.PushBack(i * 10)
+	}
+
+	tests := []struct {
+		val   int
+		index int
+		found bool
+	}{
+		{10, 0, true}, {30, 2, true}, {50, 4, true},
+		{5, 0, false}, {35, 3, false}, {60, 5, false},
+	}
+	for _, tt := range tests {
+		index, found := Deque.BinarySearchOrdered(q, tt.val)
+		if index != tt.index || found != tt.found {
+			t.Errorf("BinarySearchOrdered(%d) expected (%d, %v), got (%d, %v)", tt.val, tt.index, tt.found, index, found)
+		}
+	}
+
+	if index, found := Deque.BinarySearchOrdered(Deque.NewDeque[int](), 1); index != 0 || found {
+		t.Errorf("Search in empty deque expected (0, false), got (%d, %v)", index, found)
+	}
+}
+
+func TestDrain(t *testing.T) {
+	q := Deque.NewDeque[int]()
+	for i := 0; i < 10; i++ {
 		q.PushBack(i)
 	}
-	b.ResetTimer()
 
-	for i := 0; i < b.N; i++ {
-		q.Rotate(1)
+	// Early break leaves the rest in place
+	next := 0
+	for v := range q.Drain() {
+		if v != next {
+			t.Errorf("Expected %d, got %d", next, v)
+		}
+		next++
+		if v == 3 {
+			break
+		}
+	}
+	if q.Len() != 6 {
+		t.Fatalf("Expected 6 remaining elements, got %d", q.Len())
+	}
+	if val, _ := q.Front(); val != 4 {
+		t.Errorf("Expected front 4 after break, got %d", val)
+	}
+
+	// Elements pushed during iteration are drained too
+	count := 0
+	for v := range q.Drain() {
+		if v == 9 {
+			q.PushBack(100)
+		}
+		count++
+	}
+	if count != 7 || !q.Empty() {
+		t.Errorf("Expected 7 drained elements and empty deque, got %d and len %d", count, q.Len())
+	}
+
+	// Draining an empty deque yields nothing
+	for range q.Drain() {
+		t.Error("Drain on empty deque should yield nothing")
+	}
+}
+
+// newWrappedDeque returns a deque holding vals whose ring buffer wraps around its end.
+func newWrappedDeque(vals []int) *Deque.Deque[int] {
+	q := Deque.NewDeque[int](len(vals))
+	for range vals {
+		q.PushBack(0)
+	}
+	for range len(vals) / 2 {
+		q.PopFront()
+	}
+	for range len(vals) / 2 {
+		q.PushBack(0)
+	}
+	for i, v := range vals {
+		q.Set(i, v)
+	}
+	return q
+}
+
+func TestIsContiguous(t *testing.T) {
+	q := Deque.NewDeque[int]()
+	if !q.IsContiguous() {
+		t.Error("Empty deque should be contiguous")
+	}
+	for i := 0; i < 8; i++ {
+		q.PushBack(i)
+	}
+	if !q.IsContiguous() {
+		t.Error("Full unwrapped deque should be contiguous")
+	}
+	q.PopFront()
+	q.PushBack(8)
+	if q.IsContiguous() {
+		t.Error("Wrapped deque should not be contiguous")
+	}
+	if newWrappedDeque([]int{1, 2, 3, 4}).IsContiguous() {
+		t.Error("newWrappedDeque should produce a wrapped deque")
+	}
+}
+
+func TestContiguousFastPath(t *testing.T) {
+	vals := []int{5, -1, 9, 3, 3, 7, 0, 12, 4, 8}
+	contiguous := Deque.NewDequeWithData(vals)
+	wrapped := newWrappedDeque(vals)
+	if !contiguous.IsContiguous() || wrapped.IsContiguous() {
+		t.Fatal("Test setup should produce one contiguous and one wrapped deque")
+	}
+
+	Deque.SortOrdered(contiguous)
+	Deque.SortOrdered(wrapped)
+	if a, b := fmt.Sprint(contiguous), fmt.Sprint(wrapped); a != b {
+		t.Errorf("Sort results differ: contiguous %s, wrapped %s", a, b)
+	}
+
+	for _, v := range []int{-5, -1, 3, 6, 12, 20} {
+		i1, f1 := Deque.BinarySearchOrdered(contiguous, v)
+		i2, f2 := Deque.BinarySearchOrdered(wrapped, v)
+		if i1 != i2 || f1 != f2 {
+			t.Errorf("BinarySearchOrdered(%d) differs: contiguous (%d, %v), wrapped (%d, %v)", v, i1, f1, i2, f2)
+		}
+	}
+}
+
+func TestNilDeque(t *testing.T) {
+	var q *Deque.Deque[int]
+	ops := map[string]func(){
+		"PushBack":    func() { q.PushBack(1) },
+		"PushFront":   func() { q.PushFront(1) },
+		"PopFront":    func() { q.PopFront() },
+		"PopBack":     func() { q.PopBack() },
+		"Len":         func() { q.Len() },
+		"At":          func() { q.At(0) },
+		"Clear":       func() { q.Clear() },
+		"SortOrdered": func() { Deque.SortOrdered(q) },
+	}
+	for name, op := range ops {
+		func() {
+			defer func() {
+				if r := recover(); r != "Deque: operation on nil deque" {
+					t.Errorf("%s on nil deque: expected descriptive panic, got %v", name, r)
+				}
+			}()
+			op()
+		}()
+	}
+}
+
+func TestMaxLen(t *testing.T) {
+	q := Deque.NewDeque[int]()
+	if q.MaxLen() != 0 {
+		t.Errorf("New deque MaxLen expected 0, got %d", q.MaxLen())
+	}
+
+	for i := 0; i < 20; i++ {
+		q.PushBack(i)
+	}
+	for i := 0; i < 15; i++ {
+		q.PopFront()
+	}
+	q.PushFront(-1)
+	if q.MaxLen() != 20 {
+		t.Errorf("Expected MaxLen 20, got %d", q.MaxLen())
+	}
+
+	q.ResetMaxLen()
+	if q.MaxLen() != 6 {
+		t.Errorf("Expected MaxLen 6 after ResetMaxLen, got %d", q.MaxLen())
+	}
+	q.PushFront(-2)
+	if q.MaxLen() != 7 {
+		t.Errorf("Expected MaxLen 7, got %d", q.MaxLen())
+	}
+
+	// Shrinking an empty deque does not clear the history
+	q.Clear()
+	q.ShrinkToFit()
+	if q.MaxLen() != 7 {
+		t.Errorf("Expected MaxLen 7 after ShrinkToFit, got %d", q.MaxLen())
+	}
+
+	q.Reset()
+	if q.MaxLen() != 0 {
+		t.Errorf("Expected MaxLen 0 after Reset, got %d", q.MaxLen())
+	}
+
+	if d := Deque.NewDequeWithData([]int{1, 2, 3}); d.MaxLen() != 3 {
+		t.Errorf("Expected MaxLen 3 for NewDequeWithData, got %d", d.MaxLen())
+	}
+}
+
+func TestMaxLenConcurrent(t *testing.T) {
+	q := Deque.NewDeque[int]()
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 250; i++ {
+				q.PushFront(i)
+			}
+		}()
+	}
+	wg.Wait()
+	if q.MaxLen() != 1000 {
+		t.Errorf("Expected MaxLen 1000, got %d", q.MaxLen())
+	}
+}
+
+func TestPopN(t *testing.T) {
+	q := newWrappedDeque([]int{0, 1, 2, 3, 4, 5, 6, 7})
+
+	got := q.PopNBack(3)
+	if fmt.Sprint(got) != "[7 6 5]" {
+		t.Errorf("PopNBack(3) expected [7 6 5], got %v", got)
+	}
+	got = q.PopNFront(2)
+	if fmt.Sprint(got) != "[0 1]" {
+		t.Errorf("PopNFront(2) expected [0 1], got %v", got)
+	}
+	if s := fmt.Sprint(q); s != "[2 3 4]" {
+		t.Errorf("Expected remaining [2 3 4], got %s", s)
+	}
+
+	// The deque keeps working after batch pops
+	q.PushBack(5)
+	q.PushFront(1)
+	if s := fmt.Sprint(q); s != "[1 2 3 4 5]" {
+		t.Errorf("Expected [1 2 3 4 5], got %s", s)
+	}
+
+	// Asking for more than available drains the deque
+	got = q.PopNBack(10)
+	if fmt.Sprint(got) != "[5 4 3 2 1]" || !q.Empty() {
+		t.Errorf("PopNBack(10) expected [5 4 3 2 1] and empty deque, got %v (len %d)", got, q.Len())
+	}
+
+	// Non-positive counts and empty deques return an empty, non-nil slice
+	for _, got := range [][]int{q.PopNFront(3), q.PopNBack(0), q.PopNFront(-1)} {
+		if got == nil || len(got) != 0 {
+			t.Errorf("Expected empty non-nil slice, got %#v", got)
+		}
+	}
+}
+
+func TestZipUnzip(t *testing.T) {
+	keys := newWrappedDeque([]int{1, 2, 3, 4, 5, 6, 7, 8})
+	vals := Deque.NewDequeWithData([]string{"a", "b", "c"})
+
+	zipped := Deque.Zip(keys, vals)
+	if zipped.Len() != 3 {
+		t.Fatalf("Zip should stop at the shorter input, got length %d", zipped.Len())
+	}
+	for i := 0; i < 3; i++ {
+		p, _ := zipped.At(i)
+		if p.First != i+1 || p.Second != string(rune('a'+i)) {
+			t.Errorf("Pair %d expected (%d, %c), got %v", i, i+1, 'a'+i, p)
+		}
+	}
+	if keys.Len() != 8 || vals.Len() != 3 {
+		t.Error("Zip should not modify its inputs")
+	}
+
+	// The result is an independent, usable deque
+	zipped.PushBack(Tuple.NewPair(4, "d"))
+	ks, vs := Deque.Unzip(zipped)
+	if s := fmt.Sprint(ks); s != "[1 2 3 4]" {
+		t.Errorf("Unzip keys expected [1 2 3 4], got %s", s)
+	}
+	if s := fmt.Sprint(vs); s != "[a b c d]" {
+		t.Errorf("Unzip values expected [a b c d], got %s", s)
+	}
+	ks.PushBack(5)
+	if zipped.Len() != 4 {
+		t.Error("Unzip results should be independent of the input")
+	}
+
+	// Zipping a deque with itself must not deadlock
+	self := Deque.Zip(vals, vals)
+	if p, _ := self.At(2); self.Len() != 3 || p.First != "c" || p.Second != "c" {
+		t.Errorf("Self-zip unexpected result: %v", self)
+	}
+
+	// Empty inputs
+	empty := Deque.Zip(Deque.NewDeque[int](), vals)
+	if !empty.Empty() {
+		t.Error("Zip with an empty input should be empty")
+	}
+	empty.PushBack(Tuple.NewPair(1, "x"))
+	if empty.Len() != 1 {
+		t.Error("Empty zip result should accept pushes")
+	}
+}
+
+func TestPeekN(t *testing.T) {
+	d := newWrappedDeque([]int{1, 2, 3, 4, 5, 6, 7, 8})
+
+	if s := fmt.Sprint(d.PeekFrontN(3)); s != "[1 2 3]" {
+		t.Errorf("PeekFrontN(3) expected [1 2 3], got %s", s)
+	}
+	if s := fmt.Sprint(d.PeekBackN(3)); s != "[8 7 6]" {
+		t.Errorf("PeekBackN(3) expected [8 7 6], got %s", s)
+	}
+	if got := d.PeekFrontN(20); len(got) != 8 || got[7] != 8 {
+		t.Errorf("PeekFrontN beyond length should return all elements, got %v", got)
+	}
+	if got := d.PeekBackN(-1); got == nil || len(got) != 0 {
+		t.Errorf("PeekBackN(-1) should return an empty non-nil slice, got %#v", got)
+	}
+	if d.Len() != 8 {
+		t.Errorf("Peeks should not remove elements, length is %d", d.Len())
+	}
+
+	// Results are copies
+	got := d.PeekFrontN(1)
+	got[0] = 100
+	if v, _ := d.Front(); v != 1 {
+		t.Errorf("Modifying a peek result changed the deque front to %d", v)
+	}
+	if got := Deque.NewDeque[int]().PeekFrontN(2); len(got) != 0 {
+		t.Errorf("PeekFrontN on an empty deque should be empty, got %v", got)
+	}
+}
+
+func TestFrontBackConcurrentSet(t *testing.T) {
+	d := Deque.NewDeque[[4]int]()
+	d.PushBack([4]int{})
+	d.PushBack([4]int{})
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 1; i <= 1000; i++ {
+			d.Set(0, [4]int{i, i, i, i})
+			d.Set(-1, [4]int{i, i, i, i})
+		}
+	}()
+	// Each read must observe a fully written element, never a torn one
+	for i := 0; i < 1000; i++ {
+		for _, v := range [][4]int{must(d.Front()), must(d.Back())} {
+			if v[0] != v[1] || v[1] != v[2] || v[2] != v[3] {
+				t.Fatalf("Observed a torn element %v", v)
+			}
+		}
+	}
+	wg.Wait()
+}
+
+func must[T any](v T, ok bool) T {
+	if !ok {
+		panic("unexpected missing element")
+	}
+	return v
+}
+
+func TestSortedInsertRemove(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+	compare := func(a, b int) int { return a - b }
+
+	d := Deque.NewDeque[int]()
+	for _, v := range []int{50, 10, 40, 20, 30, 60, 0, 70, 35, 5} {
+		d.SortedInsert(v, less)
+	}
+	if s := fmt.Sprint(d); s != "[0 5 10 20 30 35 40 50 60 70]" {
+		t.Fatalf("Expected sorted contents, got %s", s)
+	}
+	if i := d.SortedInsert(30, less); i != 5 {
+		t.Errorf("Equal elements should be inserted after existing ones, got index %d", i)
+	}
+	if i := d.SortedInsert(-1, less); i != 0 {
+		t.Errorf("Expected insertion index 0, got %d", i)
+	}
+	if i := d.SortedInsert(99, less); i != d.Len()-1 {
+		t.Errorf("Expected insertion at the back, got %d", i)
+	}
+
+	// Removal from both halves, missing values and duplicates
+	for _, v := range []int{5, 60, 30, -1, 99} {
+		if !d.SortedRemove(v, compare) {
+			t.Errorf("SortedRemove(%d) should succeed", v)
+		}
+	}
+	for _, v := range []int{5, 15, 1000, -50} {
+		if d.SortedRemove(v, compare) {
+			t.Errorf("SortedRemove(%d) should fail", v)
+		}
+	}
+	if s := fmt.Sprint(d); s != "[0 10 20 30 35 40 50 70]" {
+		t.Errorf("Unexpected contents after removals: %s", s)
+	}
+
+	// Works on a wrapped buffer and keeps it consistent for other operations
+	w := newWrappedDeque([]int{10, 20, 30, 40, 50, 60, 70, 80})
+	w.PopBack()
+	w.SortedInsert(15, less)
+	w.SortedInsert(75, less)
+	w.SortedRemove(60, compare)
+	w.PushBack(90)
+	w.PushFront(1)
+	if s := fmt.Sprint(w); s != "[1 10 15 20 30 40 50 70 75 90]" {
+		t.Errorf("Unexpected wrapped contents: %s", s)
+	}
+}
+
+func TestUnique(t *testing.T) {
+	d := newWrappedDeque([]int{3, 1, 3, 2, 1, 4, 2, 3})
+
+	byEq := d.Unique(func(a, b int) bool { return a == b })
+	byMap := Deque.UniqueOrdered(d)
+	for _, u := range []*Deque.Deque[int]{byEq, byMap} {
+		if s := fmt.Sprint(u); s != "[3 1 2 4]" {
+			t.Errorf("Expected [3 1 2 4], got %s", s)
+		}
+	}
+	if d.Len() != 8 {
+		t.Error("Unique should not modify the receiver")
+	}
+	byMap.PushBack(3)
+	if byMap.Len() != 5 || byEq.Len() != 4 {
+		t.Error("Unique results should be independent deques")
+	}
+
+	// Non-comparable element type with a custom equality
+	s := Deque.NewDequeWithData([][]int{{1}, {2, 3}, {1}, {}, {2, 3}})
+	us := s.Unique(slices.Equal[[]int])
+	if got := fmt.Sprint(us); got != "[[1] [2 3] []]" {
+		t.Errorf("Unique expected [[1] [2 3] []], got %s", got)
+	}
+	if !Deque.UniqueOrdered(Deque.NewDeque[int]()).Empty() {
+		t.Error("Unique of an empty deque should be empty")
+	}
+
+	// The result is sized for the kept elements and inherits the source's limits
+	big := Deque.NewDequeWithData[int](nil, Deque.WithMaxCapacity(100000))
+	for i := 0; i < 100000; i++ {
+		big.PushBack(i % 3)
+	}
+	for _, u := range []*Deque.Deque[int]{big.Unique(func(a, b int) bool { return a == b }), Deque.UniqueOrdered(big)} {
+		if u.Len() != 3 || u.Capacity() > 8 {
+			t.Errorf("Expected 3 elements in a small backing array, got %d with capacity %d", u.Len(), u.Capacity())
+		}
+	}
+	bounded := Deque.UniqueOrdered(Deque.NewDequeWithData([]int{1, 1, 2}, Deque.WithMaxCapacity(3)))
+	bounded.PushBack(3)
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("Unique result should inherit the max capacity of its source")
+			}
+		}()
+		bounded.PushBack(4)
+	}()
+}
+
+func TestFlatten(t *testing.T) {
+	batches := Deque.NewDeque[*Deque.Deque[int]]()
+	batches.PushBack(Deque.NewDequeWithData([]int{1, 2}))
+	batches.PushBack(Deque.NewDeque[int]())
+	batches.PushBack(nil)
+	batches.PushBack(newWrappedDeque([]int{3, 4, 5, 6, 7, 8, 9, 10}))
+
+	flat := Deque.Flatten(batches)
+	if s := fmt.Sprint(flat); s != "[1 2 3 4 5 6 7 8 9 10]" {
+		t.Errorf("Flatten expected [1 2 3 4 5 6 7 8 9 10], got %s", s)
+	}
+	if batches.Len() != 4 {
+		t.Error("Flatten should not modify the outer deque")
+	}
+	first, _ := batches.Front()
+	first.PushBack(100)
+	if flat.Len() != 10 {
+		t.Error("Flatten result should be independent of the inner deques")
+	}
+	if !Deque.Flatten(Deque.NewDeque[*Deque.Deque[int]]()).Empty() {
+		t.Error("Flatten of an empty deque should be empty")
+	}
+}
+
+func TestFlatMap(t *testing.T) {
+	d := Deque.NewDequeWithData([]int{1, 0, 3})
+	repeat := func(n int) *Deque.Deque[string] {
+		if n == 0 {
+			return nil
+		}
+		r := Deque.NewDeque[string]()
+		for i := 0; i < n; i++ {
+			r.PushBack(fmt.Sprint(n))
+		}
+		return r
+	}
+	out := Deque.FlatMap(d, repeat)
+	if s := fmt.Sprint(out); s != "[1 3 3 3]" {
+		t.Errorf("FlatMap expected [1 3 3 3], got %s", s)
+	}
+	out.PushFront("x")
+	if out.Len() != 5 {
+		t.Error("FlatMap result should accept pushes")
+	}
+}
+
+func TestPushOrDiscard(t *testing.T) {
+	d := Deque.NewDequeWithData([]int{2}, Deque.WithCapacity(2), Deque.WithMaxCapacity(3))
+	if !d.PushBackOrDiscard(3) || !d.PushFrontOrDiscard(1) {
+		t.Fatal("Pushes below the max capacity should succeed")
+	}
+	if d.PushBackOrDiscard(4) || d.PushFrontOrDiscard(0) {
+		t.Error("Pushes at the max capacity should be discarded")
+	}
+	if s := fmt.Sprint(d); s != "[1 2 3]" || d.Capacity() != 3 {
+		t.Errorf("Expected [1 2 3] with capacity 3, got %s with capacity %d", s, d.Capacity())
+	}
+	d.PopFront()
+	if !d.PushBackOrDiscard(4) {
+		t.Error("Push should succeed again after a pop")
+	}
+
+	u := Deque.NewDeque[int]()
+	for i := 0; i < 100; i++ {
+		if !u.PushBackOrDiscard(i) || !u.PushFrontOrDiscard(-i) {
+			t.Fatal("Unbounded deques should never discard")
+		}
+	}
+	if u.Len() != 200 {
+		t.Errorf("Expected length 200, got %d", u.Len())
+	}
+}
+
+func TestPopWhile(t *testing.T) {
+	d := newWrappedDeque([]int{1, 2, 3, 10, 11, 4, 5, 6})
+	small := func(v int) bool { return v < 10 }
+
+	if got := fmt.Sprint(d.PopFrontWhile(small)); got != "[1 2 3]" {
+		t.Errorf("PopFrontWhile expected [1 2 3], got %s", got)
+	}
+	if got := fmt.Sprint(d.PopBackWhile(small)); got != "[6 5 4]" {
+		t.Errorf("PopBackWhile expected [6 5 4], got %s", got)
+	}
+	if got := d.PopFrontWhile(small); got == nil || len(got) != 0 {
+		t.Errorf("PopFrontWhile with no match should return an empty non-nil slice, got %#v", got)
+	}
+	if got := d.PopBackWhile(small); got == nil || len(got) != 0 {
+		t.Errorf("PopBackWhile with no match should return an empty non-nil slice, got %#v", got)
+	}
+	if s := fmt.Sprint(d); s != "[10 11]" {
+		t.Errorf("Expected remaining [10 11], got %s", s)
+	}
+
+	all := func(int) bool { return true }
+	if got := d.PopBackWhile(all); len(got) != 2 || !d.Empty() {
+		t.Errorf("PopBackWhile(all) should empty the deque, got %v", got)
+	}
+	if got := d.PopFrontWhile(all); got == nil || len(got) != 0 {
+		t.Error("PopFrontWhile on an empty deque should return an empty non-nil slice")
+	}
+	d.PushBack(7)
+	d.PushFront(8)
+	if s := fmt.Sprint(d); s != "[8 7]" {
+		t.Errorf("Deque should stay usable after PopWhile, got %s", s)
+	}
+}
+
+func TestGetAndSet(t *testing.T) {
+	d := newWrappedDeque([]int{1, 2, 3, 4, 5, 6, 7, 8})
+	if old, ok := d.GetAndSet(0, 10); !ok || old != 1 {
+		t.Errorf("GetAndSet(0) expected (1, true), got (%d, %v)", old, ok)
+	}
+	if old, ok := d.GetAndSet(-1, 80); !ok || old != 8 {
+		t.Errorf("GetAndSet(-1) expected (8, true), got (%d, %v)", old, ok)
+	}
+	if _, ok := d.GetAndSet(8, 0); ok {
+		t.Error("GetAndSet out of range should fail")
+	}
+	if s := fmt.Sprint(d); s != "[10 2 3 4 5 6 7 80]" {
+		t.Errorf("Expected [10 2 3 4 5 6 7 80], got %s", s)
+	}
+
+	// Concurrent exchanges hand every value out exactly once
+	c := Deque.NewDequeWithData([]int{0})
+	var wg sync.WaitGroup
+	results := make([][]int, 4)
+	for g := range results {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 1; i <= 1000; i++ {
+				old, _ := c.GetAndSet(0, g*1000+i)
+				results[g] = append(results[g], old)
+			}
+		}(g)
+	}
+	wg.Wait()
+	seen := map[int]bool{}
+	last, _ := c.Front()
+	seen[last] = true
+	for _, r := range results {
+		for _, v := range r {
+			if seen[v] {
+				t.Fatalf("Value %d was returned twice", v)
+			}
+			seen[v] = true
+		}
+	}
+	if len(seen) != 4001 {
+		t.Errorf("Expected 4001 distinct values, got %d", len(seen))
+	}
+}
+
+func TestCOWSnapshot(t *testing.T) {
+	d := newWrappedDeque([]int{1, 2, 3, 4, 5, 6, 7, 8})
+	snap := d.COWSnapshot()
+	if s := fmt.Sprint(snap); s != "[1 2 3 4 5 6 7 8]" || snap.Capacity() != d.Capacity() {
+		t.Fatalf("Snapshot expected [1 2 3 4 5 6 7 8], got %s", s)
+	}
+
+	// Writes to the original do not leak into the snapshot, whichever operation comes first
+	writes := map[string]func(*Deque.Deque[int]){
+		"Set":          func(q *Deque.Deque[int]) { q.Set(0, 100) },
+		"PopFront":     func(q *Deque.Deque[int]) { q.PopFront() },
+		"PopBack":      func(q *Deque.Deque[int]) { q.PopBack() },
+		"PushBack":     func(q *Deque.Deque[int]) { q.PopBack(); q.PushBack(100) },
+		"PushFront":    func(q *Deque.Deque[int]) { q.PushFront(100) },
+		"Reverse":      func(q *Deque.Deque[int]) { q.Reverse() },
+		"Rotate":       func(q *Deque.Deque[int]) { q.Rotate(3) },
+		"Swap":         func(q *Deque.Deque[int]) { q.Swap(0, 7) },
+		"Clear":        func(q *Deque.Deque[int]) { q.Clear() },
+		"PopNFront":    func(q *Deque.Deque[int]) { q.PopNFront(3) },
+		"SortedInsert": func(q *Deque.Deque[int]) { q.SortedInsert(0, func(a, b int) bool { return a < b }) },
+		"SortOrdered":  func(q *Deque.Deque[int]) { q.Set(0, 9); Deque.SortOrdered(q) },
+	}
+	for name, write := range writes {
+		orig := newWrappedDeque([]int{1, 2, 3, 4, 5, 6, 7, 8})
+		frozen := orig.COWSnapshot()
+		write(orig)
+		if s := fmt.Sprint(frozen); s != "[1 2 3 4 5 6 7 8]" {
+			t.Errorf("%s on the original changed the snapshot to %s", name, s)
+		}
+
+		// And the other way round
+		orig = newWrappedDeque([]int{1, 2, 3, 4, 5, 6, 7, 8})
+		frozen = orig.COWSnapshot()
+		write(frozen)
+		if s := fmt.Sprint(orig); s != "[1 2 3 4 5 6 7 8]" {
+			t.Errorf("%s on the snapshot changed the original to %s", name, s)
+		}
+	}
+
+	// Both sides stay fully usable after unsharing, including growth from a full buffer
+	d.PushBack(9)
+	snap.PushFront(0)
+	if s := fmt.Sprint(d); s != "[1 2 3 4 5 6 7 8 9]" {
+		t.Errorf("Original expected [1 2 3 4 5 6 7 8 9], got %s", s)
+	}
+	if s := fmt.Sprint(snap); s != "[0 1 2 3 4 5 6 7 8]" {
+		t.Errorf("Snapshot expected [0 1 2 3 4 5 6 7 8], got %s", s)
+	}
+
+	// Snapshots of snapshots
+	a := Deque.NewDequeWithData([]int{1, 2})
+	b := a.COWSnapshot()
+	c := b.COWSnapshot()
+	b.PushBack(3)
+	c.PopFront()
+	if fmt.Sprint(a, b, c) != "[1 2] [1 2 3] [2]" {
+		t.Errorf("Unexpected chained snapshot contents: %v %v %v", a, b, c)
+	}
+}
+
+func TestMoveToFrontBack(t *testing.T) {
+	d := newWrappedDeque([]int{0, 1, 2, 3, 4, 5, 6, 7})
+	steps := []struct {
+		move func() bool
+		want string
+	}{
+		{func() bool { return d.MoveToFront(6) }, "[6 0 1 2 3 4 5 7]"},
+		{func() bool { return d.MoveToFront(-1) }, "[7 6 0 1 2 3 4 5]"},
+		{func() bool { return d.MoveToBack(1) }, "[7 0 1 2 3 4 5 6]"},
+		{func() bool { return d.MoveToBack(0) }, "[0 1 2 3 4 5 6 7]"},
+		{func() bool { return d.MoveToFront(0) }, "[0 1 2 3 4 5 6 7]"},
+		{func() bool { return d.MoveToBack(-1) }, "[0 1 2 3 4 5 6 7]"},
+	}
+	for i, step := range steps {
+		if !step.move() {
+			t.Fatalf("Step %d: move should succeed", i)
+		}
+		if s := fmt.Sprint(d); s != step.want {
+			t.Fatalf("Step %d: expected %s, got %s", i, step.want, s)
+		}
+	}
+	if d.MoveToFront(8) || d.MoveToBack(-9) || Deque.NewDeque[int]().MoveToFront(0) {
+		t.Error("Out-of-range moves should fail")
+	}
+	if d.Capacity() != 8 {
+		t.Errorf("Moves should not grow a full deque, capacity is %d", d.Capacity())
+	}
+}
+
+func TestIteratorForward(t *testing.T) {
+	d := newWrappedDeque([]int{1, 2, 3, 4, 5, 6, 7, 8})
+	var got []int
+	for it := d.Begin(); it.Valid(); it.Next() {
+		if it.Index() != len(got) {
+			t.Fatalf("Index expected %d, got %d", len(got), it.Index())
+		}
+		got = append(got, it.Value())
+	}
+	if fmt.Sprint(got) != "[1 2 3 4 5 6 7 8]" {
+		t.Errorf("Forward iteration expected [1 2 3 4 5 6 7 8], got %v", got)
+	}
+
+	// Walking backwards from End
+	got = got[:0]
+	it := d.End()
+	if it.Valid() || it.Index() != 8 {
+		t.Error("End should be past the back element")
+	}
+	for v, ok := it.Prev(); ok; v, ok = it.Prev() {
+		got = append(got, v)
+	}
+	if fmt.Sprint(got) != "[8 7 6 5 4 3 2 1]" {
+		t.Errorf("Backward iteration from End expected [8 7 6 5 4 3 2 1], got %v", got)
+	}
+	if it.Index() != -1 || it.Value() != 0 {
+		t.Errorf("Exhausted iterator should sit before the front, got index %d value %d", it.Index(), it.Value())
+	}
+	// Exhaustion is sticky at the boundary and the iterator can come back
+	it.Prev()
+	if v, ok := it.Next(); !ok || v != 1 {
+		t.Errorf("Next from before the front expected (1, true), got (%d, %v)", v, ok)
+	}
+}
+
+func TestIteratorReverse(t *testing.T) {
+	d := Deque.NewDequeWithData([]string{"a", "b", "c"})
+	var got []string
+	for it := d.RBegin(); it.Valid(); it.Next() {
+		got = append(got, fmt.Sprintf("%d%s", it.Index(), it.Value()))
+	}
+	if fmt.Sprint(got) != "[2c 1b 0a]" {
+		t.Errorf("Reverse iteration expected [2c 1b 0a], got %v", got)
+	}
+
+	it := d.REnd()
+	if it.Valid() || it.Index() != -1 {
+		t.Error("REnd should be before the front element")
+	}
+	if v, ok := it.Prev(); !ok || v != "a" {
+		t.Errorf("Prev from REnd expected (a, true), got (%s, %v)", v, ok)
+	}
+
+	empty := Deque.NewDeque[int]()
+	if empty.Begin().Valid() || empty.RBegin().Valid() {
+		t.Error("Iterators over an empty deque should not be valid")
+	}
+	if _, ok := empty.Begin().Next(); ok {
+		t.Error("Next over an empty deque should fail")
+	}
+}
+
+func TestIteratorCursor(t *testing.T) {
+	d := newWrappedDeque([]int{1, 2, 3, 4, 5, 6})
+
+	// Cursors plug into the adapters of the Iterator package
+	var it iterator.Iterator[int] = d.Begin().Cursor()
+	it = iterator.FilterIter(it, func(v int) bool { return v%2 == 0 })
+	it = iterator.MapIter(it, func(v int) int { return v * 10 })
+	var got []int
+	for it.Next() {
+		got = append(got, it.Value())
+	}
+	if !slices.Equal(got, []int{20, 40, 60}) {
+		t.Errorf("Expected [20 40 60], got %v", got)
+	}
+
+	var idx []int
+	for c := d.RBegin().Cursor(); c.Next(); {
+		idx = append(idx, c.Index())
+	}
+	if !slices.Equal(idx, []int{5, 4, 3, 2, 1, 0}) {
+		t.Errorf("Reverse cursor expected indices 5..0, got %v", idx)
+	}
+	if d.End().Cursor().Next() || Deque.NewDeque[int]().Begin().Cursor().Next() {
+		t.Error("A cursor on an invalid iterator should yield nothing")
+	}
+}
+
+func TestIteratorConcurrentWrites(t *testing.T) {
+	d := Deque.NewDeque[int]()
+	for i := 0; i < 100; i++ {
+		d.PushBack(i)
+	}
+	it := d.Begin()
+
+	// Pushes at both ends, including the resizes they trigger, are never observed
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 1000; i++ {
+			d.PushBack(-1)
+			d.PushFront(-2)
+		}
+	}()
+
+	count := 0
+	for ; it.Valid(); it.Next() {
+		if it.Value() != count {
+			t.Fatalf("Iterator observed a concurrent push: index %d holds %d", count, it.Value())
+		}
+		count++
+	}
+	wg.Wait()
+	if count != 100 {
+		t.Errorf("Iterator should see exactly the 100 elements present at creation, saw %d", count)
+	}
+	if d.Len() != 2100 {
+		t.Errorf("Deque should remain usable while iterated, length is %d", d.Len())
+	}
+}
+
+func TestIteratorSharesStorage(t *testing.T) {
+	// Creating an iterator must not make the next write copy the deque: the iterator reads the live array
+	d := Deque.NewDequeWithData([]int{1, 2, 3})
+	it := d.Begin()
+	d.Set(0, 10)
+	if it.Value() != 10 {
+		t.Errorf("Iterator should read the deque's own storage, got %d", it.Value())
+	}
+	rit := d.RBegin()
+	d.PushBack(4)
+	if v := rit.Value(); v != 3 {
+		t.Errorf("Reverse iterator should start at the back element present at creation, got %d", v)
+	}
+
+	u := Deque.NewUnsafeDequeWithData([]int{1, 2, 3})
+	uit := u.Begin()
+	u.Set(0, 10)
+	if uit.Value() != 10 {
+		t.Errorf("UnsafeDeque iterator should read the deque's own storage, got %d", uit.Value())
+	}
+}
+
+func TestFilter(t *testing.T) {
+	d := newWrappedDeque([]int{0, 1, 2, 3, 4, 5, 6, 7})
+	even := d.Filter(func(v int) bool { return v%2 == 0 })
+	if s := fmt.Sprint(even); s != "[0 2 4 6]" {
+		t.Fatalf("Filter expected [0 2 4 6], got %s", s)
+	}
+	if s := fmt.Sprint(d); s != "[0 1 2 3 4 5 6 7]" {
+		t.Fatalf("Filter should not modify the receiver, got %s", s)
+	}
+	if n := d.Filter(func(int) bool { return false }).Len(); n != 0 {
+		t.Errorf("Filter matching nothing should be empty, got %d elements", n)
+	}
+
+	d.FilterInPlace(func(v int) bool { return v%3 != 0 })
+	if s := fmt.Sprint(d); s != "[1 2 4 5 7]" {
+		t.Fatalf("FilterInPlace expected [1 2 4 5 7], got %s", s)
+	}
+	d.PushBack(8)
+	d.PushFront(-1)
+	if s := fmt.Sprint(d); s != "[-1 1 2 4 5 7 8]" || d.Capacity() != 8 {
+		t.Errorf("Deque should stay usable after FilterInPlace, got %s", s)
+	}
+
+	snap := d.COWSnapshot()
+	d.FilterInPlace(func(v int) bool { return v > 4 })
+	if s := fmt.Sprint(snap); s != "[-1 1 2 4 5 7 8]" {
+		t.Errorf("FilterInPlace should not affect a snapshot, got %s", s)
+	}
+}
+
+func TestMap(t *testing.T) {
+	d := newWrappedDeque([]int{0, 1, 2, 3, 4, 5, 6, 7})
+	strs := Deque.Map(d, func(v int) string { return strconv.Itoa(v * v) })
+	if s := fmt.Sprint(strs); s != "[0 1 4 9 16 25 36 49]" {
+		t.Fatalf("Map expected squares, got %s", s)
+	}
+	if s := fmt.Sprint(d); s != "[0 1 2 3 4 5 6 7]" {
+		t.Fatalf("Map should not modify its input, got %s", s)
+	}
+	if n := Deque.Map(Deque.NewDeque[int](), strconv.Itoa).Len(); n != 0 {
+		t.Errorf("Map of an empty deque should be empty, got %d elements", n)
+	}
+
+	snap := d.COWSnapshot()
+	d.MapInPlace(func(v int) int { return -v })
+	if s := fmt.Sprint(d); s != "[0 -1 -2 -3 -4 -5 -6 -7]" {
+		t.Errorf("MapInPlace expected negated values, got %s", s)
+	}
+	if s := fmt.Sprint(snap); s != "[0 1 2 3 4 5 6 7]" {
+		t.Errorf("MapInPlace should not affect a snapshot, got %s", s)
+	}
+}
+
+func TestReduce(t *testing.T) {
+	d := newWrappedDeque([]int{1, 2, 3, 4, 5, 6, 7, 8})
+	if sum := Deque.Reduce(d, 0, func(acc, v int) int { return acc + v }); sum != 36 {
+		t.Errorf("Reduce sum expected 36, got %d", sum)
+	}
+	concat := func(acc string, v int) string { return acc + strconv.Itoa(v) }
+	if s := Deque.Reduce(d, ">", concat); s != ">12345678" {
+		t.Errorf("Reduce expected >12345678, got %s", s)
+	}
+	if s := Deque.ReduceRight(d, "<", concat); s != "<87654321" {
+		t.Errorf("ReduceRight expected <87654321, got %s", s)
+	}
+	if s := Deque.ReduceRight(Deque.NewDeque[int](), "init", concat); s != "init" {
+		t.Errorf("Reducing an empty deque should return the initial value, got %s", s)
+	}
+}
+
+func TestFromSliceAppendFromSlice(t *testing.T) {
+	src := []int{1, 2, 3}
+	d := Deque.FromSlice(src)
+	src[0] = 100
+	if s := fmt.Sprint(d); s != "[1 2 3]" {
+		t.Fatalf("FromSlice should copy its input, got %s", s)
+	}
+
+	w := newWrappedDeque([]int{0, 1, 2, 3, 4, 5})
+	w.PopFront()
+	w.AppendFromSlice([]int{6})
+	if s := fmt.Sprint(w); s != "[1 2 3 4 5 6]" || w.Stats().ResizeCount != 0 {
+		t.Fatalf("AppendFromSlice into spare capacity expected [1 2 3 4 5 6] without resizing, got %s", s)
+	}
+
+	big := make([]int, 100)
+	for i := range big {
+		big[i] = 7 + i
+	}
+	w.AppendFromSlice(big)
+	if w.Len() != 106 || w.Stats().ResizeCount != 1 || w.Capacity() != 192 {
+		t.Errorf("Expected one resize to capacity 192, got %d resizes and capacity %d",
+			w.Stats().ResizeCount, w.Capacity())
+	}
+	if got := w.ToSlice(); got[0] != 1 || got[105] != 106 || !slices.IsSorted(got) {
+		t.Errorf("AppendFromSlice should keep order, got %v", got)
+	}
+	w.AppendFromSlice(nil)
+	if w.Len() != 106 {
+		t.Error("Appending an empty slice should be a no-op")
+	}
+
+	bounded := Deque.NewDequeWithData([]int{1}, Deque.WithMaxCapacity(4))
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("AppendFromSlice beyond max capacity should panic")
+			}
+		}()
+		bounded.AppendFromSlice([]int{2, 3, 4, 5})
+	}()
+	if bounded.Len() != 1 {
+		t.Errorf("A failed AppendFromSlice should leave the deque unchanged, got %d elements", bounded.Len())
+	}
+}
+
+func TestJSON(t *testing.T) {
+	type point struct {
+		X, Y int
+	}
+	d := newWrappedDeque([]int{0, 1, 2, 3, 4, 5, 6, 7})
+	b, err := json.Marshal(d)
+	if err != nil || string(b) != "[0,1,2,3,4,5,6,7]" {
+		t.Fatalf("Marshal expected [0,1,2,3,4,5,6,7], got %s (%v)", b, err)
+	}
+	var back Deque.Deque[int]
+	if err := json.Unmarshal(b, &back); err != nil || fmt.Sprint(&back) != "[0 1 2 3 4 5 6 7]" {
+		t.Fatalf("Round trip failed: %v (%v)", &back, err)
+	}
+	back.PushFront(-1)
+	if back.Len() != 9 {
+		t.Error("Unmarshaled deque should stay usable")
+	}
+
+	strs := Deque.NewDeque[string]()
+	strs.PushBack("stale")
+	if err := json.Unmarshal([]byte(`["a","b"]`), strs); err != nil || fmt.Sprint(strs) != "[a b]" {
+		t.Errorf("Unmarshal should replace the contents, got %v (%v)", strs, err)
+	}
+	if err := json.Unmarshal([]byte(`[1]`), strs); err == nil || fmt.Sprint(strs) != "[a b]" {
+		t.Errorf("Mismatched elements should fail without modifying the deque, got %v", strs)
+	}
+
+	points := Deque.FromSlice([]point{{1, 2}, {3, 4}})
+	b, _ = json.Marshal(points)
+	pts := Deque.NewDeque[point]()
+	if err := json.Unmarshal(b, pts); err != nil || must(pts.Back()) != (point{3, 4}) {
+		t.Errorf("Struct round trip failed: %s (%v)", b, err)
+	}
+
+	bounded := Deque.NewDequeWithData([]int{1}, Deque.WithMaxCapacity(2))
+	if err := json.Unmarshal([]byte("[1,2,3]"), bounded); err == nil || bounded.Len() != 1 {
+		t.Error("Unmarshal beyond max capacity should fail")
+	}
+}
+
+func TestMarshalBinaryEdgeCases(t *testing.T) {
+	full := Deque.NewDeque[int](4)
+	for i := 0; i < 4; i++ {
+		full.PushBack(i)
+	}
+	cases := map[string]*Deque.Deque[int]{
+		"empty":   Deque.NewDeque[int](),
+		"single":  Deque.FromSlice([]int{42}),
+		"full":    full,
+		"wrapped": newWrappedDeque([]int{0, 1, 2, 3, 4, 5, 6, 7}),
+	}
+	for name, d := range cases {
+		b, err := d.MarshalBinary()
+		if err != nil {
+			t.Fatalf("%s: MarshalBinary failed: %v", name, err)
+		}
+		r := Deque.NewDeque[int]()
+		if err := r.UnmarshalBinary(b); err != nil {
+			t.Fatalf("%s: UnmarshalBinary failed: %v", name, err)
+		}
+		if fmt.Sprint(r) != fmt.Sprint(d) || r.Capacity() != d.Capacity() {
+			t.Errorf("%s: expected %v with capacity %d, got %v with capacity %d",
+				name, d, d.Capacity(), r, r.Capacity())
+		}
+	}
+}
+
+func TestSort(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+	d := newWrappedDeque([]int{5, 3, 7, 1, 6, 0, 4, 2})
+	d.Sort(less)
+	if s := fmt.Sprint(d); s != "[0 1 2 3 4 5 6 7]" || !d.IsContiguous() {
+		t.Fatalf("Sort expected [0 1 2 3 4 5 6 7], got %s", s)
+	}
+	d.PushFront(-1)
+	d.PushBack(8)
+	if s := fmt.Sprint(d); s != "[-1 0 1 2 3 4 5 6 7 8]" {
+		t.Errorf("Deque should stay usable after Sort, got %s", s)
+	}
+
+	offset := Deque.NewDeque[int]()
+	for _, v := range []int{9, 9, 9, 3, 1, 2} {
+		offset.PushBack(v)
+	}
+	offset.PopNFront(3)
+	offset.Sort(func(a, b int) bool { return a > b })
+	if s := fmt.Sprint(offset); s != "[3 2 1]" {
+		t.Errorf("Sort descending expected [3 2 1], got %s", s)
+	}
+
+	type entry struct {
+		key, seq int
+	}
+	entries := Deque.NewDeque[entry]()
+	for i, k := range []int{2, 1, 2, 1, 0, 2} {
+		entries.PushFront(entry{k, i})
+	}
+	Deque.SortStable(entries, func(a, b entry) bool { return a.key < b.key })
+	want := []entry{{0, 4}, {1, 3}, {1, 1}, {2, 5}, {2, 2}, {2, 0}}
+	if got := entries.ToSlice(); !slices.Equal(got, want) {
+		t.Errorf("SortStable expected %v, got %v", want, got)
+	}
+}
+
+func TestBinarySearchBounds(t *testing.T) {
+	d := newWrappedDeque([]int{1, 3, 3, 3, 5, 7, 9, 9})
+	if d.IsContiguous() {
+		t.Fatal("Test deque should wrap around the end of its buffer")
+	}
+	cases := []struct {
+		target       int
+		index, lower int
+		upper        int
+		found        bool
+	}{
+		{0, 0, 0, 0, false},
+		{1, 0, 0, 1, true},
+		{3, 1, 1, 4, true},
+		{4, 4, 4, 4, false},
+		{9, 6, 6, 8, true},
+		{10, 8, 8, 8, false},
+	}
+	for _, c := range cases {
+		index, found := d.BinarySearch(c.target, cmp.Compare[int])
+		if index != c.index || found != c.found {
+			t.Errorf("BinarySearch(%d) expected (%d, %v), got (%d, %v)", c.target, c.index, c.found, index, found)
+		}
+		if lb := d.LowerBound(c.target, cmp.Compare[int]); lb != c.lower {
+			t.Errorf("LowerBound(%d) expected %d, got %d", c.target, c.lower, lb)
+		}
+		if ub := d.UpperBound(c.target, cmp.Compare[int]); ub != c.upper {
+			t.Errorf("UpperBound(%d) expected %d, got %d", c.target, c.upper, ub)
+		}
+	}
+	if i, found := Deque.NewDeque[int]().BinarySearch(1, cmp.Compare[int]); i != 0 || found {
+		t.Error("BinarySearch on an empty deque should return (0, false)")
+	}
+}
+
+func TestFind(t *testing.T) {
+	d := newWrappedDeque([]int{4, 9, 2, 9, 6, 1, 9, 3})
+	isNine := func(v int) bool { return v == 9 }
+	if i, v, ok := d.Find(isNine); !ok || i != 1 || v != 9 {
+		t.Errorf("Find expected (1, 9, true), got (%d, %d, %v)", i, v, ok)
+	}
+	if i, v, ok := d.FindLast(isNine); !ok || i != 6 || v != 9 {
+		t.Errorf("FindLast expected (6, 9, true), got (%d, %d, %v)", i, v, ok)
+	}
+	if i, _, ok := d.FindLast(func(v int) bool { return v < 4 }); !ok || i != 7 {
+		t.Errorf("FindLast expected index 7, got %d", i)
+	}
+	if got := d.FindAll(isNine); !slices.Equal(got, []int{1, 3, 6}) {
+		t.Errorf("FindAll expected [1 3 6], got %v", got)
+	}
+
+	never := func(int) bool { return false }
+	if i, v, ok := d.Find(never); ok || i != -1 || v != 0 {
+		t.Errorf("Find without a match expected (-1, 0, false), got (%d, %d, %v)", i, v, ok)
+	}
+	if _, _, ok := d.FindLast(never); ok {
+		t.Error("FindLast without a match should fail")
+	}
+	if got := d.FindAll(never); got == nil || len(got) != 0 {
+		t.Errorf("FindAll without a match should return an empty slice, got %#v", got)
+	}
+}
+
+func TestInsert(t *testing.T) {
+	d := newWrappedDeque([]int{0, 1, 2, 3, 4, 5})
+	steps := []struct {
+		insert func() bool
+		want   string
+	}{
+		{func() bool { return d.Insert(1, 10) }, "[0 10 1 2 3 4 5]"},
+		{func() bool { return d.Insert(5, 11) }, "[0 10 1 2 3 11 4 5]"},
+		{func() bool { return d.Insert(8, 12) }, "[0 10 1 2 3 11 4 5 12]"},
+		{func() bool { return d.Insert(-1, 13) }, "[0 10 1 2 3 11 4 5 13 12]"},
+		{func() bool { return d.Insert(0, 14) }, "[14 0 10 1 2 3 11 4 5 13 12]"},
+		{func() bool { return d.InsertSlice(2, []int{20, 21, 22}) }, "[14 0 20 21 22 10 1 2 3 11 4 5 13 12]"},
+		{func() bool { return d.InsertSlice(-2, []int{23, 24}) }, "[14 0 20 21 22 10 1 2 3 11 4 5 23 24 13 12]"},
+		{func() bool { return d.InsertSlice(16, []int{25}) }, "[14 0 20 21 22 10 1 2 3 11 4 5 23 24 13 12 25]"},
+		{func() bool { return d.InsertSlice(3, nil) }, "[14 0 20 21 22 10 1 2 3 11 4 5 23 24 13 12 25]"},
+	}
+	for i, step := range steps {
+		if !step.insert() {
+			t.Fatalf("Step %d: insert should succeed", i)
+		}
+		if s := fmt.Sprint(d); s != step.want {
+			t.Fatalf("Step %d: expected %s, got %s", i, step.want, s)
+		}
+	}
+	if d.Insert(18, 0) || d.Insert(-18, 0) || d.InsertSlice(18, []int{0}) || d.InsertSlice(-18, nil) {
+		t.Error("Out-of-range inserts should fail")
+	}
+
+	big := make([]int, 100)
+	for i := range big {
+		big[i] = 100 + i
+	}
+	w := newWrappedDeque([]int{0, 1, 2, 3, 4, 5, 6, 7})
+	w.InsertSlice(1, big)
+	if got := w.ToSlice(); w.Len() != 108 || got[0] != 0 || got[1] != 100 || got[100] != 199 || got[101] != 1 {
+		t.Errorf("Large InsertSlice produced %v", got)
+	}
+	if w.Stats().ResizeCount != 1 {
+		t.Errorf("InsertSlice should resize at most once, got %d resizes", w.Stats().ResizeCount)
+	}
+
+	bounded := Deque.NewDequeWithData([]int{1, 2}, Deque.WithMaxCapacity(3))
+	if bounded.InsertSlice(1, []int{8, 9}) || !bounded.Insert(1, 8) || bounded.Insert(1, 9) {
+		t.Error("Inserts should fail only when the deque would exceed its max capacity")
+	}
+	if s := fmt.Sprint(bounded); s != "[1 8 2]" {
+		t.Errorf("Expected [1 8 2], got %s", s)
+	}
+}
+
+func TestRemove(t *testing.T) {
+	d := newWrappedDeque([]int{0, 1, 2, 3, 4, 5, 6, 7})
+	if v, ok := d.Remove(2); !ok || v != 2 {
+		t.Errorf("Remove(2) expected 2, got %d", v)
+	}
+	if v, ok := d.Remove(-2); !ok || v != 6 {
+		t.Errorf("Remove(-2) expected 6, got %d", v)
+	}
+	if s := fmt.Sprint(d); s != "[0 1 3 4 5 7]" {
+		t.Fatalf("Expected [0 1 3 4 5 7], got %s", s)
+	}
+	if _, ok := d.Remove(6); ok {
+		t.Error("Remove out of range should fail")
+	}
+	if _, ok := d.Remove(-7); ok {
+		t.Error("Remove with a negative index out of range should fail")
+	}
+
+	cases := []struct {
+		from, to int
+		want     string
+	}{
+		{1, 3, "[0 3 4 5 6 7 8 9 10 11]"},
+		{8, 11, "[0 1 2 3 4 5 6 7 11]"},
+		{-3, -1, "[0 1 2 3 4 5 6 7 8 11]"},
+		{0, 12, "[]"},
+		{5, 5, "[0 1 2 3 4 5 6 7 8 9 10 11]"},
+	}
+	for _, c := range cases {
+		r := newWrappedDeque([]int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11})
+		if !r.RemoveRange(c.from, c.to) {
+			t.Fatalf("RemoveRange(%d, %d) should succeed", c.from, c.to)
+		}
+		if s := fmt.Sprint(r); s != c.want {
+			t.Errorf("RemoveRange(%d, %d) expected %s, got %s", c.from, c.to, c.want, s)
+		}
+		r.PushBack(99)
+		r.PushFront(-1)
+		if must(r.Front()) != -1 || must(r.Back()) != 99 {
+			t.Errorf("RemoveRange(%d, %d) left the deque unusable", c.from, c.to)
+		}
+	}
+	if d.RemoveRange(3, 2) || d.RemoveRange(0, 7) || d.RemoveRange(-7, 1) {
+		t.Error("Invalid ranges should fail")
+	}
+	if d.Len() != 6 {
+		t.Errorf("Failed removals should leave the deque unchanged, got %v", d)
+	}
+}
+
+func TestExtendPrepend(t *testing.T) {
+	d := newWrappedDeque([]int{0, 1, 2, 3})
+	other := newWrappedDeque([]int{4, 5, 6, 7, 8, 9})
+	d.Extend(other)
+	if s := fmt.Sprint(d); s != "[0 1 2 3 4 5 6 7 8 9]" {
+		t.Fatalf("Extend expected [0 1 2 3 4 5 6 7 8 9], got %s", s)
+	}
+	if s := fmt.Sprint(other); s != "[4 5 6 7 8 9]" {
+		t.Fatalf("Extend should not modify its argument, got %s", s)
+	}
+	d.Prepend(Deque.FromSlice([]int{-2, -1}))
+	if s := fmt.Sprint(d); s != "[-2 -1 0 1 2 3 4 5 6 7 8 9]" {
+		t.Fatalf("Prepend expected [-2 -1 0 ... 9], got %s", s)
+	}
+
+	self := newWrappedDeque([]int{1, 2, 3, 4})
+	self.Extend(self)
+	self.Prepend(self)
+	if s := fmt.Sprint(self); s != "[1 2 3 4 1 2 3 4 1 2 3 4 1 2 3 4]" {
+		t.Errorf("Extending a deque with itself expected four copies, got %s", s)
+	}
+
+	empty := Deque.NewDeque[int]()
+	empty.Prepend(empty)
+	d.Extend(empty)
+	if d.Len() != 12 || !empty.Empty() {
+		t.Error("Extending with an empty deque should be a no-op")
+	}
+
+	snap := other.COWSnapshot()
+	other.Extend(Deque.FromSlice([]int{10}))
+	if snap.Len() != 6 || other.Len() != 7 {
+		t.Error("Extend should not write through to a snapshot")
+	}
+
+	bounded := Deque.NewDequeWithData([]int{1}, Deque.WithMaxCapacity(4))
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("Extend beyond max capacity should panic")
+			}
+		}()
+		bounded.Extend(other)
+	}()
+	if bounded.Len() != 1 {
+		t.Errorf("A failed Extend should leave the deque unchanged, got %v", bounded)
+	}
+}
+
+func TestSplit(t *testing.T) {
+	d := newWrappedDeque([]int{0, 1, 2, 3, 4, 5, 6, 7})
+	cases := []struct {
+		index       int
+		left, right string
+	}{
+		{3, "[0 1 2]", "[3 4 5 6 7]"},
+		{0, "[]", "[0 1 2 3 4 5 6 7]"},
+		{8, "[0 1 2 3 4 5 6 7]", "[]"},
+		{-2, "[0 1 2 3 4 5]", "[6 7]"},
+	}
+	for _, c := range cases {
+		left, right, ok := d.Split(c.index)
+		if !ok || fmt.Sprint(left) != c.left || fmt.Sprint(right) != c.right {
+			t.Errorf("Split(%d) expected %s %s, got %v %v", c.index, c.left, c.right, left, right)
+		}
+	}
+	if s := fmt.Sprint(d); s != "[0 1 2 3 4 5 6 7]" {
+		t.Fatalf("Split should not modify the receiver, got %s", s)
+	}
+	for _, index := range []int{9, -9} {
+		if left, right, ok := d.Split(index); ok || left != nil || right != nil {
+			t.Errorf("Split(%d) should fail", index)
+		}
+	}
+
+	left, right, _ := d.Split(4)
+	left.PushBack(100)
+	right.PushFront(-100)
+	if s := fmt.Sprint(left, right); s != "[0 1 2 3 100] [-100 4 5 6 7]" {
+		t.Errorf("Split halves should be independent, got %s", s)
+	}
+
+	bounded := Deque.NewDequeWithData([]int{1, 2, 3}, Deque.WithMaxCapacity(3))
+	_, r, _ := bounded.Split(1)
+	if r.Capacity() != 3 || !r.PushBackOrDiscard(4) || r.PushBackOrDiscard(5) {
+		t.Errorf("Split should keep the max capacity, got capacity %d", r.Capacity())
+	}
+}
+
+func TestWindowsChunks(t *testing.T) {
+	d := newWrappedDeque([]int{0, 1, 2, 3, 4, 5, 6, 7})
+	collect := func(next func() ([]int, bool)) string {
+		var parts []string
+		for s, ok := next(); ok; s, ok = next() {
+			parts = append(parts, fmt.Sprint(s))
+		}
+		return strings.Join(parts, " ")
+	}
+
+	windows := d.Windows(6)
+	d.PushBack(8)
+	if got := collect(windows); got != "[0 1 2 3 4 5] [1 2 3 4 5 6] [2 3 4 5 6 7]" {
+		t.Errorf("Windows(6) over a snapshot expected three windows, got %s", got)
+	}
+	if got := collect(d.Chunks(4)); got != "[0 1 2 3] [4 5 6 7] [8]" {
+		t.Errorf("Chunks(4) expected a short last chunk, got %s", got)
+	}
+	if got := collect(d.Windows(10)); got != "" {
+		t.Errorf("Windows larger than the deque should yield nothing, got %s", got)
+	}
+	if got := collect(Deque.NewDeque[int]().Chunks(1)); got != "" {
+		t.Errorf("Chunks of an empty deque should yield nothing, got %s", got)
+	}
+
+	next := d.Windows(2)
+	first, _ := next()
+	first[1] = 100
+	if second, _ := next(); second[0] != 1 {
+		t.Error("Windows should return independent copies")
+	}
+
+	for _, size := range []int{0, -1} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Windows(%d) should panic", size)
+				}
+			}()
+			d.Windows(size)
+		}()
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Chunks(%d) should panic", size)
+				}
+			}()
+			d.Chunks(size)
+		}()
+	}
+}
+
+func TestAnyAllNone(t *testing.T) {
+	d := newWrappedDeque([]int{2, 4, 6, 7, 8, 10, 12, 14})
+	calls := 0
+	counted := func(pred func(int) bool) func(int) bool {
+		calls = 0
+		return func(v int) bool {
+			calls++
+			return pred(v)
+		}
+	}
+	even := func(v int) bool { return v%2 == 0 }
+	odd := func(v int) bool { return v%2 != 0 }
+
+	if !d.Any(counted(odd)) || calls != 4 {
+		t.Errorf("Any should stop at the first match, visited %d elements", calls)
+	}
+	if d.All(counted(even)) || calls != 4 {
+		t.Errorf("All should stop at the first mismatch, visited %d elements", calls)
+	}
+	if d.None(counted(odd)) || calls != 4 {
+		t.Errorf("None should stop at the first match, visited %d elements", calls)
+	}
+	if !d.All(counted(func(v int) bool { return v > 0 })) || calls != 8 {
+		t.Errorf("All should visit every element when all match, visited %d", calls)
+	}
+	if !d.None(func(v int) bool { return v > 100 }) || d.Any(func(v int) bool { return v > 100 }) {
+		t.Error("None and Any disagree")
+	}
+
+	empty := Deque.NewDeque[int]()
+	if empty.Any(even) || !empty.All(even) || !empty.None(even) {
+		t.Error("Empty deque: Any should be false, All and None true")
+	}
+}
+
+func TestCount(t *testing.T) {
+	d := newWrappedDeque([]int{1, 2, 3, 2, 5, 2, 7, 8})
+	if n := d.Count(func(v int) bool { return v%2 == 0 }); n != 4 {
+		t.Errorf("Count of even values expected 4, got %d", n)
+	}
+	eq := func(a, b int) bool { return a == b }
+	if n := d.CountEqual(2, eq); n != 3 {
+		t.Errorf("CountEqual(2) expected 3, got %d", n)
+	}
+	if n := d.CountEqual(4, eq); n != 0 {
+		t.Errorf("CountEqual(4) expected 0, got %d", n)
+	}
+	if n := Deque.NewDeque[int]().Count(func(int) bool { return true }); n != 0 {
+		t.Errorf("Count on an empty deque expected 0, got %d", n)
+	}
+	if allocs := testing.AllocsPerRun(10, func() { d.CountEqual(2, eq) }); allocs > 1 {
+		t.Errorf("CountEqual should not copy the deque, got %v allocations", allocs)
+	}
+}
+
+func TestUnsafeDeque(t *testing.T) {
+	q := Deque.NewUnsafeDeque[int](2)
+	if _, ok := q.PopFront(); ok || !q.Empty() {
+		t.Fatal("New UnsafeDeque should be empty")
+	}
+	for i := 0; i < 5; i++ {
+		q.PushBack(i)
+		q.PushFront(-i - 1)
+	}
+	if s := fmt.Sprint(q); s != "[-5 -4 -3 -2 -1 0 1 2 3 4]" || q.Capacity() != 16 {
+		t.Fatalf("Expected [-5 ... 4] with capacity 16, got %s with capacity %d", s, q.Capacity())
+	}
+	if v, ok := q.PopFront(); !ok || v != -5 {
+		t.Errorf("PopFront expected -5, got %d", v)
+	}
+	if v, ok := q.PopBack(); !ok || v != 4 {
+		t.Errorf("PopBack expected 4, got %d", v)
+	}
+	if !q.Set(-1, 30) || q.Set(8, 0) || must(q.Back()) != 30 || must(q.Front()) != -4 {
+		t.Errorf("Unexpected Set/Front/Back results on %v", q)
+	}
+	if v, ok := q.At(4); !ok || v != 0 {
+		t.Errorf("At(4) expected 0, got %d", v)
+	}
+	if _, ok := q.At(-9); ok {
+		t.Error("At out of range should fail")
+	}
+
+	sum := 0
+	q.ForEach(func(i, v int) { sum += v })
+	if got := q.ToSlice(); !slices.Equal(got, []int{-4, -3, -2, -1, 0, 1, 2, 30}) || sum != 23 {
+		t.Errorf("Unexpected contents %v", got)
+	}
+	q.Clear()
+	if q.Len() != 0 || q.Capacity() != 16 {
+		t.Error("Clear should empty the deque and keep its capacity")
+	}
+
+	// Mirror a random sequence of operations on a Deque
+	ref := Deque.NewDeque[int]()
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 2000; i++ {
+		switch r.Intn(4) {
+		case 0:
+			q.PushBack(i)
+			ref.PushBack(i)
+		case 1:
+			q.PushFront(i)
+			ref.PushFront(i)
+		case 2:
+			a, aok := q.PopFront()
+			b, bok := ref.PopFront()
+			if a != b || aok != bok {
+				t.Fatalf("Step %d: PopFront mismatch %d/%v vs %d/%v", i, a, aok, b, bok)
+			}
+		case 3:
+			a, aok := q.PopBack()
+			b, bok := ref.PopBack()
+			if a != b || aok != bok {
+				t.Fatalf("Step %d: PopBack mismatch %d/%v vs %d/%v", i, a, aok, b, bok)
+			}
+		}
+	}
+	if !slices.Equal(q.ToSlice(), ref.ToSlice()) {
+		t.Error("UnsafeDeque and Deque diverged")
+	}
+}
+
+func TestUnsafeDequeMatchesDeque(t *testing.T) {
+	opts := []Deque.DequeOption{Deque.WithCapacity(3), Deque.WithGrowthFactor(1.5), Deque.WithMaxCapacity(64)}
+	ref := Deque.NewDequeWithData([]int{5, 1, 4}, opts...)
+	q := Deque.NewUnsafeDequeWithData([]int{5, 1, 4}, opts...)
+	r := rand.New(rand.NewSource(7))
+	less := func(a, b int) bool { return a < b }
+	even := func(v int) bool { return v%2 == 0 }
+	eq := func(a, b int) bool { return a == b }
+
+	for step := 0; step < 5000; step++ {
+		v := r.Intn(50)
+		i, j := r.Intn(12)-2, r.Intn(12)-2
+		var got, want any
+		op := r.Intn(32)
+		switch op {
+		case 0:
+			got, want = q.PushBackOrDiscard(v), ref.PushBackOrDiscard(v)
+		case 1:
+			got, want = q.PushFrontOrDiscard(v), ref.PushFrontOrDiscard(v)
+		case 2:
+			got, want = fmt.Sprint(q.PopFront()), fmt.Sprint(ref.PopFront())
+		case 3:
+			got, want = fmt.Sprint(q.PopBack()), fmt.Sprint(ref.PopBack())
+		case 4:
+			got, want = q.Insert(i, v), ref.Insert(i, v)
+		case 5:
+			got, want = fmt.Sprint(q.Remove(i)), fmt.Sprint(ref.Remove(i))
+		case 6:
+			got, want = q.RemoveRange(i, j), ref.RemoveRange(i, j)
+		case 7:
+			got, want = q.InsertSlice(i, []int{v, v + 1, v + 2}), ref.InsertSlice(i, []int{v, v + 1, v + 2})
+		case 8:
+			q.Rotate(i)
+			ref.Rotate(i)
+		case 9:
+			q.Reverse()
+			ref.Reverse()
+		case 10:
+			q.Sort(less)
+			ref.Sort(less)
+			if q.Len() < 64 {
+				got, want = q.SortedInsert(v, less), ref.SortedInsert(v, less)
+			}
+		case 11:
+			got, want = q.MoveToFront(i), ref.MoveToFront(i)
+		case 12:
+			got, want = q.MoveToBack(i), ref.MoveToBack(i)
+		case 13:
+			got, want = q.Swap(i, j), ref.Swap(i, j)
+		case 14:
+			got, want = q.PopNFront(i), ref.PopNFront(i)
+		case 15:
+			got, want = q.PopBackWhile(even), ref.PopBackWhile(even)
+		case 16:
+			q.Compact(eq)
+			ref.Compact(eq)
+		case 17:
+			q.FilterInPlace(func(v int) bool { return v%7 != 0 })
+			ref.FilterInPlace(func(v int) bool { return v%7 != 0 })
+		case 18:
+			if q.Len() < 32 {
+				q.Extend(q)
+				ref.Extend(ref)
+			}
+		case 19:
+			if q.Len() < 32 {
+				q.Prepend(q)
+				ref.Prepend(ref)
+			}
+		case 20:
+			q.ShrinkToFit()
+			ref.ShrinkToFit()
+		case 21:
+			got, want = fmt.Sprint(q.GetAndSet(i, v)), fmt.Sprint(ref.GetAndSet(i, v))
+		case 22:
+			got, want = q.PeekBackN(i), ref.PeekBackN(i)
+		case 23:
+			if q.Len() < 56 {
+				q.AppendFromSlice([]int{v, v})
+				ref.AppendFromSlice([]int{v, v})
+			}
+		case 24:
+			got, want = fmt.Sprint(q.PopFrontIf(even)), fmt.Sprint(ref.PopFrontIf(even))
+		case 25:
+			got, want = q.PopNBack(i), ref.PopNBack(i)
+		case 26:
+			got, want = q.PopFrontWhile(even), ref.PopFrontWhile(even)
+		case 27:
+			got, want = q.PeekFrontN(i), ref.PeekFrontN(i)
+		case 28:
+			got, want = q.TrySet(i, v, v+1, eq), ref.TrySet(i, v, v+1, eq)
+		case 29:
+			q.Sort(less)
+			ref.Sort(less)
+			got, want = q.SortedRemove(v, cmp.Compare[int]), ref.SortedRemove(v, cmp.Compare[int])
+		case 30:
+			q.MapInPlace(func(x int) int { return (x * 3) % 50 })
+			ref.MapInPlace(func(x int) int { return (x * 3) % 50 })
+		case 31:
+			a, b, c := q.Find(even)
+			x, y, z := q.FindLast(even)
+			got = fmt.Sprint(a, b, c, x, y, z)
+			a, b, c = ref.Find(even)
+			x, y, z = ref.FindLast(even)
+			want = fmt.Sprint(a, b, c, x, y, z)
+		}
+		if fmt.Sprint(got) != fmt.Sprint(want) {
+			t.Fatalf("Step %d (op %d): UnsafeDeque returned %v, Deque returned %v", step, op, got, want)
+		}
+		if q.Debug() != ref.Debug() || q.MaxLen() != ref.MaxLen() || q.Stats() != ref.Stats() {
+			t.Fatalf("Step %d (op %d): UnsafeDeque\n%s\ndiverged from Deque\n%s", step, op, q.Debug(), ref.Debug())
+		}
+		// Every element that entered or left is counted exactly once, by whichever method moved it
+		if st := ref.Stats(); st.CurrentLen != 3+st.PushFrontCount+st.PushBackCount+st.InsertCount-
+			st.PopFrontCount-st.PopBackCount-st.RemoveCount {
+			t.Fatalf("Step %d (op %d): counters %+v do not add up to the length", step, op, st)
+		}
+	}
+
+	if q.IsContiguous() != ref.IsContiguous() || q.All(even) != ref.All(even) || q.None(even) != ref.None(even) ||
+		q.CountEqual(4, eq) != ref.CountEqual(4, eq) {
+		t.Error("Predicates should match")
+	}
+	if !slices.Equal(q.Unique(eq).ToSlice(), ref.Unique(eq).ToSlice()) || !q.Copy().Equal(q, eq) {
+		t.Error("Unique and Copy should match")
+	}
+	ql, qr, qok := q.Split(-2)
+	rl, rr, rok := ref.Split(-2)
+	if qok != rok || fmt.Sprint(ql, qr) != fmt.Sprint(rl, rr) || ql.Capacity() != rl.Capacity() {
+		t.Errorf("Split(-2) gave %v %v, Deque gave %v %v", ql, qr, rl, rr)
+	}
+	qw, rw := q.Windows(3), ref.Windows(3)
+	qc, rc := q.Chunks(4), ref.Chunks(4)
+	for {
+		a, aok := qw()
+		b, bok := rw()
+		c, cok := qc()
+		d, dok := rc()
+		if !slices.Equal(a, b) || aok != bok || !slices.Equal(c, d) || cok != dok {
+			t.Fatal("Windows and Chunks should match")
+		}
+		if !aok && !cok {
+			break
+		}
+	}
+	if q.End().Index() != ref.End().Index() || q.Begin().Value() != ref.Begin().Value() || q.REnd().Index() != -1 {
+		t.Error("Iterators should match")
+	}
+	if fmt.Sprintf("%.3v", q) != fmt.Sprintf("%.3v", ref) || !slices.Equal(q.FindAll(even), ref.FindAll(even)) {
+		t.Error("Formatting and searches should match")
+	}
+	if q.Count(even) != ref.Count(even) || q.Any(even) != ref.Any(even) || q.Filter(even).Len() != ref.Filter(even).Len() {
+		t.Error("Predicates should match")
+	}
+	q.Sort(less)
+	ref.Sort(less)
+	for v := -1; v < 51; v++ {
+		cmp := cmp.Compare[int]
+		qi, qok := q.BinarySearch(v, cmp)
+		ri, rok := ref.BinarySearch(v, cmp)
+		if qi != ri || qok != rok || q.UpperBound(v, cmp) != ref.UpperBound(v, cmp) {
+			t.Fatalf("Searches for %d differ", v)
+		}
+	}
+
+	q.ResetStats()
+	q.ResetMaxLen()
+	if q.Stats().PushBackCount != 0 || q.MaxLen() != q.Len() {
+		t.Error("ResetStats and ResetMaxLen should restart the counters")
+	}
+	q.Reset()
+	if q.Len() != 0 || q.MaxLen() != 0 || q.Capacity() != 3 {
+		t.Errorf("Reset should restore the initial capacity 3, got %d", q.Capacity())
+	}
+}
+
+func TestUnsafeDequeEncodingAndIterators(t *testing.T) {
+	q := Deque.NewUnsafeDeque[string](4)
+	for _, s := range []string{"b", "c", "d"} {
+		q.PushBack(s)
+	}
+	q.PushFront("a")
+	q.PopFront()
+	q.PushBack("e")
+
+	b, err := q.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	d := Deque.NewDeque[string]()
+	if err := d.UnmarshalBinary(b); err != nil || !slices.Equal(d.ToSlice(), q.ToSlice()) {
+		t.Fatalf("Deque should decode an UnsafeDeque payload, got %v, %v", d, err)
+	}
+	if b, err = d.MarshalBinary(); err != nil {
+		t.Fatal(err)
+	}
+	back := Deque.NewUnsafeDequeWithData[string](nil, Deque.WithMaxCapacity(4))
+	if err := back.UnmarshalBinary(b); err != nil || fmt.Sprint(back) != "[b c d e]" || back.Capacity() != 4 {
+		t.Fatalf("UnsafeDeque should decode a Deque payload, got %v, %v", back, err)
+	}
+	small := Deque.NewUnsafeDequeWithData[string](nil, Deque.WithMaxCapacity(2))
+	if err := small.UnmarshalBinary(b); err == nil || small.Len() != 0 {
+		t.Error("UnmarshalBinary should respect the maximum capacity")
+	}
+	if back.PushBackOrDiscard("f") || back.Len() != 4 {
+		t.Error("PushBackOrDiscard should discard on a full bounded deque")
+	}
+	func() {
+		defer func() {
+			if r := recover(); r == nil || !strings.Contains(fmt.Sprint(r), "max capacity 4") {
+				t.Errorf("PushFront on a full bounded deque should panic, got %v", r)
+			}
+		}()
+		back.PushFront("z")
+	}()
+	if err := back.PushBackWithContext(context.Background(), "f"); !errors.Is(err, Deque.ErrFull) || back.Len() != 4 {
+		t.Errorf("PushBackWithContext on a full UnsafeDeque should fail with ErrFull, got %v", err)
+	}
+
+	js, err := json.Marshal(q)
+	if err != nil || string(js) != `["b","c","d","e"]` {
+		t.Fatalf("Unexpected JSON %s, %v", js, err)
+	}
+	var u Deque.UnsafeDeque[string]
+	if err := json.Unmarshal(js, &u); err != nil || fmt.Sprint(&u) != "[b c d e]" {
+		t.Fatalf("UnmarshalJSON failed: %v, %v", &u, err)
+	}
+
+	var seen []string
+	for it := q.RBegin(); it.Valid(); it.Next() {
+		seen = append(seen, it.Value())
+		q.PushBack("x") // pushes after creation are not observed
+	}
+	if strings.Join(seen, "") != "edcb" {
+		t.Errorf("Reverse iteration expected edcb, got %v", seen)
+	}
+	snap := q.COWSnapshot()
+	drained := slices.Collect(q.Drain())
+	if len(drained) != 8 || !q.Empty() || snap.Len() != 8 || !snap.Equal(Deque.NewUnsafeDequeWithData(drained), func(a, b string) bool { return a == b }) {
+		t.Error("Drain should empty the deque and leave the snapshot intact")
+	}
+
+	if _, err := q.PopFrontWithContext(context.Background()); !errors.Is(err, Deque.ErrEmpty) {
+		t.Errorf("PopFrontWithContext on an empty UnsafeDeque should fail with ErrEmpty, got %v", err)
+	}
+	done, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := q.PopFrontWithContext(done); !errors.Is(err, context.Canceled) {
+		t.Errorf("PopFrontWithContext with a done ctx should return ctx.Err(), got %v", err)
+	}
+}
+
+func TestEqual(t *testing.T) {
+	a := Deque.NewDeque[int](4)
+	b := Deque.NewDeque[int](16)
+	for i := 0; i < 6; i++ {
+		a.PushBack(i)
+	}
+	// Build b with a different ring layout
+	for i := 5; i >= 0; i-- {
+		b.PushFront(i)
+	}
+	if !Deque.DequeEqual(a, b) || !Deque.DequeEqual(b, a) || !Deque.DequeEqual(a, a) {
+		t.Errorf("Expected %v and %v to be equal", a, b)
+	}
+	b.Set(3, 13)
+	if Deque.DequeEqual(a, b) {
+		t.Error("Deques differing in one element should not be equal")
+	}
+	if !a.Equal(b, func(x, y int) bool { return x%10 == y%10 }) {
+		t.Error("Equal should use the supplied comparison")
+	}
+	b.PopBack()
+	if Deque.DequeEqual(a, b) {
+		t.Error("Deques of different lengths should not be equal")
+	}
+	if !Deque.DequeEqual(Deque.NewDeque[int](), Deque.NewDeque[int](32)) {
+		t.Error("Empty deques should be equal")
+	}
+}
+
+func TestEqualConcurrent(t *testing.T) {
+	a := newWrappedDeque([]int{1, 2, 3})
+	b := newWrappedDeque([]int{1, 2, 3})
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				a.Equal(b, func(x, y int) bool { return x == y })
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				b.Equal(a, func(x, y int) bool { return x == y })
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestZipWith(t *testing.T) {
+	a := newWrappedDeque([]int{1, 2, 3, 4, 5})
+	b := Deque.NewDequeWithData([]string{"a", "bb", "ccc"})
+
+	got := Deque.ZipWith(a, b, func(n int, s string) string { return strings.Repeat(s, n) })
+	if s := fmt.Sprint(got); s != "[a bbbb ccccccccc]" {
+		t.Errorf("ZipWith expected [a bbbb ccccccccc], got %s", s)
+	}
+	if a.Len() != 5 || b.Len() != 3 {
+		t.Error("ZipWith should not modify its inputs")
+	}
+	// fn runs without the locks held, so it may touch the inputs
+	sums := Deque.ZipWith(a, a, func(x, y int) int { return x + y + a.Len() })
+	if s := fmt.Sprint(sums); s != "[7 9 11 13 15]" {
+		t.Errorf("ZipWith of a deque with itself expected [7 9 11 13 15], got %s", s)
+	}
+	if empty := Deque.ZipWith(a, Deque.NewDeque[string](), func(int, string) int { return 0 }); !empty.Empty() {
+		t.Error("ZipWith with an empty input should be empty")
+	}
+}
+
+func TestCompact(t *testing.T) {
+	eq := func(a, b int) bool { return a == b }
+	d := newWrappedDeque([]int{1, 1, 2, 2, 2, 3, 1, 1, 4, 4})
+	d.Compact(eq)
+	if s := fmt.Sprint(d); s != "[1 2 3 1 4]" {
+		t.Errorf("Compact expected [1 2 3 1 4], got %s", s)
+	}
+	d.PushBack(4)
+	d.PushFront(0)
+	if s := fmt.Sprint(d); s != "[0 1 2 3 1 4 4]" {
+		t.Errorf("Deque should stay usable after Compact, got %s", s)
+	}
+
+	// Runs are judged against the kept element, as in std::unique
+	near := newWrappedDeque([]int{1, 2, 3, 4, 10, 11})
+	near.Compact(func(a, b int) bool { return b-a <= 1 })
+	if s := fmt.Sprint(near); s != "[1 3 10]" {
+		t.Errorf("Compact with a tolerance expected [1 3 10], got %s", s)
+	}
+
+	for _, vals := range [][]int{{}, {7}, {5, 5, 5, 5}} {
+		d := Deque.NewDequeWithData(slices.Clone(vals))
+		d.Compact(eq)
+		if d.Len() != min(len(vals), 1) {
+			t.Errorf("Compact of %v expected length %d, got %d", vals, min(len(vals), 1), d.Len())
+		}
+	}
+}
+
+func TestCompactShrinks(t *testing.T) {
+	d := Deque.NewDeque[int](4)
+	for i := 0; i < 64; i++ {
+		d.PushBack(i / 16)
+	}
+	d.Compact(func(a, b int) bool { return a == b })
+	if s := fmt.Sprint(d); s != "[0 1 2 3]" || d.Capacity() != 4 {
+		t.Errorf("Expected [0 1 2 3] with capacity 4, got %s with capacity %d", s, d.Capacity())
+	}
+
+	// Little removed, so the capacity is kept
+	d = Deque.NewDeque[int](4)
+	for i := 0; i < 8; i++ {
+		d.PushBack(i / 2 * 2)
+		d.PushBack(i)
+	}
+	capacity := d.Capacity()
+	d.Compact(func(a, b int) bool { return a == b })
+	if d.Capacity() != capacity || d.Len() < capacity/2 {
+		t.Errorf("Expected capacity %d to be kept, got %d with %d elements", capacity, d.Capacity(), d.Len())
+	}
+}
+
+func TestFlattenSlices(t *testing.T) {
+	d := Deque.NewDequeWithData([][]int{{1, 2}, nil, {3}, {}, {4, 5, 6}})
+	flat := Deque.FlattenSlices(d)
+	if s := fmt.Sprint(flat); s != "[1 2 3 4 5 6]" {
+		t.Errorf("FlattenSlices expected [1 2 3 4 5 6], got %s", s)
+	}
+	if d.Len() != 5 {
+		t.Error("FlattenSlices should not modify its input")
+	}
+	// The result does not alias the inner slices
+	flat.Set(0, 10)
+	if inner, _ := d.At(0); inner[0] != 1 {
+		t.Error("FlattenSlices result should be independent of the inner slices")
+	}
+	if !Deque.FlattenSlices(Deque.NewDeque[[]int]()).Empty() {
+		t.Error("FlattenSlices of an empty deque should be empty")
+	}
+}
+
+func TestFlatMapSlices(t *testing.T) {
+	d := newWrappedDeque([]int{0, 1, 2, 3})
+	got := Deque.FlatMapSlices(d, func(n int) []string {
+		return slices.Repeat([]string{strconv.Itoa(n)}, n)
+	})
+	if s := fmt.Sprint(got); s != "[1 2 2 3 3 3]" {
+		t.Errorf("FlatMapSlices expected [1 2 2 3 3 3], got %s", s)
+	}
+	// fn runs outside the lock, so it may read the input
+	lens := Deque.FlatMapSlices(d, func(n int) []int { return []int{d.Len()} })
+	if s := fmt.Sprint(lens); s != "[4 4 4 4]" {
+		t.Errorf("FlatMapSlices expected [4 4 4 4], got %s", s)
+	}
+	if empty := Deque.FlatMapSlices(d, func(int) []int { return nil }); !empty.Empty() {
+		t.Error("FlatMapSlices with only nil results should be empty")
+	}
+}
+
+func TestGroupBy(t *testing.T) {
+	d := newWrappedDeque([]int{1, 2, 3, 4, 5, 6, 7})
+	groups := Deque.GroupBy(d, func(n int) int { return n % 3 })
+	if len(groups) != 3 {
+		t.Fatalf("Expected 3 groups, got %d", len(groups))
+	}
+	for k, want := range map[int]string{0: "[3 6]", 1: "[1 4 7]", 2: "[2 5]"} {
+		if s := fmt.Sprint(groups[k]); s != want {
+			t.Errorf("Group %d expected %s, got %s", k, want, s)
+		}
+	}
+	groups[1].PushBack(10)
+	if d.Len() != 7 {
+		t.Error("Groups should be independent of the input")
+	}
+
+	// Single group
+	single := Deque.GroupBy(d, func(int) string { return "all" })
+	if len(single) != 1 || !Deque.DequeEqual(single["all"], d) {
+		t.Errorf("Expected one group equal to the input, got %v", single)
+	}
+
+	// All distinct
+	distinct := Deque.GroupBy(d, func(n int) int { return n })
+	if len(distinct) != 7 || distinct[4].Len() != 1 || must(distinct[4].Front()) != 4 {
+		t.Errorf("Expected 7 singleton groups, got %v", distinct)
+	}
+
+	// Empty input
+	if empty := Deque.GroupBy(Deque.NewDeque[int](), func(n int) int { return n }); empty == nil || len(empty) != 0 {
+		t.Errorf("Expected an empty non-nil map, got %v", empty)
+	}
+}
+
+func TestGroupByKeepsLimits(t *testing.T) {
+	d := Deque.NewDequeWithData([]int{0, 1, 2, 3, 4, 5, 6, 7}, Deque.WithMaxCapacity(8))
+	evens := Deque.GroupBy(d, func(n int) bool { return n%2 == 0 })[true]
+	for i := 0; i < 4; i++ {
+		evens.PushBack(i)
+	}
+	if evens.Len() != 8 || evens.Capacity() != 8 {
+		t.Errorf("Expected 8 elements within the maximum capacity 8, got %d with capacity %d", evens.Len(), evens.Capacity())
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("Pushing past the inherited maximum capacity should panic")
+		}
+	}()
+	evens.PushBack(8)
+}
+
+func TestPushBackWithContext(t *testing.T) {
+	d := Deque.NewDequeWithData([]int{1, 2}, Deque.WithMaxCapacity(2))
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := d.PushBackWithContext(ctx, 3); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Push on a full deque expected DeadlineExceeded, got %v", err)
+	}
+	if s := fmt.Sprint(d); s != "[1 2]" {
+		t.Errorf("A timed out push should not change the deque, got %s", s)
+	}
+
+	// A pop from another goroutine frees space for the waiting push
+	go func() {
+		time.Sleep(5 * time.Millisecond)
+		d.PopFront()
+	}()
+	if err := d.PushBackWithContext(context.Background(), 3); err != nil {
+		t.Fatalf("Push should succeed once space is freed, got %v", err)
+	}
+	if s := fmt.Sprint(d); s != "[2 3]" {
+		t.Errorf("Expected [2 3], got %s", s)
+	}
+
+	// Unbounded deques never wait but still honour a done context
+	u := Deque.NewDeque[int]()
+	if err := u.PushBackWithContext(context.Background(), 1); err != nil || u.Len() != 1 {
+		t.Errorf("Push on an unbounded deque should succeed, got %v", err)
+	}
+	done, stop := context.WithCancel(context.Background())
+	stop()
+	if err := u.PushBackWithContext(done, 2); !errors.Is(err, context.Canceled) || u.Len() != 1 {
+		t.Errorf("Push with a cancelled context expected Canceled and no push, got %v", err)
+	}
+}
+
+func TestPopFrontWithContext(t *testing.T) {
+	d := Deque.NewDeque[string]()
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if v, err := d.PopFrontWithContext(ctx); !errors.Is(err, context.DeadlineExceeded) || v != "" {
+		t.Fatalf("Pop on an empty deque expected DeadlineExceeded, got (%q, %v)", v, err)
+	}
+
+	go func() {
+		time.Sleep(5 * time.Millisecond)
+		d.PushBack("a")
+	}()
+	if v, err := d.PopFrontWithContext(context.Background()); err != nil || v != "a" {
+		t.Fatalf("Pop should return the pushed element, got (%q, %v)", v, err)
+	}
+
+	d.PushBack("b")
+	done, stop := context.WithCancel(context.Background())
+	stop()
+	if _, err := d.PopFrontWithContext(done); !errors.Is(err, context.Canceled) || d.Len() != 1 {
+		t.Errorf("Pop with a cancelled context expected Canceled and no pop, got %v", err)
+	}
+}
+
+func TestContextProducerConsumer(t *testing.T) {
+	d := Deque.NewDequeWithData[int](nil, Deque.WithCapacity(4), Deque.WithMaxCapacity(4))
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	const n = 1000
+	go func() {
+		for i := 0; i < n; i++ {
+			if err := d.PushBackWithContext(ctx, i); err != nil {
+				t.Errorf("Push %d failed: %v", i, err)
+				return
+			}
+		}
+	}()
+	for i := 0; i < n; i++ {
+		v, err := d.PopFrontWithContext(ctx)
+		if err != nil || v != i {
+			t.Fatalf("Pop %d expected %d, got (%d, %v)", i, i, v, err)
+		}
+	}
+}
+
+func TestConcurrentPushPopKeepsElements(t *testing.T) {
+	d := Deque.NewDeque[int](4)
+	const producers, perProducer = 4, 2000
+	var wg sync.WaitGroup
+	for p := 0; p < producers; p++ {
+		wg.Add(1)
+		go func(p int) {
+			defer wg.Done()
+			for i := 0; i < perProducer; i++ {
+				if i%2 == 0 {
+					d.PushBack(p*perProducer + i)
+				} else {
+					d.PushFront(p*perProducer + i)
+				}
+			}
+		}(p)
+	}
+
+	var mu sync.Mutex
+	seen := make(map[int]int)
+	stop := make(chan struct{})
+	var consumers sync.WaitGroup
+	for c := 0; c < 4; c++ {
+		consumers.Add(1)
+		go func(c int) {
+			defer consumers.Done()
+			for {
+				var v int
+				var ok bool
+				if c%2 == 0 {
+					v, ok = d.PopFront()
+				} else {
+					v, ok = d.PopBack()
+				}
+				if ok {
+					mu.Lock()
+					seen[v]++
+					mu.Unlock()
+					continue
+				}
+				select {
+				case <-stop:
+					return
+				default:
+					runtime.Gosched()
+				}
+			}
+		}(c)
+	}
+	wg.Wait()
+	close(stop)
+	consumers.Wait()
+	for v, ok := d.PopFront(); ok; v, ok = d.PopFront() {
+		seen[v]++
+	}
+
+	if len(seen) != producers*perProducer {
+		t.Fatalf("Expected %d distinct elements, got %d", producers*perProducer, len(seen))
+	}
+	for v, n := range seen {
+		if n != 1 {
+			t.Fatalf("Element %d popped %d times", v, n)
+		}
+	}
+}
+
+func TestInitBounded(t *testing.T) {
+	d := Deque.NewDequeWithData[int](nil, Deque.WithMaxCapacity(4))
+	d.Init(100)
+	if d.Capacity() != 4 {
+		t.Errorf("Init(100) on a deque bounded to 4 should set capacity to 4, got %d", d.Capacity())
+	}
+	d.Init(0)
+	if d.Capacity() != 4 {
+		t.Errorf("Init(0) on a deque bounded to 4 should set capacity to 4, got %d", d.Capacity())
+	}
+	pushed := 0
+	for i := 0; i < 50; i++ {
+		if d.PushBackOrDiscard(i) {
+			pushed++
+		}
+	}
+	if pushed != 4 || d.Capacity() != 4 {
+		t.Errorf("Expected 4 pushes within capacity 4, got %d with capacity %d", pushed, d.Capacity())
+	}
+	d.Init(2)
+	if d.Capacity() != 2 {
+		t.Errorf("Init below the maximum capacity should be honoured, got %d", d.Capacity())
+	}
+}
+
+func TestContextWaitersWakeOnAnyChange(t *testing.T) {
+	d := Deque.NewDequeWithData([]int{1, 2}, Deque.WithMaxCapacity(2))
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	done := make(chan error)
+	go func() { done <- d.PushBackWithContext(ctx, 3) }()
+	time.Sleep(5 * time.Millisecond)
+	d.Clear()
+	if err := <-done; err != nil || d.Len() != 1 {
+		t.Fatalf("Clear should wake the blocked push, got %v with length %d", err, d.Len())
+	}
+
+	d.Clear()
+	pops := make(chan int)
+	go func() {
+		v, _ := d.PopFrontWithContext(ctx)
+		pops <- v
+	}()
+	time.Sleep(5 * time.Millisecond)
+	d.InsertSlice(0, []int{7, 8})
+	if v := <-pops; v != 7 {
+		t.Errorf("InsertSlice should wake the blocked pop with 7, got %d", v)
+	}
+}
+
+func BenchmarkPushPop(b *testing.B) {
+	q := Deque.NewDeque[int]()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		q.PushBack(i)
+		q.PopFront()
+	}
+}
+
+func BenchmarkConcurrentPushPop(b *testing.B) {
+	q := Deque.NewDeque[int]()
+	b.ResetTimer()
+
+	b.RunParallel(func(pb *testing.PB) {
+		r := rand.New(rand.NewSource(time.Now().UnixNano()))
+		for pb.Next() {
+			if r.Intn(2) == 0 {
+				q.PushBack(1)
+			} else {
+				q.PopFront()
+			}
+		}
+	})
+}
+
+func BenchmarkRotate(b *testing.B) {
+	q := Deque.NewDeque[int]()
+	for i := 0; i < 1000; i++ {
+		q.PushBack(i)
+	}
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		q.Rotate(1)
+	}
+}
+
+func BenchmarkBinarySearchOrdered(b *testing.B) {
+	vals := make([]int, 1<<16)
+	for i := range vals {
+		vals[i] = i * 2
+	}
+	for _, bc := range []struct {
+		name string
+		q    *Deque.Deque[int]
+	}{
+		{"contiguous", Deque.NewDequeWithData(vals)},
+		{"wrapped", newWrappedDeque(vals)},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				Deque.BinarySearchOrdered(bc.q, (i*7)%(len(vals)*2))
+			}
+		})
+	}
+}
+
+func BenchmarkSortOrdered(b *testing.B) {
+	vals := make([]int, 1<<14)
+	r := rand.New(rand.NewSource(1))
+	for i := range vals {
+		vals[i] = r.Int()
+	}
+	for _, bc := range []struct {
+		name string
+		q    *Deque.Deque[int]
+	}{
+		{"contiguous", Deque.NewDequeWithData(vals)},
+		{"wrapped", newWrappedDeque(vals)},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				for j, v := range vals {
+					bc.q.Set(j, v)
+				}
+				b.StartTimer()
+				Deque.SortOrdered(bc.q)
+			}
+		})
+	}
+}
+
+func BenchmarkAppendFromSlice(b *testing.B) {
+	src := make([]int, 1<<16)
+	b.Run("PushBack", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			q := Deque.NewDeque[int]()
+			for _, v := range src {
+				q.PushBack(v)
+			}
+		}
+	})
+	b.Run("AppendFromSlice", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			q := Deque.NewDeque[int]()
+			q.AppendFromSlice(src)
+		}
+	})
+}
+
+func BenchmarkSort(b *testing.B) {
+	src := rand.Perm(1 << 14)
+	less := func(a, b int) bool { return a < b }
+	b.Run("Sort", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			b.StopTimer()
+			q := Deque.FromSlice(src)
+			b.StartTimer()
+			q.Sort(less)
+		}
+	})
+	b.Run("ToSliceSortFromSlice", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			b.StopTimer()
+			q := Deque.FromSlice(src)
+			b.StartTimer()
+			s := q.ToSlice()
+			slices.SortFunc(s, func(a, b int) int { return a - b })
+			q = Deque.FromSlice(s)
+		}
+	})
+}
+
+func BenchmarkExtend(b *testing.B) {
+	src := Deque.NewDeque[int]()
+	for i := 0; i < 1<<14; i++ {
+		src.PushBack(i)
+	}
+	b.Run("PushBackLoop", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			q := Deque.NewDeque[int]()
+			src.ForEach(func(_ int, v int) { q.PushBack(v) })
+		}
+	})
+	b.Run("Extend", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			q := Deque.NewDeque[int]()
+			q.Extend(src)
+		}
+	})
+}
+
+func BenchmarkUnsafePushPop(b *testing.B) {
+	q := Deque.NewUnsafeDeque[int]()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		q.PushBack(i)
+		q.PopFront()
+	}
+}
+
+func BenchmarkContextProducerConsumer(b *testing.B) {
+	d := Deque.NewDequeWithData[int](nil, Deque.WithCapacity(16), Deque.WithMaxCapacity(16))
+	ctx := context.Background()
+	go func() {
+		for i := 0; i < b.N; i++ {
+			d.PushBackWithContext(ctx, i)
+		}
+	}()
+	for i := 0; i < b.N; i++ {
+		d.PopFrontWithContext(ctx)
 	}
 }
@@ -0,0 +1,131 @@
+package main_test
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"GoSTL/ExpiryMap"
+)
+
+func TestSetGetDelete(t *testing.T) {
+	m := ExpiryMap.NewExpiryMap[string, int](time.Hour)
+	defer m.Close()
+
+	m.Set("a", 1, time.Hour)
+	m.Set("b", 2, 0)
+	if v, ok := m.Get("a"); !ok || v != 1 {
+		t.Errorf("Expected (1, true), got (%d, %v)", v, ok)
+	}
+	if v, ok := m.Get("b"); !ok || v != 2 {
+		t.Errorf("Entry without TTL expected (2, true), got (%d, %v)", v, ok)
+	}
+	m.Set("a", 10, time.Hour)
+	if v, _ := m.Get("a"); v != 10 || m.Len() != 2 {
+		t.Errorf("Set should replace the value, got %d with length %d", v, m.Len())
+	}
+	if !m.Delete("a") || m.Delete("a") {
+		t.Error("Delete should succeed once")
+	}
+	if _, ok := m.Get("a"); ok || m.Len() != 1 {
+		t.Error("Deleted key should be gone")
+	}
+}
+
+func TestLazyExpiry(t *testing.T) {
+	// No background cleanup: Get alone must hide and evict expired entries
+	m := ExpiryMap.NewExpiryMap[string, string](0)
+	m.Set("k", "v", 10*time.Millisecond)
+	m.Set("forever", "v", 0)
+	time.Sleep(20 * time.Millisecond)
+
+	if _, ok := m.Get("k"); ok {
+		t.Error("Expired entry should not be returned")
+	}
+	if m.Len() != 1 {
+		t.Errorf("Get should evict the expired entry, length is %d", m.Len())
+	}
+	if m.Delete("k") {
+		t.Error("Delete of an expired entry should report false")
+	}
+	if _, ok := m.Get("forever"); !ok {
+		t.Error("Entry without TTL should never expire")
+	}
+}
+
+func TestBackgroundCleanup(t *testing.T) {
+	m := ExpiryMap.NewExpiryMap[int, int](5 * time.Millisecond)
+	defer m.Close()
+	for i := 0; i < 100; i++ {
+		m.Set(i, i, 10*time.Millisecond)
+	}
+	m.Set(-1, -1, time.Hour)
+
+	deadline := time.Now().Add(time.Second)
+	for m.Len() != 1 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if m.Len() != 1 {
+		t.Errorf("Cleanup should remove expired entries, length is %d", m.Len())
+	}
+}
+
+func TestRefreshTTL(t *testing.T) {
+	m := ExpiryMap.NewExpiryMap[string, int](0)
+	m.Set("k", 1, 10*time.Millisecond)
+	m.Set("k", 2, time.Hour) // extends the TTL
+	m.Set("p", 1, 10*time.Millisecond)
+	m.Set("p", 2, 0) // removes the TTL
+	time.Sleep(20 * time.Millisecond)
+
+	for _, k := range []string{"k", "p"} {
+		if v, ok := m.Get(k); !ok || v != 2 {
+			t.Errorf("Refreshed key %s expected (2, true), got (%d, %v)", k, v, ok)
+		}
+	}
+}
+
+func TestCloseIdempotent(t *testing.T) {
+	m := ExpiryMap.NewExpiryMap[int, int](time.Millisecond)
+	m.Close()
+	m.Close()
+	m.Set(1, 1, time.Hour)
+	if _, ok := m.Get(1); !ok {
+		t.Error("Map should remain usable after Close")
+	}
+}
+
+func TestConcurrentAccess(t *testing.T) {
+	m := ExpiryMap.NewExpiryMap[string, int](time.Millisecond)
+	defer m.Close()
+
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 500; i++ {
+				key := fmt.Sprint(i % 50)
+				m.Set(key, i, time.Duration(i%5)*time.Millisecond)
+				m.Get(key)
+				if i%7 == 0 {
+					m.Delete(key)
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+	if m.Len() > 50 {
+		t.Errorf("Expected at most 50 keys, got %d", m.Len())
+	}
+}
+
+func BenchmarkSetGet(b *testing.B) {
+	m := ExpiryMap.NewExpiryMap[int, int](time.Second)
+	defer m.Close()
+	for i := 0; i < b.N; i++ {
+		m.Set(i%1024, i, time.Minute)
+		m.Get(i % 1024)
+	}
+}
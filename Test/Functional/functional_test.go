@@ -0,0 +1,147 @@
+package main_test
+
+import (
+	"cmp"
+	"testing"
+
+	"GoSTL/Functional"
+	"GoSTL/Iterator"
+	"GoSTL/LinkedList"
+	"GoSTL/Stack"
+	"GoSTL/TreeMap"
+	"GoSTL/Tuple"
+)
+
+func ints(n int) Iterator.Iterator[int] {
+	s := make([]int, n)
+	for i := range s {
+		s[i] = i
+	}
+	return Iterator.FromSlice(s)
+}
+
+func equal[T comparable](a, b []T) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestMap(t *testing.T) {
+	got := Functional.Map(ints(4), func(v int) int { return v * v })
+	if expected := []int{0, 1, 4, 9}; !equal(got, expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+	if got := Functional.Map(ints(0), func(v int) int { return v }); len(got) != 0 {
+		t.Errorf("Map over empty iterator should be empty, got %v", got)
+	}
+}
+
+func TestFilter(t *testing.T) {
+	got := Functional.Filter(ints(10), func(v int) bool { return v%3 == 0 })
+	if expected := []int{0, 3, 6, 9}; !equal(got, expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+}
+
+func TestReduce(t *testing.T) {
+	sum := Functional.Reduce(ints(101), 0, func(acc, v int) int { return acc + v })
+	if sum != 5050 {
+		t.Errorf("Expected 5050, got %d", sum)
+	}
+
+	// Test accumulator of a different type
+	s := Functional.Reduce(ints(3), "", func(acc string, v int) string { return acc + string(rune('a'+v)) })
+	if s != "abc" {
+		t.Errorf("Expected %q, got %q", "abc", s)
+	}
+}
+
+func TestForEach(t *testing.T) {
+	var seen []int
+	Functional.ForEach(ints(3), func(v int) { seen = append(seen, v) })
+	if expected := []int{0, 1, 2}; !equal(seen, expected) {
+		t.Errorf("Expected %v, got %v", expected, seen)
+	}
+}
+
+func TestZip(t *testing.T) {
+	it := Functional.Zip(ints(5), Iterator.FromSlice([]string{"a", "b", "c"}))
+	count := 0
+	for it.Next() {
+		p := it.Value()
+		if p.First != count || p.Second != string(rune('a'+count)) {
+			t.Errorf("Pair %d unexpected: %v", count, p)
+		}
+		count++
+	}
+	if count != 3 {
+		t.Errorf("Zip should stop at the shorter input, got %d pairs", count)
+	}
+}
+
+func TestTakeDrop(t *testing.T) {
+	if got := Functional.Take(ints(10), 3); !equal(got, []int{0, 1, 2}) {
+		t.Errorf("Take(3) expected [0 1 2], got %v", got)
+	}
+	if got := Functional.Take(ints(2), 5); !equal(got, []int{0, 1}) {
+		t.Errorf("Take(5) on 2 elements expected [0 1], got %v", got)
+	}
+	if got := Functional.Take(ints(2), 0); len(got) != 0 {
+		t.Errorf("Take(0) should be empty, got %v", got)
+	}
+
+	rest := Functional.Take(Functional.Drop(ints(10), 7), 10)
+	if !equal(rest, []int{7, 8, 9}) {
+		t.Errorf("Drop(7) expected [7 8 9], got %v", rest)
+	}
+	if Functional.Drop(ints(3), 5).Next() {
+		t.Error("Drop past the end should yield nothing")
+	}
+}
+
+func TestContainers(t *testing.T) {
+	st := Stack.FromSlice([]int{1, 2, 3, 4}) // 4 is on top
+	if got := Functional.Map(Iterator.FromSeq(st.All()), func(v int) int { return v * 10 }); !equal(got, []int{40, 30, 20, 10}) {
+		t.Errorf("Map over a Stack expected top to bottom [40 30 20 10], got %v", got)
+	}
+	if st.Len() != 4 {
+		t.Errorf("Iterating should leave the Stack unchanged, got %d elements", st.Len())
+	}
+
+	l := LinkedList.NewLinkedList[int]()
+	for i := 0; i < 10; i++ {
+		l.PushBack(i)
+	}
+	if got := Functional.Filter(Iterator.FromSeq(l.All()), func(v int) bool { return v%4 == 0 }); !equal(got, []int{0, 4, 8}) {
+		t.Errorf("Filter over a LinkedList expected [0 4 8], got %v", got)
+	}
+	// Take leaves the rest of the list unvisited; the abandoned sequence holds no lock
+	if got := Functional.Take(Functional.Drop(Iterator.FromSeq(l.All()), 2), 3); !equal(got, []int{2, 3, 4}) {
+		t.Errorf("Take(Drop) over a LinkedList expected [2 3 4], got %v", got)
+	}
+	l.PushBack(10)
+
+	m := TreeMap.NewTreeMap[string, int](cmp.Compare[string])
+	for i, k := range []string{"c", "a", "b"} {
+		m.Put(k, i)
+	}
+	total := Functional.Reduce(Iterator.FromSeq2(m.All()), "", func(acc string, e Tuple.KeyValue[string, int]) string {
+		return acc + e.Key
+	})
+	if total != "abc" {
+		t.Errorf("Reduce over a TreeMap expected keys in order abc, got %q", total)
+	}
+
+	zipped := Functional.Zip(Iterator.FromSeq(st.All()), Iterator.FromSeq(l.All()))
+	var sums []int
+	Functional.ForEach(zipped, func(p Tuple.Pair[int, int]) { sums = append(sums, p.First+p.Second) })
+	if !equal(sums, []int{4, 4, 4, 4}) {
+		t.Errorf("Zip of a Stack and a LinkedList expected [4 4 4 4], got %v", sums)
+	}
+}
@@ -0,0 +1,140 @@
+package main_test
+
+import (
+	"math/rand"
+	"slices"
+	"sync"
+	"testing"
+
+	"GoSTL/HashMap"
+)
+
+func TestPutGetDelete(t *testing.T) {
+	m := HashMap.NewHashMap[string, int]()
+	m.Put("a", 1)
+	m.Put("b", 2)
+	m.Put("a", 10)
+	if v, ok := m.Get("a"); !ok || v != 10 || m.Len() != 2 {
+		t.Errorf("Expected (10, true) with 2 entries, got (%d, %v) with %d", v, ok, m.Len())
+	}
+	if _, ok := m.Get("missing"); ok || m.ContainsKey("missing") {
+		t.Error("Missing key should not be found")
+	}
+	if !m.Delete("a") || m.Delete("a") || m.ContainsKey("a") {
+		t.Error("Delete should succeed exactly once")
+	}
+	if !m.ContainsKey("b") || m.Len() != 1 {
+		t.Errorf("Expected only b to remain, got %d entries", m.Len())
+	}
+}
+
+func TestAgainstBuiltinMap(t *testing.T) {
+	m := HashMap.NewHashMap[int, int](4)
+	ref := make(map[int]int)
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 50000; i++ {
+		key := r.Intn(2000)
+		switch r.Intn(3) {
+		case 0, 1:
+			m.Put(key, i)
+			ref[key] = i
+		case 2:
+			_, present := ref[key]
+			if m.Delete(key) != present {
+				t.Fatalf("Step %d: Delete(%d) disagrees with the reference", i, key)
+			}
+			delete(ref, key)
+		}
+	}
+	if m.Len() != len(ref) {
+		t.Fatalf("Expected %d entries, got %d", len(ref), m.Len())
+	}
+	for key := 0; key < 2000; key++ {
+		v, ok := m.Get(key)
+		want, present := ref[key]
+		if ok != present || v != want {
+			t.Fatalf("Get(%d) expected (%d, %v), got (%d, %v)", key, want, present, v, ok)
+		}
+	}
+}
+
+func TestKeysValuesEntries(t *testing.T) {
+	m := HashMap.NewHashMap[int, string]()
+	for i := 0; i < 100; i++ {
+		m.Put(i, string(rune('a'+i%26)))
+	}
+	keys := m.Keys()
+	slices.Sort(keys)
+	if len(keys) != 100 || keys[0] != 0 || keys[99] != 99 {
+		t.Errorf("Keys returned %v", keys)
+	}
+	if len(m.Values()) != 100 {
+		t.Errorf("Expected 100 values, got %d", len(m.Values()))
+	}
+	for _, e := range m.Entries() {
+		if e.Value != string(rune('a'+e.Key%26)) {
+			t.Errorf("Entry %d has value %s", e.Key, e.Value)
+		}
+	}
+	visited := 0
+	m.ForEach(func(k int, v string) {
+		visited++
+		m.Delete(k)
+	})
+	if visited != 100 || m.Len() != 0 {
+		t.Errorf("ForEach should visit every entry of a snapshot, visited %d", visited)
+	}
+}
+
+func TestCopyClear(t *testing.T) {
+	m := HashMap.NewHashMap[int, int]()
+	for i := 0; i < 20; i++ {
+		m.Put(i, i*i)
+	}
+	c := m.Copy()
+	m.Clear()
+	c.Put(100, 1)
+	if m.Len() != 0 || m.ContainsKey(3) {
+		t.Error("Clear should remove every entry")
+	}
+	if v, ok := c.Get(4); !ok || v != 16 || c.Len() != 21 {
+		t.Error("Copy should be independent of the original")
+	}
+	m.Put(1, 1)
+	if v, _ := m.Get(1); v != 1 || m.Len() != 1 {
+		t.Error("Map should stay usable after Clear")
+	}
+}
+
+func TestConcurrentAccess(t *testing.T) {
+	m := HashMap.NewHashMap[int, int]()
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				key := g*1000 + i
+				m.Put(key, key)
+				if v, ok := m.Get(key); !ok || v != key {
+					t.Errorf("Get(%d) returned (%d, %v)", key, v, ok)
+				}
+				if i%2 == 1 {
+					m.Delete(key)
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+	if m.Len() != 4000 {
+		t.Errorf("Expected 4000 entries, got %d", m.Len())
+	}
+}
+
+func BenchmarkPutGet(b *testing.B) {
+	m := HashMap.NewHashMap[int, int]()
+	for i := 0; i < b.N; i++ {
+		m.Put(i&0xffff, i)
+		m.Get(i & 0x7fff)
+	}
+}
@@ -0,0 +1,130 @@
+package main_test
+
+import (
+	"slices"
+	"sync"
+	"testing"
+
+	"GoSTL/HashSet"
+)
+
+// setOf creates a set holding vals.
+func setOf(vals ...int) *HashSet.HashSet[int] {
+	s := HashSet.NewHashSet[int]()
+	for _, v := range vals {
+		s.Add(v)
+	}
+	return s
+}
+
+// rangeSet creates a set holding lo, lo+step, ... below hi.
+func rangeSet(lo, hi, step int) *HashSet.HashSet[int] {
+	s := HashSet.NewHashSet[int]()
+	for v := lo; v < hi; v += step {
+		s.Add(v)
+	}
+	return s
+}
+
+// sorted returns the elements of s in ascending order.
+func sorted(s *HashSet.HashSet[int]) []int {
+	vals := s.ToSlice()
+	slices.Sort(vals)
+	return vals
+}
+
+func TestAddRemoveContains(t *testing.T) {
+	s := setOf(1, 2, 2, 3)
+	if s.Len() != 3 || !s.Contains(2) || s.Contains(4) {
+		t.Fatalf("Unexpected set %v", sorted(s))
+	}
+	if !s.Remove(2) || s.Remove(2) || s.Contains(2) {
+		t.Error("Remove should succeed exactly once")
+	}
+	c := s.Copy()
+	s.Clear()
+	if s.Len() != 0 || !slices.Equal(sorted(c), []int{1, 3}) {
+		t.Error("Copy should be independent of the original")
+	}
+	var seen []int
+	c.ForEach(func(v int) {
+		seen = append(seen, v)
+		c.Remove(v)
+	})
+	if len(seen) != 2 || c.Len() != 0 {
+		t.Errorf("ForEach should visit a snapshot, visited %v", seen)
+	}
+}
+
+func TestSetOperations(t *testing.T) {
+	large := rangeSet(0, 10000, 1)
+	evens := rangeSet(0, 20000, 2)
+	cases := []struct {
+		name               string
+		a, b               *HashSet.HashSet[int]
+		union, inter, diff int
+		subset             bool
+	}{
+		{"empty/empty", setOf(), setOf(), 0, 0, 0, true},
+		{"empty/singleton", setOf(), setOf(1), 1, 0, 0, true},
+		{"singleton/empty", setOf(1), setOf(), 1, 0, 1, false},
+		{"singleton/same", setOf(1), setOf(1), 1, 1, 0, true},
+		{"singleton/other", setOf(1), setOf(2), 2, 0, 1, false},
+		{"singleton/large", setOf(42), large, 10000, 1, 0, true},
+		{"large/empty", large, setOf(), 10000, 0, 10000, false},
+		{"large/evens", large, evens, 15000, 5000, 5000, false},
+		{"large/self", large, large, 10000, 10000, 0, true},
+	}
+	for _, c := range cases {
+		if n := c.a.Union(c.b).Len(); n != c.union {
+			t.Errorf("%s: Union expected %d elements, got %d", c.name, c.union, n)
+		}
+		if n := c.a.Intersection(c.b).Len(); n != c.inter {
+			t.Errorf("%s: Intersection expected %d elements, got %d", c.name, c.inter, n)
+		}
+		if n := c.a.Difference(c.b).Len(); n != c.diff {
+			t.Errorf("%s: Difference expected %d elements, got %d", c.name, c.diff, n)
+		}
+		if c.a.IsSubset(c.b) != c.subset {
+			t.Errorf("%s: IsSubset expected %v", c.name, c.subset)
+		}
+	}
+
+	a, b := setOf(1, 2, 3, 4), setOf(3, 4, 5)
+	if got := sorted(a.Union(b)); !slices.Equal(got, []int{1, 2, 3, 4, 5}) {
+		t.Errorf("Union expected [1 2 3 4 5], got %v", got)
+	}
+	if got := sorted(a.Intersection(b)); !slices.Equal(got, []int{3, 4}) {
+		t.Errorf("Intersection expected [3 4], got %v", got)
+	}
+	if got := sorted(a.Difference(b)); !slices.Equal(got, []int{1, 2}) {
+		t.Errorf("Difference expected [1 2], got %v", got)
+	}
+	if got := sorted(a); !slices.Equal(got, []int{1, 2, 3, 4}) {
+		t.Errorf("Set operations should not modify their operands, got %v", got)
+	}
+	if diff := evens.Difference(large); diff.Contains(9998) || !diff.Contains(10000) || diff.Len() != 5000 {
+		t.Error("Difference of evens and large should hold the evens from 10000")
+	}
+}
+
+func TestConcurrentAdd(t *testing.T) {
+	s := HashSet.NewHashSet[int]()
+	other := rangeSet(0, 100, 1)
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 500; i++ {
+				s.Add(i)
+				s.Union(other)
+				s.IsSubset(other)
+			}
+		}(g)
+	}
+	wg.Wait()
+	if s.Len() != 500 {
+		t.Errorf("Expected 500 elements, got %d", s.Len())
+	}
+}
@@ -0,0 +1,111 @@
+package main_test
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"GoSTL/Heap"
+)
+
+func intLess(a, b int) bool { return a < b }
+
+// drain pops every element of h in order.
+func drain(h *Heap.Heap[int]) []int {
+	var out []int
+	for v, ok := h.Pop(); ok; v, ok = h.Pop() {
+		out = append(out, v)
+	}
+	return out
+}
+
+func TestPushPop(t *testing.T) {
+	h := Heap.NewHeap(intLess)
+	if _, ok := h.Pop(); ok || !h.Empty() {
+		t.Error("New heap should be empty")
+	}
+	vals := rand.Perm(100)
+	for _, v := range vals {
+		h.Push(v)
+	}
+	if v, _ := h.Peek(); v != 0 || h.Len() != 100 {
+		t.Errorf("Expected peek 0 and length 100, got %d and %d", v, h.Len())
+	}
+	if got := drain(h); !slices.IsSorted(got) || len(got) != 100 {
+		t.Errorf("Pops should come out sorted, got %v", got)
+	}
+
+	// A max-heap via the less function
+	mh := Heap.NewHeap(func(a, b int) bool { return a > b })
+	for _, v := range []int{3, 9, 1} {
+		mh.Push(v)
+	}
+	if v, _ := mh.Pop(); v != 9 {
+		t.Errorf("Max-heap expected 9, got %d", v)
+	}
+	mh.Clear()
+	if !mh.Empty() {
+		t.Error("Clear should empty the heap")
+	}
+}
+
+func TestBuildHeap(t *testing.T) {
+	data := rand.Perm(1000)
+	h := Heap.BuildHeap(data, intLess)
+	if h.Len() != 1000 {
+		t.Fatalf("Expected length 1000, got %d", h.Len())
+	}
+	got := drain(h)
+	if !slices.IsSorted(got) {
+		t.Error("BuildHeap should produce a valid heap")
+	}
+	if Heap.BuildHeap([]int(nil), intLess).Len() != 0 {
+		t.Error("BuildHeap of nil should be empty")
+	}
+}
+
+func TestMergeHeaps(t *testing.T) {
+	a := Heap.BuildHeap([]int{5, 1, 9}, intLess)
+	b := Heap.BuildHeap([]int{4, 8, 0, 7}, intLess)
+	var m *Heap.MinHeap[int] = Heap.MergeHeaps(a, b)
+	if a.Len() != 3 || b.Len() != 4 {
+		t.Error("MergeHeaps should not modify its inputs")
+	}
+	if s := fmt.Sprint(drain(m)); s != "[0 1 4 5 7 8 9]" {
+		t.Errorf("Expected [0 1 4 5 7 8 9], got %s", s)
+	}
+
+	self := Heap.MergeHeaps(a, a)
+	if s := fmt.Sprint(drain(self)); s != "[1 1 5 5 9 9]" {
+		t.Errorf("Self-merge expected [1 1 5 5 9 9], got %s", s)
+	}
+	empty := Heap.MergeHeaps(Heap.NewHeap(intLess), Heap.NewHeap(intLess))
+	if !empty.Empty() {
+		t.Error("Merging empty heaps should be empty")
+	}
+}
+
+func BenchmarkMerge(b *testing.B) {
+	for _, n := range []int{10000, 1000000} {
+		x := Heap.BuildHeap(rand.Perm(n), intLess)
+		yVals := rand.Perm(n)
+		y := Heap.BuildHeap(slices.Clone(yVals), intLess)
+
+		b.Run(fmt.Sprintf("MergeHeaps/n=%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				Heap.MergeHeaps(x, y)
+			}
+		})
+		b.Run(fmt.Sprintf("RepeatedPush/n=%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				h := Heap.MergeHeaps(x, Heap.NewHeap(intLess))
+				b.StartTimer()
+				for _, v := range yVals {
+					h.Push(v)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,200 @@
+package main_test
+
+import (
+	"fmt"
+	"math/rand"
+	"sync"
+	"testing"
+
+	"GoSTL/HopscotchMap"
+)
+
+func TestPutGetDelete(t *testing.T) {
+	m := HopscotchMap.NewHopscotchMap[string, int](0)
+	if !m.Put("a", 1) || !m.Put("b", 2) {
+		t.Error("Put of new keys should return true")
+	}
+	if m.Put("a", 10) {
+		t.Error("Put of an existing key should return false")
+	}
+	if v, ok := m.Get("a"); !ok || v != 10 {
+		t.Errorf("Expected (10, true), got (%d, %v)", v, ok)
+	}
+	if _, ok := m.Get("missing"); ok {
+		t.Error("Missing key should not be found")
+	}
+	if !m.Delete("a") || m.Delete("a") {
+		t.Error("Delete should succeed exactly once")
+	}
+	if m.Len() != 1 {
+		t.Errorf("Expected length 1, got %d", m.Len())
+	}
+}
+
+func TestAgainstBuiltinMap(t *testing.T) {
+	m := HopscotchMap.NewHopscotchMap[int, int](16)
+	ref := map[int]int{}
+	r := rand.New(rand.NewSource(1))
+
+	for i := 0; i < 200000; i++ {
+		k := r.Intn(20000)
+		switch r.Intn(3) {
+		case 0, 1:
+			_, existed := ref[k]
+			if m.Put(k, i) == existed {
+				t.Fatalf("Put(%d) result mismatch", k)
+			}
+			ref[k] = i
+		case 2:
+			_, existed := ref[k]
+			if m.Delete(k) != existed {
+				t.Fatalf("Delete(%d) result mismatch", k)
+			}
+			delete(ref, k)
+		}
+	}
+
+	if m.Len() != len(ref) {
+		t.Fatalf("Expected length %d, got %d", len(ref), m.Len())
+	}
+	for k, want := range ref {
+		if v, ok := m.Get(k); !ok || v != want {
+			t.Fatalf("Get(%d) expected (%d, true), got (%d, %v)", k, want, v, ok)
+		}
+	}
+	seen := 0
+	for k, v := range m.All() {
+		if ref[k] != v {
+			t.Fatalf("All yielded %d=%d, expected %d", k, v, ref[k])
+		}
+		seen++
+	}
+	if seen != len(ref) {
+		t.Errorf("All yielded %d entries, expected %d", seen, len(ref))
+	}
+	if lf := m.LoadFactor(); lf <= 0 || lf > 0.9 {
+		t.Errorf("Load factor %v out of range", lf)
+	}
+}
+
+func TestHighLoad(t *testing.T) {
+	// Fill to just under the resize threshold and make sure everything stays reachable
+	m := HopscotchMap.NewHopscotchMap[int, int](1 << 12)
+	n := (1 << 13) * 89 / 100
+	for i := 0; i < n; i++ {
+		m.Put(i*7919, i)
+	}
+	for i := 0; i < n; i++ {
+		if v, ok := m.Get(i * 7919); !ok || v != i {
+			t.Fatalf("Get(%d) expected (%d, true), got (%d, %v)", i*7919, i, v, ok)
+		}
+	}
+}
+
+func TestAllEarlyBreak(t *testing.T) {
+	m := HopscotchMap.NewHopscotchMap[int, int](0)
+	for i := 0; i < 10; i++ {
+		m.Put(i, i)
+	}
+	count := 0
+	for k := range m.All() {
+		m.Delete(k) // modifying the map while iterating must not deadlock
+		count++
+		if count == 3 {
+			break
+		}
+	}
+	if count != 3 || m.Len() != 7 {
+		t.Errorf("Expected 3 iterations and 7 remaining entries, got %d and %d", count, m.Len())
+	}
+}
+
+func TestConcurrentAccess(t *testing.T) {
+	m := HopscotchMap.NewHopscotchMap[int, int](0)
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				k := g*1000 + i
+				m.Put(k, k)
+				if v, ok := m.Get(k); !ok || v != k {
+					t.Errorf("Get(%d) failed", k)
+					return
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+	if m.Len() != 8000 {
+		t.Errorf("Expected 8000 entries, got %d", m.Len())
+	}
+}
+
+// Lookup benchmarks at several load factors of a 64Ki-bucket table.
+var loadFactors = []float64{0.5, 0.75, 0.85}
+
+const tableSize = 1 << 16
+
+func BenchmarkGet(b *testing.B) {
+	for _, lf := range loadFactors {
+		n := int(tableSize * lf)
+
+		hm := HopscotchMap.NewHopscotchMap[int, int](tableSize * 9 / 10)
+		std := make(map[int]int, n)
+		var sm sync.Map
+		for i := 0; i < n; i++ {
+			hm.Put(i, i)
+			std[i] = i
+			sm.Store(i, i)
+		}
+
+		b.Run(fmt.Sprintf("Hopscotch/lf=%.2f", lf), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				hm.Get(i % n)
+			}
+		})
+		b.Run(fmt.Sprintf("map/lf=%.2f", lf), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				_ = std[i%n]
+			}
+		})
+		b.Run(fmt.Sprintf("sync.Map/lf=%.2f", lf), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				sm.Load(i % n)
+			}
+		})
+	}
+}
+
+func BenchmarkPut(b *testing.B) {
+	for _, lf := range loadFactors {
+		n := int(tableSize * lf)
+
+		b.Run(fmt.Sprintf("Hopscotch/lf=%.2f", lf), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				hm := HopscotchMap.NewHopscotchMap[int, int](tableSize * 9 / 10)
+				for k := 0; k < n; k++ {
+					hm.Put(k, k)
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("map/lf=%.2f", lf), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				std := make(map[int]int, n)
+				for k := 0; k < n; k++ {
+					std[k] = k
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("sync.Map/lf=%.2f", lf), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				var sm sync.Map
+				for k := 0; k < n; k++ {
+					sm.Store(k, k)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,150 @@
+package main_test
+
+import (
+	"maps"
+	"runtime"
+	"slices"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"GoSTL/Iterator"
+)
+
+func collect[T any](it Iterator.Iterator[T]) []T {
+	var out []T
+	for it.Next() {
+		out = append(out, it.Value())
+	}
+	return out
+}
+
+func TestFromSlice(t *testing.T) {
+	it := Iterator.FromSlice([]int{10, 20, 30})
+	for i := 0; i < 3; i++ {
+		if !it.Next() {
+			t.Fatalf("Next() returned false at index %d", i)
+		}
+		if it.Index() != i || it.Value() != (i+1)*10 {
+			t.Errorf("Expected (%d, %d), got (%d, %d)", i, (i+1)*10, it.Index(), it.Value())
+		}
+	}
+	if it.Next() {
+		t.Error("Next() should return false after the last element")
+	}
+	if it.Next() {
+		t.Error("Next() should keep returning false once exhausted")
+	}
+
+	// Test empty slice
+	if Iterator.FromSlice[int](nil).Next() {
+		t.Error("Next() on empty iterator should return false")
+	}
+}
+
+func TestMapIter(t *testing.T) {
+	got := collect(Iterator.MapIter(Iterator.FromSlice([]int{1, 2, 3}), strconv.Itoa))
+	expected := []string{"1", "2", "3"}
+	if len(got) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, got)
+	}
+	for i := range expected {
+		if got[i] != expected[i] {
+			t.Errorf("Index %d expected %q, got %q", i, expected[i], got[i])
+		}
+	}
+}
+
+func TestFilterIter(t *testing.T) {
+	even := func(v int) bool { return v%2 == 0 }
+	got := collect(Iterator.FilterIter(Iterator.FromSlice([]int{1, 2, 3, 4, 5, 6}), even))
+	expected := []int{2, 4, 6}
+	if len(got) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, got)
+	}
+	for i := range expected {
+		if got[i] != expected[i] {
+			t.Errorf("Index %d expected %d, got %d", i, expected[i], got[i])
+		}
+	}
+
+	// Test no matches
+	if got := collect(Iterator.FilterIter(Iterator.FromSlice([]int{1, 3}), even)); len(got) != 0 {
+		t.Errorf("Expected no elements, got %v", got)
+	}
+}
+
+func TestZipIter(t *testing.T) {
+	got := collect(Iterator.ZipIter(Iterator.FromSlice([]int{1, 2, 3}), Iterator.FromSlice([]string{"a", "b"})))
+	if len(got) != 2 {
+		t.Fatalf("Zip should stop at the shorter input, got %d pairs", len(got))
+	}
+	if got[0].First != 1 || got[0].Second != "a" || got[1].First != 2 || got[1].Second != "b" {
+		t.Errorf("Unexpected pairs: %v", got)
+	}
+}
+
+func TestChainIter(t *testing.T) {
+	got := collect(Iterator.ChainIter(
+		Iterator.FromSlice([]int{1, 2}),
+		Iterator.FromSlice[int](nil),
+		Iterator.FromSlice([]int{3}),
+	))
+	expected := []int{1, 2, 3}
+	if len(got) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, got)
+	}
+	for i := range expected {
+		if got[i] != expected[i] {
+			t.Errorf("Index %d expected %d, got %d", i, expected[i], got[i])
+		}
+	}
+
+	if Iterator.ChainIter[int]().Next() {
+		t.Error("Empty chain should yield nothing")
+	}
+}
+
+func TestFromSeq(t *testing.T) {
+	it := Iterator.FromSeq(slices.Values([]int{10, 20, 30}))
+	if got := collect(it); !slices.Equal(got, []int{10, 20, 30}) {
+		t.Errorf("Expected [10 20 30], got %v", got)
+	}
+	if it.Next() {
+		t.Error("Next() should keep returning false once exhausted")
+	}
+	if Iterator.FromSeq(slices.Values([]int(nil))).Next() {
+		t.Error("Next() on an empty sequence should return false")
+	}
+
+	entries := collect(Iterator.FromSeq2(maps.All(map[string]int{"a": 1})))
+	if len(entries) != 1 || entries[0].Key != "a" || entries[0].Value != 1 {
+		t.Errorf("Expected one entry a=1, got %v", entries)
+	}
+}
+
+func TestFromSeqAbandoned(t *testing.T) {
+	var stopped atomic.Bool
+	it := Iterator.FromSeq(func(yield func(int) bool) {
+		defer stopped.Store(true)
+		for i := 0; ; i++ {
+			if !yield(i) {
+				return
+			}
+		}
+	})
+	if !it.Next() || !it.Next() || it.Value() != 1 {
+		t.Fatal("Expected the first two elements of an infinite sequence")
+	}
+
+	// Dropping the iterator must stop the sequence rather than leave it suspended forever
+	it = nil
+	for i := 0; i < 100 && !stopped.Load(); i++ {
+		runtime.GC()
+		time.Sleep(time.Millisecond)
+	}
+	if !stopped.Load() {
+		t.Error("An abandoned iterator should stop its sequence once collected")
+	}
+}
@@ -0,0 +1,305 @@
+package main_test
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"sync"
+	"testing"
+
+	"GoSTL/LinkedList"
+)
+
+func TestPushPop(t *testing.T) {
+	l := LinkedList.NewLinkedList[int]()
+	if _, ok := l.PopFront(); ok || l.Front() != nil || l.Back() != nil {
+		t.Fatal("Empty list should have no elements")
+	}
+	for i := 1; i <= 3; i++ {
+		l.PushBack(i)
+		l.PushFront(-i)
+	}
+	if got := l.ToSlice(); !slices.Equal(got, []int{-3, -2, -1, 1, 2, 3}) {
+		t.Fatalf("Unexpected order %v", got)
+	}
+	if v, ok := l.PopFront(); !ok || v != -3 {
+		t.Errorf("PopFront expected -3, got %d", v)
+	}
+	if v, ok := l.PopBack(); !ok || v != 3 {
+		t.Errorf("PopBack expected 3, got %d", v)
+	}
+	if l.Len() != 4 || l.Front().Value != -2 || l.Back().Value != 2 {
+		t.Errorf("Unexpected state %v", l)
+	}
+}
+
+func TestInsertRemove(t *testing.T) {
+	l := LinkedList.NewLinkedList[string]()
+	b := l.PushBack("b")
+	l.InsertBefore(b, "a")
+	d := l.InsertAfter(b, "d")
+	l.InsertBefore(d, "c")
+	if got := fmt.Sprint(l); got != "[a b c d]" {
+		t.Fatalf("Expected [a b c d], got %s", got)
+	}
+	if !l.Remove(b) || l.Remove(b) {
+		t.Error("Remove should succeed exactly once")
+	}
+	if b.Next() != nil || b.Prev() != nil {
+		t.Error("Removed node should be detached")
+	}
+	if l.InsertAfter(b, "x") != nil {
+		t.Error("InsertAfter on a detached node should fail")
+	}
+
+	other := LinkedList.NewLinkedList[string]()
+	if other.Remove(d) || other.InsertBefore(d, "x") != nil {
+		t.Error("Nodes from another list should be rejected")
+	}
+
+	var walked []string
+	for n := l.Front(); n != nil; n = n.Next() {
+		walked = append(walked, n.Value)
+	}
+	for n := l.Back(); n != nil; n = n.Prev() {
+		walked = append(walked, n.Value)
+	}
+	if !slices.Equal(walked, []string{"a", "c", "d", "d", "c", "a"}) {
+		t.Errorf("Unexpected walk %v", walked)
+	}
+}
+
+func TestReverseCopyClear(t *testing.T) {
+	l := LinkedList.NewLinkedList[int]()
+	l.Reverse()
+	nodes := make([]*LinkedList.Node[int], 5)
+	for i := range nodes {
+		nodes[i] = l.PushBack(i)
+	}
+	c := l.Copy()
+	l.Reverse()
+	if got := l.ToSlice(); !slices.Equal(got, []int{4, 3, 2, 1, 0}) {
+		t.Fatalf("Expected reversed order, got %v", got)
+	}
+	if nodes[2].Next() != nodes[1] || nodes[2].Prev() != nodes[3] || l.Front() != nodes[4] {
+		t.Error("Nodes should stay valid after Reverse")
+	}
+	if got := c.ToSlice(); !slices.Equal(got, []int{0, 1, 2, 3, 4}) {
+		t.Errorf("Copy should be independent, got %v", got)
+	}
+
+	l.Clear()
+	if !l.Empty() || nodes[0].Next() != nil || l.Remove(nodes[0]) {
+		t.Error("Clear should empty the list and detach its nodes")
+	}
+	l.PushBack(7)
+	l.ForEach(func(i, v int) {
+		if i != 0 || v != 7 {
+			t.Errorf("Unexpected element %d at %d", v, i)
+		}
+	})
+}
+
+func TestSplice(t *testing.T) {
+	l := LinkedList.NewLinkedList[int]()
+	nodes := make([]*LinkedList.Node[int], 6)
+	for i := range nodes {
+		nodes[i] = l.PushBack(i)
+	}
+
+	// Within one list: move [1 2] after 4, then [4 1] to the front
+	if !l.Splice(nodes[4], nodes[1], 2) {
+		t.Fatal("Splice within a list should succeed")
+	}
+	if got := l.ToSlice(); !slices.Equal(got, []int{0, 3, 4, 1, 2, 5}) || l.Len() != 6 {
+		t.Fatalf("Expected [0 3 4 1 2 5], got %v", got)
+	}
+	if !l.Splice(nil, nodes[4], 2) {
+		t.Fatal("Splice to the front should succeed")
+	}
+	if got := l.ToSlice(); !slices.Equal(got, []int{4, 1, 0, 3, 2, 5}) {
+		t.Fatalf("Expected [4 1 0 3 2 5], got %v", got)
+	}
+	if !l.Splice(nodes[1], nodes[0], 1) || !slices.Equal(l.ToSlice(), []int{4, 1, 0, 3, 2, 5}) {
+		t.Error("Splicing a run right after its own predecessor should leave the list unchanged")
+	}
+
+	// Between lists: the nodes stay valid and change owner
+	other := LinkedList.NewLinkedList[int]()
+	x := other.PushBack(10)
+	other.PushBack(11)
+	if !other.Splice(x, nodes[0], 3) {
+		t.Fatal("Splice between lists should succeed")
+	}
+	if got := other.ToSlice(); !slices.Equal(got, []int{10, 0, 3, 2, 11}) || other.Len() != 5 {
+		t.Errorf("Expected [10 0 3 2 11], got %v", got)
+	}
+	if got := l.ToSlice(); !slices.Equal(got, []int{4, 1, 5}) || l.Len() != 3 {
+		t.Errorf("Expected [4 1 5] left behind, got %v", got)
+	}
+	if l.Remove(nodes[3]) || !other.Remove(nodes[3]) || nodes[2].Next().Value != 11 || nodes[1].Next() != nodes[5] {
+		t.Error("Spliced nodes should belong to their new list")
+	}
+
+	// Invalid splices change nothing
+	for name, ok := range map[string]bool{
+		"count past the back": l.Splice(nil, nodes[1], 3),
+		"dst inside the run":  l.Splice(nodes[1], nodes[4], 2),
+		"dst from other list": l.Splice(x, nodes[4], 1),
+		"detached src":        l.Splice(nil, nodes[3], 1),
+		"zero count":          l.Splice(nil, nodes[4], 0),
+	} {
+		if ok {
+			t.Errorf("Splice with %s should fail", name)
+		}
+	}
+	if !slices.Equal(l.ToSlice(), []int{4, 1, 5}) || !slices.Equal(other.ToSlice(), []int{10, 0, 2, 11}) {
+		t.Errorf("Failed splices should change nothing, got %v and %v", l, other)
+	}
+}
+
+func TestSpliceConcurrent(t *testing.T) {
+	// Goroutines move runs back and forth between two lists; locking both lists in a fixed order avoids deadlock
+	a, b := LinkedList.NewLinkedList[int](), LinkedList.NewLinkedList[int]()
+	for i := 0; i < 100; i++ {
+		a.PushBack(i)
+		b.PushBack(100 + i)
+	}
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			from, to := a, b
+			if g%2 == 1 {
+				from, to = b, a
+			}
+			for i := 0; i < 500; i++ {
+				if front := from.Front(); front != nil {
+					to.Splice(to.Back(), front, 1)
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+
+	all := append(a.ToSlice(), b.ToSlice()...)
+	slices.Sort(all)
+	if len(all) != 200 || a.Len()+b.Len() != 200 || all[0] != 0 || all[199] != 199 {
+		t.Errorf("Expected the 200 values to be preserved, got %d values in lists of %d and %d", len(all), a.Len(), b.Len())
+	}
+}
+
+func TestSort(t *testing.T) {
+	type pair struct{ key, seq int }
+	byKey := func(a, b pair) bool { return a.key < b.key }
+
+	for _, n := range []int{0, 1, 2, 3, 7, 64, 1000} {
+		l := LinkedList.NewLinkedList[pair]()
+		nodes := make([]*LinkedList.Node[pair], n)
+		for i := range nodes {
+			nodes[i] = l.PushBack(pair{rand.Intn(n/4 + 1), i})
+		}
+		want := l.ToSlice()
+		slices.SortStableFunc(want, func(a, b pair) int { return a.key - b.key })
+		l.Sort(byKey)
+		if got := l.ToSlice(); !slices.Equal(got, want) {
+			t.Fatalf("n=%d: expected stable order %v, got %v", n, want, got)
+		}
+		if l.Len() != n {
+			t.Fatalf("n=%d: expected length %d, got %d", n, n, l.Len())
+		}
+
+		// Walk backwards to check the prev links, and make sure every original node is still in the list
+		var back []pair
+		for node := l.Back(); node != nil; node = node.Prev() {
+			back = append(back, node.Value)
+		}
+		slices.Reverse(back)
+		if !slices.Equal(back, want) {
+			t.Fatalf("n=%d: prev links out of order: %v", n, back)
+		}
+		for _, node := range nodes {
+			if !l.Remove(node) {
+				t.Fatalf("n=%d: node %v should still belong to the list", n, node.Value)
+			}
+		}
+	}
+
+	l := LinkedList.NewLinkedList[int]()
+	for _, v := range []int{3, 1, 2} {
+		l.PushBack(v)
+	}
+	l.Sort(func(a, b int) bool { return a > b })
+	l.PushBack(0)
+	if got := l.ToSlice(); !slices.Equal(got, []int{3, 2, 1, 0}) {
+		t.Errorf("Expected descending order with a usable tail, got %v", got)
+	}
+}
+
+func TestConcurrentPush(t *testing.T) {
+	l := LinkedList.NewLinkedList[int]()
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 500; i++ {
+				if i%2 == 0 {
+					l.PushBack(g*500 + i)
+				} else {
+					l.PushFront(g*500 + i)
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+
+	got := l.ToSlice()
+	slices.Sort(got)
+	for i, v := range got {
+		if v != i {
+			t.Fatalf("Expected %d at %d, got %d", i, i, v)
+		}
+	}
+	if len(got) != 4000 {
+		t.Errorf("Expected 4000 elements, got %d", len(got))
+	}
+}
+
+func BenchmarkSort(b *testing.B) {
+	for _, n := range []int{10000, 1000000} {
+		vals := rand.Perm(n)
+		less := func(a, b int) bool { return a < b }
+		fill := func(l *LinkedList.LinkedList[int]) {
+			l.Clear()
+			for _, v := range vals {
+				l.PushBack(v)
+			}
+		}
+
+		b.Run(fmt.Sprintf("MergeSort/n=%d", n), func(b *testing.B) {
+			l := LinkedList.NewLinkedList[int]()
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				fill(l)
+				b.StartTimer()
+				l.Sort(less)
+			}
+		})
+		b.Run(fmt.Sprintf("SliceRebuild/n=%d", n), func(b *testing.B) {
+			l := LinkedList.NewLinkedList[int]()
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				fill(l)
+				b.StartTimer()
+				s := l.ToSlice()
+				slices.SortStableFunc(s, func(a, b int) int { return a - b })
+				l.Clear()
+				for _, v := range s {
+					l.PushBack(v)
+				}
+			}
+		})
+	}
+}
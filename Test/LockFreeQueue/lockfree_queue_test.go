@@ -0,0 +1,215 @@
+package main_test
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+	"testing"
+
+	"GoSTL/LockFreeQueue"
+	queue "GoSTL/Queue"
+)
+
+func TestFIFO(t *testing.T) {
+	q := LockFreeQueue.NewLockFreeQueue[int]()
+	if !q.IsEmpty() {
+		t.Error("New queue should be empty")
+	}
+	if _, ok := q.Dequeue(); ok {
+		t.Error("Dequeue on an empty queue should fail")
+	}
+	for i := 0; i < 100; i++ {
+		q.Enqueue(i)
+	}
+	for i := 0; i < 100; i++ {
+		if v, ok := q.Dequeue(); !ok || v != i {
+			t.Fatalf("Dequeue expected (%d, true), got (%d, %v)", i, v, ok)
+		}
+	}
+	if !q.IsEmpty() {
+		t.Error("Queue should be empty after dequeuing everything")
+	}
+	q.Enqueue(7)
+	if v, _ := q.Dequeue(); v != 7 {
+		t.Errorf("Queue should be reusable after draining, got %d", v)
+	}
+
+	var zero LockFreeQueue.LockFreeQueue[string]
+	if !zero.IsEmpty() {
+		t.Error("The zero value should be an empty queue")
+	}
+	zero.Enqueue("x")
+	if v, ok := zero.Dequeue(); !ok || v != "x" {
+		t.Error("The zero value should be usable")
+	}
+}
+
+// TestZeroValueConcurrentFirstUse checks that goroutines racing to use a zero-value queue install a single sentinel.
+func TestZeroValueConcurrentFirstUse(t *testing.T) {
+	for round := 0; round < 200; round++ {
+		var q LockFreeQueue.LockFreeQueue[int]
+		var wg sync.WaitGroup
+		for g := 0; g < 8; g++ {
+			wg.Add(1)
+			go func(g int) {
+				defer wg.Done()
+				q.Enqueue(g)
+			}(g)
+		}
+		wg.Wait()
+		n := 0
+		for _, ok := q.Dequeue(); ok; _, ok = q.Dequeue() {
+			n++
+		}
+		if n != 8 {
+			t.Fatalf("Round %d: expected 8 values, got %d", round, n)
+		}
+	}
+}
+
+// TestLinearizability checks the FIFO guarantees observable under contention: every value is dequeued
+// exactly once, and every consumer sees the values of each producer in the order they were enqueued.
+func TestLinearizability(t *testing.T) {
+	const producers, consumers, perProducer = 500, 500, 200
+	q := LockFreeQueue.NewLockFreeQueue[[2]int]()
+
+	var wg sync.WaitGroup
+	for p := 0; p < producers; p++ {
+		wg.Add(1)
+		go func(p int) {
+			defer wg.Done()
+			for i := 0; i < perProducer; i++ {
+				q.Enqueue([2]int{p, i})
+			}
+		}(p)
+	}
+
+	results := make([][][2]int, consumers)
+	for c := 0; c < consumers; c++ {
+		wg.Add(1)
+		go func(c int) {
+			defer wg.Done()
+			for i := 0; i < perProducer; i++ {
+				if v, ok := q.Dequeue(); ok {
+					results[c] = append(results[c], v)
+				}
+			}
+		}(c)
+	}
+	wg.Wait()
+	var rest [][2]int
+	for v, ok := q.Dequeue(); ok; v, ok = q.Dequeue() {
+		rest = append(rest, v)
+	}
+	results = append(results, rest)
+
+	seen := make([][]bool, producers)
+	for p := range seen {
+		seen[p] = make([]bool, perProducer)
+	}
+	total := 0
+	for c, dequeued := range results {
+		last := make(map[int]int)
+		for _, v := range dequeued {
+			if seen[v[0]][v[1]] {
+				t.Fatalf("Value %v dequeued twice", v)
+			}
+			seen[v[0]][v[1]] = true
+			total++
+			if prev, ok := last[v[0]]; ok && prev >= v[1] {
+				t.Fatalf("Consumer %d saw producer %d's value %d after %d", c, v[0], v[1], prev)
+			}
+			last[v[0]] = v[1]
+		}
+	}
+	if total != producers*perProducer {
+		t.Errorf("Expected %d values dequeued, got %d", producers*perProducer, total)
+	}
+}
+
+// TestNodesRecycled checks that an enqueue/dequeue cycle with no other operation in flight reuses the dequeued node.
+func TestNodesRecycled(t *testing.T) {
+	q := LockFreeQueue.NewLockFreeQueue[int]()
+	allocs := testing.AllocsPerRun(1000, func() {
+		q.Enqueue(1)
+		q.Dequeue()
+	})
+	// The race detector makes sync.Pool drop some nodes, so allow an occasional allocation
+	if allocs > 0.5 {
+		t.Errorf("Expected dequeued nodes to be recycled, got %v allocations per enqueue/dequeue", allocs)
+	}
+}
+
+// TestRecyclingUnderContention interleaves enqueues and dequeues so that nodes are recycled whenever a goroutine
+// briefly runs alone, and checks that no value is lost or duplicated and that each producer's order is kept.
+func TestRecyclingUnderContention(t *testing.T) {
+	const goroutines, perGoroutine = 8, 20000
+	q := LockFreeQueue.NewLockFreeQueue[[2]int]()
+
+	var wg sync.WaitGroup
+	dequeued := make([][][2]int, goroutines+1)
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < perGoroutine; i++ {
+				q.Enqueue([2]int{g, i})
+				if v, ok := q.Dequeue(); ok {
+					dequeued[g] = append(dequeued[g], v)
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+	for v, ok := q.Dequeue(); ok; v, ok = q.Dequeue() {
+		dequeued[goroutines] = append(dequeued[goroutines], v)
+	}
+
+	seen := make([][]bool, goroutines)
+	for g := range seen {
+		seen[g] = make([]bool, perGoroutine)
+	}
+	total := 0
+	for c, values := range dequeued {
+		last := make(map[int]int)
+		for _, v := range values {
+			if seen[v[0]][v[1]] {
+				t.Fatalf("Value %v dequeued twice", v)
+			}
+			seen[v[0]][v[1]] = true
+			total++
+			if prev, ok := last[v[0]]; ok && prev >= v[1] {
+				t.Fatalf("Consumer %d saw producer %d's value %d after %d", c, v[0], v[1], prev)
+			}
+			last[v[0]] = v[1]
+		}
+	}
+	if total != goroutines*perGoroutine {
+		t.Errorf("Expected %d values dequeued, got %d", goroutines*perGoroutine, total)
+	}
+}
+
+func BenchmarkEnqueueDequeue(b *testing.B) {
+	for _, procs := range []int{1, 4, 16} {
+		b.Run(fmt.Sprintf("LockFreeQueue/procs=%d", procs), func(b *testing.B) {
+			defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(procs))
+			q := LockFreeQueue.NewLockFreeQueue[int]()
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					q.Enqueue(1)
+					q.Dequeue()
+				}
+			})
+		})
+		b.Run(fmt.Sprintf("Queue/procs=%d", procs), func(b *testing.B) {
+			defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(procs))
+			q := queue.NewQueue[int]()
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					q.Push(1)
+					q.Pop()
+				}
+			})
+		})
+	}
+}
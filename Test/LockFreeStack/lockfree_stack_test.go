@@ -0,0 +1,173 @@
+package main_test
+
+import (
+	"sync"
+	"testing"
+
+	"GoSTL/LockFreeStack"
+	"GoSTL/Stack"
+)
+
+func TestLIFO(t *testing.T) {
+	s := LockFreeStack.NewLockFreeStack[int]()
+	if !s.IsEmpty() {
+		t.Error("New stack should be empty")
+	}
+	if _, ok := s.Pop(); ok {
+		t.Error("Pop on an empty stack should fail")
+	}
+	if _, ok := s.Peek(); ok {
+		t.Error("Peek on an empty stack should fail")
+	}
+	for i := 0; i < 100; i++ {
+		s.Push(i)
+	}
+	if v, _ := s.Peek(); v != 99 {
+		t.Errorf("Peek expected 99, got %d", v)
+	}
+	for i := 99; i >= 0; i-- {
+		if v, ok := s.Pop(); !ok || v != i {
+			t.Fatalf("Pop expected (%d, true), got (%d, %v)", i, v, ok)
+		}
+	}
+	if !s.IsEmpty() {
+		t.Error("Stack should be empty after popping everything")
+	}
+
+	var zero LockFreeStack.LockFreeStack[string]
+	zero.Push("x")
+	if v, _ := zero.Pop(); v != "x" {
+		t.Error("The zero value should be usable")
+	}
+}
+
+// TestStress checks that under heavy contention every pushed value is popped exactly once:
+// nothing is lost or duplicated by racing compare-and-swaps.
+func TestStress(t *testing.T) {
+	const producers, consumers, perProducer = 1000, 1000, 100
+	s := LockFreeStack.NewLockFreeStack[[2]int]()
+
+	var wg sync.WaitGroup
+	for p := 0; p < producers; p++ {
+		wg.Add(1)
+		go func(p int) {
+			defer wg.Done()
+			for i := 0; i < perProducer; i++ {
+				s.Push([2]int{p, i})
+			}
+		}(p)
+	}
+
+	results := make([][][2]int, consumers)
+	for c := 0; c < consumers; c++ {
+		wg.Add(1)
+		go func(c int) {
+			defer wg.Done()
+			for i := 0; i < perProducer; i++ {
+				if v, ok := s.Pop(); ok {
+					results[c] = append(results[c], v)
+				}
+			}
+		}(c)
+	}
+	wg.Wait()
+	for v, ok := s.Pop(); ok; v, ok = s.Pop() {
+		results = append(results, [][2]int{v})
+	}
+
+	seen := make([][]bool, producers)
+	for p := range seen {
+		seen[p] = make([]bool, perProducer)
+	}
+	total := 0
+	for _, popped := range results {
+		for _, v := range popped {
+			if seen[v[0]][v[1]] {
+				t.Fatalf("Value %v popped twice", v)
+			}
+			seen[v[0]][v[1]] = true
+			total++
+		}
+	}
+	if total != producers*perProducer {
+		t.Errorf("Expected %d values popped, got %d", producers*perProducer, total)
+	}
+}
+
+// TestNodesRecycled checks that a push/pop cycle with no other operation in flight reuses the popped node.
+func TestNodesRecycled(t *testing.T) {
+	s := LockFreeStack.NewLockFreeStack[int]()
+	allocs := testing.AllocsPerRun(1000, func() {
+		s.Push(1)
+		s.Pop()
+	})
+	// The race detector makes sync.Pool drop some nodes, so allow an occasional allocation
+	if allocs > 0.5 {
+		t.Errorf("Expected popped nodes to be recycled, got %v allocations per push/pop", allocs)
+	}
+}
+
+// TestRecyclingUnderContention mixes pushes, pops and peeks so that nodes are recycled whenever a goroutine
+// briefly runs alone, and checks that no value is lost, duplicated or read from a reused node.
+func TestRecyclingUnderContention(t *testing.T) {
+	const goroutines, perGoroutine = 8, 20000
+	s := LockFreeStack.NewLockFreeStack[int]()
+
+	var wg sync.WaitGroup
+	popped := make([][]int, goroutines)
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < perGoroutine; i++ {
+				s.Push(g*perGoroutine + i)
+				if v, ok := s.Peek(); ok && (v < 0 || v >= goroutines*perGoroutine) {
+					t.Errorf("Peek returned %d, which was never pushed", v)
+				}
+				if v, ok := s.Pop(); ok {
+					popped[g] = append(popped[g], v)
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+	for v, ok := s.Pop(); ok; v, ok = s.Pop() {
+		popped[0] = append(popped[0], v)
+	}
+
+	seen := make([]bool, goroutines*perGoroutine)
+	for _, values := range popped {
+		for _, v := range values {
+			if seen[v] {
+				t.Fatalf("Value %d popped twice", v)
+			}
+			seen[v] = true
+		}
+	}
+	for v, ok := range seen {
+		if !ok {
+			t.Fatalf("Value %d was lost", v)
+		}
+	}
+}
+
+func BenchmarkPushPop(b *testing.B) {
+	b.Run("LockFreeStack", func(b *testing.B) {
+		s := LockFreeStack.NewLockFreeStack[int]()
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				s.Push(1)
+				s.Pop()
+			}
+		})
+	})
+	b.Run("Stack", func(b *testing.B) {
+		s := Stack.NewStack[int]()
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				s.Push(1)
+				s.Pop()
+			}
+		})
+	})
+}
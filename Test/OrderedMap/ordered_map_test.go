@@ -0,0 +1,115 @@
+package main_test
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"sync"
+	"testing"
+
+	"GoSTL/OrderedMap"
+)
+
+func TestInsertionOrder(t *testing.T) {
+	m := OrderedMap.NewOrderedMap[string, int]()
+	for i, k := range []string{"c", "a", "d", "b"} {
+		m.Put(k, i)
+	}
+	m.Put("a", 10)
+	if got := m.Keys(); !slices.Equal(got, []string{"c", "a", "d", "b"}) {
+		t.Fatalf("Keys expected insertion order, got %v", got)
+	}
+	if got := m.Values(); !slices.Equal(got, []int{0, 10, 2, 3}) {
+		t.Errorf("Values expected [0 10 2 3], got %v", got)
+	}
+
+	if !m.Delete("a") || m.Delete("a") || m.Delete("missing") {
+		t.Error("Delete should succeed exactly once for present keys")
+	}
+	m.Put("a", 20)
+	var got []string
+	m.ForEach(func(k string, v int) {
+		got = append(got, fmt.Sprintf("%s=%d", k, v))
+	})
+	if fmt.Sprint(got) != "[c=0 d=2 b=3 a=20]" {
+		t.Errorf("A re-inserted key should move to the end, got %v", got)
+	}
+	if v, ok := m.Get("a"); !ok || v != 20 || m.Len() != 4 {
+		t.Errorf("Get(a) expected 20 with 4 entries, got %d with %d", v, m.Len())
+	}
+	if _, ok := m.Get("missing"); ok {
+		t.Error("Missing key should not be found")
+	}
+
+	m.Clear()
+	m.Put("z", 1)
+	if got := m.Entries(); len(got) != 1 || got[0].Key != "z" || got[0].Value != 1 {
+		t.Errorf("Map should stay usable after Clear, got %v", got)
+	}
+}
+
+func TestAgainstReference(t *testing.T) {
+	m := OrderedMap.NewOrderedMap[int, int]()
+	var refKeys []int
+	refVals := make(map[int]int)
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 20000; i++ {
+		key := r.Intn(300)
+		if r.Intn(2) == 0 {
+			if _, ok := refVals[key]; !ok {
+				refKeys = append(refKeys, key)
+			}
+			refVals[key] = i
+			m.Put(key, i)
+		} else {
+			_, present := refVals[key]
+			if m.Delete(key) != present {
+				t.Fatalf("Step %d: Delete(%d) disagrees with the reference", i, key)
+			}
+			if present {
+				delete(refVals, key)
+				refKeys = slices.DeleteFunc(refKeys, func(k int) bool { return k == key })
+			}
+		}
+		if i%1000 == 0 && !slices.Equal(m.Keys(), refKeys) {
+			t.Fatalf("Step %d: key order diverged from the reference", i)
+		}
+	}
+	entries := m.Entries()
+	if len(entries) != len(refKeys) {
+		t.Fatalf("Expected %d entries, got %d", len(refKeys), len(entries))
+	}
+	for i, e := range entries {
+		if e.Key != refKeys[i] || e.Value != refVals[e.Key] {
+			t.Fatalf("Entry %d expected %d=%d, got %d=%d", i, refKeys[i], refVals[refKeys[i]], e.Key, e.Value)
+		}
+	}
+}
+
+func TestConcurrentPutDelete(t *testing.T) {
+	m := OrderedMap.NewOrderedMap[int, int]()
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 500; i++ {
+				key := g*500 + i
+				m.Put(key, key)
+				if i%2 == 1 {
+					m.Delete(key - 1)
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+	keys := m.Keys()
+	if len(keys) != 2000 || m.Len() != 2000 {
+		t.Fatalf("Expected 2000 keys, got %d", len(keys))
+	}
+	for _, k := range keys {
+		if k%2 == 0 {
+			t.Fatalf("Deleted key %d still present", k)
+		}
+	}
+}
@@ -0,0 +1,263 @@
+package main_test
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"math/rand"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"GoSTL/PriorityQueue"
+)
+
+func TestMinMaxHeap(t *testing.T) {
+	vals := rand.Perm(200)
+
+	minPQ := PriorityQueue.NewMinHeap[int]()
+	maxPQ := PriorityQueue.NewMaxHeap[int](4)
+	for _, v := range vals {
+		minPQ.Push(v)
+		maxPQ.Push(v)
+	}
+	if minPQ.Len() != 200 || maxPQ.Empty() {
+		t.Fatalf("Expected 200 elements, got %d", minPQ.Len())
+	}
+	if v, _ := minPQ.Peek(); v != 0 {
+		t.Errorf("Min-heap peek expected 0, got %d", v)
+	}
+	if v, _ := maxPQ.Peek(); v != 199 {
+		t.Errorf("Max-heap peek expected 199, got %d", v)
+	}
+	for i := 0; i < 200; i++ {
+		if v, ok := minPQ.Pop(); !ok || v != i {
+			t.Fatalf("Min-heap pop expected %d, got %d", i, v)
+		}
+		if v, ok := maxPQ.Pop(); !ok || v != 199-i {
+			t.Fatalf("Max-heap pop expected %d, got %d", 199-i, v)
+		}
+	}
+	if _, ok := minPQ.Pop(); ok || !minPQ.Empty() {
+		t.Error("Pop on an empty queue should fail")
+	}
+	if _, ok := maxPQ.Peek(); ok {
+		t.Error("Peek on an empty queue should fail")
+	}
+}
+
+func TestCustomComparator(t *testing.T) {
+	type job struct {
+		name     string
+		priority int
+	}
+	pq := PriorityQueue.NewPriorityQueue(func(a, b job) int { return cmp.Compare(b.priority, a.priority) })
+	pq.Push(job{"low", 1})
+	pq.Push(job{"urgent", 10})
+	pq.Push(job{"normal", 5})
+	for _, want := range []string{"urgent", "normal", "low"} {
+		if j, _ := pq.Pop(); j.name != want {
+			t.Errorf("Expected %s, got %s", want, j.name)
+		}
+	}
+}
+
+func TestCopyClearShrink(t *testing.T) {
+	pq := PriorityQueue.NewMinHeap[int]()
+	for i := 100; i > 0; i-- {
+		pq.Push(i)
+	}
+	c := pq.Copy()
+	pq.Clear()
+	if !pq.Empty() || c.Len() != 100 {
+		t.Fatalf("Copy should be independent, got lengths %d and %d", pq.Len(), c.Len())
+	}
+	if got := c.ToSlice(); !slices.IsSorted(got) || len(got) != 100 {
+		t.Error("ToSlice should return elements in pop order")
+	}
+
+	for i := 0; i < 90; i++ {
+		c.Pop()
+	}
+	c.ShrinkToFit()
+	if c.Capacity() != 10 {
+		t.Errorf("Expected capacity 10 after ShrinkToFit, got %d", c.Capacity())
+	}
+	pq.ShrinkToFit()
+	if pq.Capacity() != 8 {
+		t.Errorf("ShrinkToFit should keep the initial capacity, got %d", pq.Capacity())
+	}
+	if v, _ := c.Pop(); v != 91 {
+		t.Errorf("Expected 91 after shrinking, got %d", v)
+	}
+}
+
+func TestConcurrentPushPop(t *testing.T) {
+	pq := PriorityQueue.NewMinHeap[int]()
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 500; i++ {
+				pq.Push(g*500 + i)
+			}
+		}(g)
+	}
+	wg.Wait()
+
+	seen := make([]bool, 4000)
+	var mu sync.Mutex
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for v, ok := pq.Pop(); ok; v, ok = pq.Pop() {
+				mu.Lock()
+				if seen[v] {
+					t.Errorf("Value %d popped twice", v)
+				}
+				seen[v] = true
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	for v, ok := range seen {
+		if !ok {
+			t.Fatalf("Value %d was never popped", v)
+		}
+	}
+}
+
+func TestConcurrentPriorityQueueHandles(t *testing.T) {
+	pq := PriorityQueue.NewConcurrentPriorityQueue(cmp.Compare[int])
+	handles := make(map[int]*PriorityQueue.Handle[int])
+	for _, v := range rand.Perm(50) {
+		handles[v] = pq.Push(v)
+	}
+	if v, ok := pq.Peek(); !ok || v != 0 || pq.Len() != 50 {
+		t.Fatalf("Expected 50 elements with 0 on top, got %d (len %d)", v, pq.Len())
+	}
+
+	// Move 40 to the top and 0 to the bottom, and drop every multiple of 10 but those two
+	if !pq.Update(handles[40], -1) || !pq.Update(handles[0], 100) {
+		t.Fatal("Update of queued elements should succeed")
+	}
+	for _, v := range []int{10, 20, 30} {
+		if got, ok := pq.Remove(handles[v]); !ok || got != v {
+			t.Fatalf("Remove expected (%d, true), got (%d, %v)", v, got, ok)
+		}
+	}
+	if _, ok := pq.Remove(handles[10]); ok || pq.Update(handles[20], 5) {
+		t.Error("Handles of removed elements should be invalid")
+	}
+
+	var got []int
+	for v, ok := pq.TryPop(); ok; v, ok = pq.TryPop() {
+		got = append(got, v)
+	}
+	if len(got) != 47 || got[0] != -1 || got[46] != 100 || !slices.IsSorted(got) {
+		t.Errorf("Expected 47 elements from -1 to 100 in order, got %v", got)
+	}
+	if pq.Update(handles[1], 0) || !pq.Empty() {
+		t.Error("Handles of popped elements should be invalid")
+	}
+	other := PriorityQueue.NewConcurrentPriorityQueue(cmp.Compare[int])
+	h := other.Push(1)
+	if _, ok := pq.Remove(h); ok || other.Len() != 1 {
+		t.Error("A handle from another queue should be rejected")
+	}
+}
+
+func TestConcurrentPriorityQueueBlockingPop(t *testing.T) {
+	pq := PriorityQueue.NewConcurrentPriorityQueue(func(a, b string) int { return cmp.Compare(a, b) })
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := pq.BlockingPop(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("BlockingPop on an empty queue should wait for ctx, got %v", err)
+	}
+
+	result := make(chan string)
+	go func() {
+		v, _ := pq.BlockingPop(context.Background())
+		result <- v
+	}()
+	time.Sleep(10 * time.Millisecond)
+	pq.Push("woken")
+	select {
+	case v := <-result:
+		if v != "woken" {
+			t.Errorf("BlockingPop expected woken, got %s", v)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("BlockingPop was not woken by Push")
+	}
+
+	done, cancelDone := context.WithCancel(context.Background())
+	cancelDone()
+	pq.Push("kept")
+	if _, err := pq.BlockingPop(done); !errors.Is(err, context.Canceled) || pq.Len() != 1 {
+		t.Errorf("BlockingPop with a done ctx should pop nothing, got %v", err)
+	}
+}
+
+func TestConcurrentPriorityQueueContention(t *testing.T) {
+	const producers, perProducer = 8, 500
+	pq := PriorityQueue.NewConcurrentPriorityQueue(cmp.Compare[int])
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	popped := make(chan int, producers*perProducer)
+	for g := 0; g < producers; g++ {
+		wg.Add(2)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < perProducer; i++ {
+				h := pq.Push(g*perProducer + i)
+				pq.Peek()
+				if i%2 == 0 {
+					pq.Update(h, g*perProducer+i) // a no-op move that must keep the heap valid
+				}
+			}
+		}(g)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < perProducer; i++ {
+				v, err := pq.BlockingPop(ctx)
+				if err != nil {
+					t.Errorf("BlockingPop failed: %v", err)
+					return
+				}
+				popped <- v
+			}
+		}()
+	}
+	wg.Wait()
+	close(popped)
+
+	seen := make([]bool, producers*perProducer)
+	for v := range popped {
+		if seen[v] {
+			t.Fatalf("Value %d popped twice", v)
+		}
+		seen[v] = true
+	}
+	if i := slices.Index(seen, false); i >= 0 {
+		t.Errorf("Value %d was never popped", i)
+	}
+}
+
+func BenchmarkPushPop(b *testing.B) {
+	pq := PriorityQueue.NewMinHeap[int]()
+	for i := 0; i < 1000; i++ {
+		pq.Push(rand.Int())
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		pq.Push(rand.Int())
+		pq.Pop()
+	}
+}
@@ -2,6 +2,7 @@ package main_test
 
 import (
 	queue "GoSTL/Queue"
+	"encoding/json"
 	"fmt"
 	"testing"
 )
@@ -19,11 +20,151 @@ func TestNewQueue(t *testing.T) {
 	}
 }
 
+func TestNewQueueOptions(t *testing.T) {
+	data := []int{1, 2, 3}
+	q := queue.NewQueueFrom(data, queue.WithInitialCapacity(16))
+	data[0] = 100
+	if q.Len() != 3 || q.Capacity() != 16 {
+		t.Errorf("Expected length 3 and capacity 16, got %d and %d", q.Len(), q.Capacity())
+	}
+	if s := fmt.Sprint(q); s != "[1 2 3]" {
+		t.Errorf("Expected preloaded [1 2 3] independent of the source slice, got %s", s)
+	}
+	if v, _ := q.Pop(); v != 1 {
+		t.Errorf("Expected the first preloaded element at the front, got %d", v)
+	}
+
+	g := queue.NewQueue[int](queue.WithGrowthFactor(1.5), queue.WithInitialCapacity(8))
+	for i := 0; i < 9; i++ {
+		g.Push(i)
+	}
+	if g.Capacity() != 12 {
+		t.Errorf("Expected capacity 12 with growth factor 1.5, got %d", g.Capacity())
+	}
+
+	b := queue.NewQueue[int](queue.WithMaxCapacity(2))
+	b.Push(1)
+	b.Push(2)
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("Push beyond the max capacity should panic")
+			}
+		}()
+		b.Push(3)
+	}()
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("NewQueueFrom with more elements than the max capacity should panic")
+			}
+		}()
+		queue.NewQueueFrom([]int{1, 2, 3}, queue.WithMaxCapacity(2))
+	}()
+
+	if q := queue.NewQueue[int](); q.Capacity() != 8 || !q.Empty() {
+		t.Errorf("Expected an empty queue with capacity 8 without options, got capacity %d", q.Capacity())
+	}
+}
+
+func TestQueuePrioritise(t *testing.T) {
+	q := queue.NewQueueFrom([]string{"a", "b", "cancel", "d"})
+	if !q.Prioritise(2) {
+		t.Fatal("Prioritise(2) should succeed")
+	}
+	if v, _ := q.Front(); v != "cancel" {
+		t.Errorf("Expected cancel at the front, got %s", v)
+	}
+	if !q.Deprioritise(-3) {
+		t.Fatal("Deprioritise(-3) should succeed")
+	}
+	if s := fmt.Sprint(q); s != "[cancel b d a]" {
+		t.Errorf("Expected [cancel b d a], got %s", s)
+	}
+	if q.Prioritise(4) || q.Deprioritise(-5) {
+		t.Error("Out-of-range indices should fail")
+	}
+}
+
+func TestQueueJSON(t *testing.T) {
+	q := queue.NewQueue[string]()
+	q.Push("a")
+	q.Push("b")
+	b, err := json.Marshal(q)
+	if err != nil || string(b) != `["a","b"]` {
+		t.Fatalf("Marshal expected [\"a\",\"b\"], got %s (%v)", b, err)
+	}
+
+	var wrapper struct {
+		Q queue.Queue[string]
+	}
+	if err := json.Unmarshal([]byte(`{"Q":["a","b"]}`), &wrapper); err != nil {
+		t.Fatal(err)
+	}
+	if v, _ := wrapper.Q.Pop(); v != "a" || wrapper.Q.Len() != 1 {
+		t.Errorf("Round trip should keep FIFO order, got %s first", v)
+	}
+	if err := json.Unmarshal([]byte(`[1]`), q); err == nil || q.Len() != 2 {
+		t.Error("Mismatched elements should fail without modifying the queue")
+	}
+}
+
+func TestQueueMarshalBinary(t *testing.T) {
+	q := queue.NewQueue[int]()
+	for i := 0; i < 12; i++ {
+		q.Push(i)
+	}
+	for i := 0; i < 6; i++ {
+		q.Pop()
+	}
+	b, err := q.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var r queue.Queue[int]
+	if err := r.UnmarshalBinary(b); err != nil {
+		t.Fatal(err)
+	}
+	if fmt.Sprint(&r) != fmt.Sprint(q) {
+		t.Errorf("Expected %v, got %v", q, &r)
+	}
+	if err := r.UnmarshalBinary(b[:5]); err == nil || r.Len() != 6 {
+		t.Error("Truncated payload should fail without modifying the queue")
+	}
+}
+
+func TestQueueBackEnds(t *testing.T) {
+	q := queue.NewQueue[int]()
+	if _, ok := q.Back(); ok {
+		t.Error("Back on an empty queue should fail")
+	}
+	q.Push(1)
+	q.Push(2)
+	if v, ok := q.Back(); !ok || v != 2 {
+		t.Errorf("Back expected 2, got %d", v)
+	}
+	q.PushFront(0)
+	if v, _ := q.Front(); v != 0 {
+		t.Errorf("PushFront should make 0 the next element, got %d", v)
+	}
+	if v, ok := q.PopBack(); !ok || v != 2 {
+		t.Errorf("PopBack expected 2, got %d", v)
+	}
+	if s := fmt.Sprint(q); s != "[0 1]" {
+		t.Errorf("Expected [0 1], got %s", s)
+	}
+	q.Clear()
+	if _, ok := q.PopBack(); ok {
+		t.Error("PopBack on an empty queue should fail")
+	}
+}
+
 func TestQueueInit(t *testing.T) {
 	q := queue.NewQueue[string]()
-	q.Init(5) // Should use minimum capacity (8)
-	if q.Capacity() < 8 {
-		t.Errorf("Init(5) should set capacity >= 8, got %d", q.Capacity())
+	q.Init(5)
+	if q.Capacity() != 5 {
+		t.Errorf("Init(5) should set capacity to 5, got %d", q.Capacity())
 	}
 
 	q.Init(20)
@@ -32,6 +173,47 @@ func TestQueueInit(t *testing.T) {
 	}
 }
 
+func TestQueueInitBounded(t *testing.T) {
+	q := queue.NewQueue[int](queue.WithMaxCapacity(4))
+	q.Init(100)
+	if q.Capacity() != 4 {
+		t.Errorf("Init(100) on a queue bounded to 4 should set capacity to 4, got %d", q.Capacity())
+	}
+	for i := 0; i < 4; i++ {
+		q.Push(i)
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("Push beyond the maximum capacity should panic after Init")
+		}
+	}()
+	q.Push(4)
+}
+
+func TestQueueReset(t *testing.T) {
+	q := queue.NewQueue[int]()
+	q.Init(4)
+	for i := 0; i < 10; i++ {
+		q.Push(i)
+	}
+	if q.Capacity() <= 4 {
+		t.Fatalf("Capacity should have grown beyond 4, got %d", q.Capacity())
+	}
+
+	q.Reset()
+	if !q.Empty() {
+		t.Error("After Reset, queue should be empty")
+	}
+	if q.Capacity() != 4 {
+		t.Errorf("Reset should restore capacity 4, got %d", q.Capacity())
+	}
+
+	q.Push(1)
+	if val, ok := q.Front(); !ok || val != 1 {
+		t.Errorf("Expected (1, true) after reuse, got (%d, %v)", val, ok)
+	}
+}
+
 func TestQueuePushPop(t *testing.T) {
 	q := queue.NewQueue[int]()
 
@@ -63,6 +245,40 @@ func TestQueuePushPop(t *testing.T) {
 	}
 }
 
+func TestQueueAliases(t *testing.T) {
+	a := queue.NewQueue[int]()
+	b := queue.NewQueue[int]()
+
+	// Test empty queue
+	if _, ok := a.Dequeue(); ok {
+		t.Error("Expected false when dequeuing from empty queue")
+	}
+	if _, ok := a.Peek(); ok {
+		t.Error("Expected false when peeking at empty queue")
+	}
+
+	for i := 0; i < 20; i++ {
+		a.Enqueue(i)
+		b.Push(i)
+	}
+	if a.Len() != b.Len() {
+		t.Errorf("Enqueue and Push should produce the same length, got %d and %d", a.Len(), b.Len())
+	}
+
+	for i := 0; i < 20; i++ {
+		peek, _ := a.Peek()
+		front, _ := b.Front()
+		if peek != front {
+			t.Errorf("Peek() = %d, Front() = %d", peek, front)
+		}
+		v1, ok1 := a.Dequeue()
+		v2, ok2 := b.Pop()
+		if v1 != v2 || ok1 != ok2 || v1 != i {
+			t.Errorf("Dequeue() = (%d, %v), Pop() = (%d, %v), expected %d", v1, ok1, v2, ok2, i)
+		}
+	}
+}
+
 func TestQueueFront(t *testing.T) {
 	q := queue.NewQueue[string]()
 
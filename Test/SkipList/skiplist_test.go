@@ -0,0 +1,239 @@
+package main_test
+
+import (
+	"cmp"
+	"math/rand"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"GoSTL/SkipList"
+	"GoSTL/TreeMap"
+)
+
+func TestPutGetDelete(t *testing.T) {
+	s := SkipList.NewSkipList[string, int](strings.Compare)
+	for i, k := range []string{"m", "c", "x", "a", "e"} {
+		s.Put(k, i)
+	}
+	s.Put("c", 10)
+	if v, ok := s.Get("c"); !ok || v != 10 || s.Len() != 5 {
+		t.Errorf("Expected (10, true) with 5 entries, got (%d, %v) with %d", v, ok, s.Len())
+	}
+	if _, ok := s.Get("b"); ok {
+		t.Error("Missing key should not be found")
+	}
+	if !s.Delete("m") || s.Delete("m") || s.Len() != 4 {
+		t.Error("Delete should succeed exactly once")
+	}
+	var keys []string
+	s.ForEachAscending(func(k string, _ int) { keys = append(keys, k) })
+	if !slices.Equal(keys, []string{"a", "c", "e", "x"}) {
+		t.Errorf("ForEachAscending expected ascending order, got %v", keys)
+	}
+}
+
+func TestMinMax(t *testing.T) {
+	s := SkipList.NewSkipList[int, string](cmp.Compare[int])
+	if _, _, ok := s.Min(); ok {
+		t.Error("Min of an empty list should fail")
+	}
+	if _, _, ok := s.Max(); ok {
+		t.Error("Max of an empty list should fail")
+	}
+	for _, k := range []int{5, 1, 9, 3} {
+		s.Put(k, "")
+	}
+	if k, _, ok := s.Min(); !ok || k != 1 {
+		t.Errorf("Min expected 1, got %d", k)
+	}
+	if k, _, ok := s.Max(); !ok || k != 9 {
+		t.Errorf("Max expected 9, got %d", k)
+	}
+	s.Delete(9)
+	if k, _, _ := s.Max(); k != 5 {
+		t.Errorf("Max after delete expected 5, got %d", k)
+	}
+}
+
+func TestRangeQuery(t *testing.T) {
+	s := SkipList.NewSkipList[int, int](cmp.Compare[int])
+	for k := 0; k < 20; k += 2 {
+		s.Put(k, k*k)
+	}
+	got := s.RangeQuery(3, 10)
+	if len(got) != 4 || got[0].Key != 4 || got[3].Key != 10 || got[1].Value != 36 {
+		t.Errorf("Expected keys 4..10 inclusive, got %v", got)
+	}
+	if got := s.RangeQuery(10, 3); len(got) != 0 {
+		t.Errorf("Inverted range should be empty, got %v", got)
+	}
+	if got := s.RangeQuery(-5, 100); len(got) != 10 {
+		t.Errorf("Wide range should return every entry, got %d", len(got))
+	}
+}
+
+func TestAgainstMap(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	s := SkipList.NewSkipList[int, int](cmp.Compare[int])
+	ref := make(map[int]int)
+	for i := 0; i < 20000; i++ {
+		k := r.Intn(1000)
+		switch r.Intn(3) {
+		case 0:
+			if s.Delete(k) != (ref[k] != 0) {
+				t.Fatalf("Step %d: Delete(%d) disagrees with the reference", i, k)
+			}
+			delete(ref, k)
+		default:
+			s.Put(k, i+1)
+			ref[k] = i + 1
+		}
+	}
+	if s.Len() != len(ref) {
+		t.Fatalf("Expected %d entries, got %d", len(ref), s.Len())
+	}
+	prev := -1
+	s.ForEachAscending(func(k, v int) {
+		if k <= prev || ref[k] != v {
+			t.Fatalf("Entry (%d, %d) out of order or wrong", k, v)
+		}
+		prev = k
+	})
+}
+
+func TestRank(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	s := SkipList.NewSkipList[int, int](cmp.Compare[int])
+	if _, ok := s.Rank(0); ok {
+		t.Error("Rank on an empty list should fail")
+	}
+	if _, _, ok := s.ByRank(0); ok {
+		t.Error("ByRank on an empty list should fail")
+	}
+
+	ref := make(map[int]bool)
+	check := func(step int) {
+		keys := make([]int, 0, len(ref))
+		for k := range ref {
+			keys = append(keys, k)
+		}
+		slices.Sort(keys)
+		for i, k := range keys {
+			if got, ok := s.Rank(k); !ok || got != i {
+				t.Fatalf("Step %d: Rank(%d) expected %d, got (%d, %v)", step, k, i, got, ok)
+			}
+			if got, v, ok := s.ByRank(i); !ok || got != k || v != k*2 {
+				t.Fatalf("Step %d: ByRank(%d) expected %d, got (%d, %d, %v)", step, i, k, got, v, ok)
+			}
+		}
+		if _, ok := s.Rank(-1); ok {
+			t.Fatalf("Step %d: Rank of a missing key should fail", step)
+		}
+		if _, _, ok := s.ByRank(len(keys)); ok {
+			t.Fatalf("Step %d: ByRank past the end should fail", step)
+		}
+		if _, _, ok := s.ByRank(-1); ok {
+			t.Fatalf("Step %d: ByRank(-1) should fail", step)
+		}
+	}
+	for i := 0; i < 3000; i++ {
+		k := r.Intn(300)
+		if r.Intn(3) == 0 {
+			s.Delete(k)
+			delete(ref, k)
+		} else {
+			s.Put(k, k*2)
+			ref[k] = true
+		}
+		if i%100 == 0 {
+			check(i)
+		}
+	}
+	check(3000)
+
+	s.Clear()
+	for i := 0; i < 10; i++ {
+		s.Put(i*10, i*20)
+	}
+	if got, ok := s.Rank(50); !ok || got != 5 {
+		t.Errorf("Rank after Clear expected 5, got (%d, %v)", got, ok)
+	}
+	if k, _, ok := s.ByRank(9); !ok || k != 90 {
+		t.Errorf("ByRank after Clear expected 90, got (%d, %v)", k, ok)
+	}
+}
+
+func TestClear(t *testing.T) {
+	s := SkipList.NewSkipList[int, int](cmp.Compare[int])
+	for i := 0; i < 100; i++ {
+		s.Put(i, i)
+	}
+	s.Clear()
+	if s.Len() != 0 || len(s.RangeQuery(0, 100)) != 0 {
+		t.Error("Clear should remove every entry")
+	}
+	s.Put(1, 1)
+	if v, ok := s.Get(1); !ok || v != 1 {
+		t.Error("List should stay usable after Clear")
+	}
+}
+
+func TestForEachMayModify(t *testing.T) {
+	s := SkipList.NewSkipList[int, int](cmp.Compare[int])
+	for i := 0; i < 10; i++ {
+		s.Put(i, i)
+	}
+	s.ForEachAscending(func(k, _ int) { s.Delete(k) })
+	if s.Len() != 0 {
+		t.Errorf("Expected an empty list, got %d entries", s.Len())
+	}
+}
+
+func TestConcurrentAccess(t *testing.T) {
+	s := SkipList.NewSkipList[int, int](cmp.Compare[int])
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 500; i++ {
+				k := g*500 + i
+				s.Put(k, k)
+				s.Get(k)
+				if i%2 == 1 {
+					s.Delete(k)
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+	if s.Len() != 2000 {
+		t.Errorf("Expected 2000 entries, got %d", s.Len())
+	}
+}
+
+const benchSize = 1 << 16
+
+func BenchmarkSkipListGet(b *testing.B) {
+	s := SkipList.NewSkipList[int, int](cmp.Compare[int])
+	for _, k := range rand.New(rand.NewSource(1)).Perm(benchSize) {
+		s.Put(k, k)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		s.Get(i & (benchSize - 1))
+	}
+}
+
+func BenchmarkTreeMapGet(b *testing.B) {
+	m := TreeMap.NewTreeMap[int, int](cmp.Compare[int])
+	for _, k := range rand.New(rand.NewSource(1)).Perm(benchSize) {
+		m.Put(k, k)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		m.Get(i & (benchSize - 1))
+	}
+}
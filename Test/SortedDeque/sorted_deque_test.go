@@ -0,0 +1,131 @@
+package main_test
+
+import (
+	"cmp"
+	"fmt"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"GoSTL/SortedDeque"
+)
+
+func TestInsertRemove(t *testing.T) {
+	s := SortedDeque.NewSortedDeque(cmp.Compare[int])
+	for _, v := range []int{5, 1, 4, 1, 3} {
+		s.Insert(v)
+	}
+	if str := fmt.Sprint(s); str != "[1 1 3 4 5]" {
+		t.Fatalf("Expected [1 1 3 4 5], got %s", str)
+	}
+	if i := s.Insert(2); i != 2 {
+		t.Errorf("Insert(2) expected index 2, got %d", i)
+	}
+	if !s.Remove(1) || !s.Remove(5) || s.Remove(42) {
+		t.Error("Unexpected Remove results")
+	}
+	if str := fmt.Sprint(s); str != "[1 2 3 4]" || s.Len() != 4 {
+		t.Errorf("Expected [1 2 3 4], got %s", str)
+	}
+	if v, _ := s.Front(); v != 1 {
+		t.Errorf("Front expected 1, got %d", v)
+	}
+	if v, _ := s.Back(); v != 4 {
+		t.Errorf("Back expected 4, got %d", v)
+	}
+	if v, ok := s.At(2); !ok || v != 3 {
+		t.Errorf("At(2) expected 3, got %d", v)
+	}
+}
+
+func TestContainsAndRange(t *testing.T) {
+	s := SortedDeque.NewSortedDeque(cmp.Compare[int])
+	for _, v := range rand.Perm(20) {
+		s.Insert(v * 2) // even numbers 0..38
+	}
+	for v := -1; v < 41; v++ {
+		if s.Contains(v) != (v >= 0 && v < 40 && v%2 == 0) {
+			t.Errorf("Contains(%d) returned the wrong result", v)
+		}
+	}
+	if got := slices.Collect(s.Range(5, 13)); !slices.Equal(got, []int{6, 8, 10, 12}) {
+		t.Errorf("Range(5, 13) expected [6 8 10 12], got %v", got)
+	}
+	if got := slices.Collect(s.Range(6, 8)); !slices.Equal(got, []int{6}) {
+		t.Errorf("Range should include lo and exclude hi, got %v", got)
+	}
+	if got := slices.Collect(s.Range(100, 200)); len(got) != 0 {
+		t.Errorf("Empty range expected, got %v", got)
+	}
+	// Modifying during iteration must not deadlock
+	for v := range s.Range(0, 10) {
+		s.Remove(v)
+	}
+	if s.Len() != 15 {
+		t.Errorf("Expected 15 elements after removing a range, got %d", s.Len())
+	}
+}
+
+func TestCustomOrder(t *testing.T) {
+	type task struct {
+		name     string
+		priority int
+	}
+	s := SortedDeque.NewSortedDeque(func(a, b task) int { return cmp.Compare(b.priority, a.priority) })
+	s.Insert(task{"low", 1})
+	s.Insert(task{"high", 9})
+	s.Insert(task{"mid", 5})
+	if v, _ := s.Front(); v.name != "high" {
+		t.Errorf("Expected the highest priority first, got %s", v.name)
+	}
+}
+
+// sortedSlice is a slice-based sorted list used as a baseline in the benchmarks.
+type sortedSlice []int
+
+func (s *sortedSlice) insert(v int) {
+	i, _ := slices.BinarySearch(*s, v)
+	*s = slices.Insert(*s, i, v)
+}
+
+func BenchmarkInsert(b *testing.B) {
+	for _, n := range []int{1000, 10000} {
+		vals := rand.Perm(n)
+		b.Run(fmt.Sprintf("SortedDeque/n=%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				s := SortedDeque.NewSortedDeque(cmp.Compare[int])
+				for _, v := range vals {
+					s.Insert(v)
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("SortedSlice/n=%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				var s sortedSlice
+				for _, v := range vals {
+					s.insert(v)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkContains(b *testing.B) {
+	const n = 10000
+	s := SortedDeque.NewSortedDeque(cmp.Compare[int])
+	var baseline sortedSlice
+	for _, v := range rand.Perm(n) {
+		s.Insert(v)
+		baseline.insert(v)
+	}
+	b.Run("SortedDeque", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			s.Contains(i % n)
+		}
+	})
+	b.Run("SortedSlice", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_, _ = slices.BinarySearch(baseline, i%n)
+		}
+	})
+}
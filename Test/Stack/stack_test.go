@@ -1,13 +1,16 @@
 package main_test
 
 import (
+	"encoding/json"
 	"fmt"
 	"math/rand"
 	"runtime"
+	"strings"
 	"sync"
 	"testing"
 	"time"
 
+	"GoSTL/Deque"
 	"GoSTL/Stack"
 )
 
@@ -438,6 +441,478 @@ func TestFormat(t *testing.T) {
 	}
 }
 
+func TestString(t *testing.T) {
+	s := Stack.NewStack[string]()
+	if s.String() != "[]" || s.StringN(2) != "[]" {
+		t.Errorf("Empty stack expected [], got %q and %q", s.String(), s.StringN(2))
+	}
+	for _, v := range []string{"a", "b", "c", "d"} {
+		s.Push(v)
+	}
+	if str := s.String(); str != "[d c b a]" || str != fmt.Sprintf("%v", s) {
+		t.Errorf("String expected [d c b a] matching %%v, got %q", str)
+	}
+	if str := s.StringN(2); str != "[d c ...+2]" {
+		t.Errorf("StringN(2) expected [d c ...+2], got %q", str)
+	}
+	if str := s.StringN(4); str != "[d c b a]" {
+		t.Errorf("StringN(4) expected [d c b a], got %q", str)
+	}
+	if str := s.StringN(0); str != s.String() {
+		t.Errorf("StringN(0) should show all elements, got %q", str)
+	}
+	if str := s.StringN(3); str != fmt.Sprintf("%6v", s) {
+		t.Errorf("StringN(3) should match %%6v, got %q", str)
+	}
+}
+
+func TestHooks(t *testing.T) {
+	s := Stack.NewStack[int](2)
+	var pushed, popped []int
+	s.SetOnPush(func(val int) { pushed = append(pushed, val) })
+	s.SetOnPop(func(val int) { popped = append(popped, val) })
+
+	// Push past the initial capacity to cover the resize path
+	for i := 0; i < 10; i++ {
+		s.Push(i)
+	}
+	for i := 0; i < 3; i++ {
+		s.Pop()
+	}
+
+	if len(pushed) != 10 {
+		t.Errorf("Expected 10 push notifications, got %d", len(pushed))
+	}
+	for i, val := range pushed {
+		if val != i {
+			t.Errorf("Push hook %d expected %d, got %d", i, i, val)
+		}
+	}
+	expected := []int{9, 8, 7}
+	if len(popped) != len(expected) {
+		t.Fatalf("Expected %d pop notifications, got %d", len(expected), len(popped))
+	}
+	for i, exp := range expected {
+		if popped[i] != exp {
+			t.Errorf("Pop hook %d expected %d, got %d", i, exp, popped[i])
+		}
+	}
+
+	// Failed pops must not fire the hook
+	s.Clear()
+	s.Pop()
+	if len(popped) != 3 {
+		t.Errorf("Pop on empty stack should not call hook, got %d calls", len(popped))
+	}
+
+	// nil disables the hooks
+	s.SetOnPush(nil)
+	s.SetOnPop(nil)
+	s.Push(1)
+	s.Pop()
+	if len(pushed) != 10 || len(popped) != 3 {
+		t.Error("Hooks should not be called after being set to nil")
+	}
+}
+
+func TestDebug(t *testing.T) {
+	s := Stack.NewStack[int]()
+	for i := 1; i <= 3; i++ {
+		s.Push(i)
+	}
+	expected := "top=3 cap=8\ndata=[1 2 3 0 0 0 0 0]"
+	if str := s.Debug(); str != expected {
+		t.Errorf("Debug() expected %q, got %q", expected, str)
+	}
+
+	// Unoccupied pointer slots show as <nil>
+	ps := Stack.NewStack[*int]()
+	expected = "top=0 cap=8\ndata=[<nil> <nil> <nil> <nil> <nil> <nil> <nil> <nil>]"
+	if str := ps.Debug(); str != expected {
+		t.Errorf("Debug() expected %q, got %q", expected, str)
+	}
+}
+
+func TestDrain(t *testing.T) {
+	s := Stack.NewStack[int]()
+	for i := 0; i < 10; i++ {
+		s.Push(i)
+	}
+
+	// Early break leaves the rest in place
+	next := 9
+	for v := range s.Drain() {
+		if v != next {
+			t.Errorf("Expected %d, got %d", next, v)
+		}
+		next--
+		if v == 6 {
+			break
+		}
+	}
+	if s.Length() != 6 {
+		t.Fatalf("Expected 6 remaining elements, got %d", s.Length())
+	}
+
+	// Elements pushed during iteration are popped next
+	var got []int
+	for v := range s.Drain() {
+		got = append(got, v)
+		if v == 5 {
+			s.Push(100)
+		}
+	}
+	expected := []int{5, 100, 4, 3, 2, 1, 0}
+	if len(got) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, got)
+	}
+	for i, exp := range expected {
+		if got[i] != exp {
+			t.Errorf("Drain step %d expected %d, got %d", i, exp, got[i])
+		}
+	}
+	if !s.Empty() {
+		t.Error("Stack should be empty after full drain")
+	}
+}
+
+func TestPushUnique(t *testing.T) {
+	s := Stack.NewStack[string](2)
+	eq := func(a, b string) bool { return a == b }
+
+	for _, v := range []string{"x", "y", "z"} {
+		if !s.PushUnique(v, eq) {
+			t.Errorf("PushUnique(%q) should succeed", v)
+		}
+	}
+	for _, v := range []string{"x", "y", "z"} {
+		if s.PushUnique(v, eq) {
+			t.Errorf("PushUnique(%q) should fail for existing element", v)
+		}
+	}
+	if s.Length() != 3 {
+		t.Errorf("Expected length 3, got %d", s.Length())
+	}
+	if val, _ := s.Top(); val != "z" {
+		t.Errorf("Expected top z, got %s", val)
+	}
+
+	// Popped elements no longer count as present
+	s.Pop()
+	if !s.PushUnique("z", eq) {
+		t.Error("PushUnique(z) should succeed after z was popped")
+	}
+}
+
+func TestBoundedStack(t *testing.T) {
+	s := Stack.NewBoundedStack[int](3)
+	if s.MaxCapacity() != 3 || s.Capacity() != 3 {
+		t.Errorf("Expected max capacity and capacity 3, got %d and %d", s.MaxCapacity(), s.Capacity())
+	}
+	for i := 0; i < 3; i++ {
+		if s.IsFull() {
+			t.Fatalf("Stack should not be full with %d elements", i)
+		}
+		s.Push(i)
+	}
+	if !s.IsFull() {
+		t.Error("Stack should be full with 3 elements")
+	}
+
+	expectPanic := func(name, want string, fn func()) {
+		t.Helper()
+		defer func() {
+			r := recover()
+			if r == nil {
+				t.Errorf("%s should panic", name)
+			} else if r != want {
+				t.Errorf("%s panic message expected %q, got %v", name, want, r)
+			}
+		}()
+		fn()
+	}
+	full := "Stack: push on full bounded stack, capacity 3"
+	expectPanic("Push", full, func() { s.Push(3) })
+	expectPanic("PushUnique", full, func() { s.PushUnique(3, func(a, b int) bool { return a == b }) })
+	expectPanic("Resize", "Stack: Resize called on bounded stack, capacity 3", func() { s.Resize(10) })
+	if s.Length() != 3 || s.Capacity() != 3 {
+		t.Errorf("Failed pushes should leave the stack unchanged, got length %d capacity %d", s.Length(), s.Capacity())
+	}
+
+	// The stack is still usable after a failed push, and never grows past its limit after trimming
+	s.Pop()
+	s.TrimToSize()
+	s.Push(7)
+	if !s.IsFull() || s.Capacity() != 3 {
+		t.Errorf("Expected a full stack with capacity 3, got capacity %d", s.Capacity())
+	}
+	if c := s.Copy(); c.MaxCapacity() != 3 || !c.IsFull() {
+		t.Error("Copy should preserve the bound")
+	}
+
+	u := Stack.NewStack[int]()
+	if u.IsFull() || u.MaxCapacity() != 0 {
+		t.Error("Unbounded stacks are never full and have no max capacity")
+	}
+	expectPanic("NewBoundedStack(0)", "Stack: invalid bounded stack capacity 0", func() { Stack.NewBoundedStack[int](0) })
+}
+
+func TestPopWhile(t *testing.T) {
+	s := Stack.NewStack[int](2)
+	var popped []int
+	s.SetOnPop(func(val int) { popped = append(popped, val) })
+	for _, v := range []int{9, 1, 2, 3} {
+		s.Push(v)
+	}
+
+	got := s.PopWhile(func(v int) bool { return v < 5 })
+	if fmt.Sprint(got) != "[3 2 1]" || fmt.Sprint(popped) != "[3 2 1]" {
+		t.Errorf("Expected [3 2 1] popped top-down with hooks, got %v and hooks %v", got, popped)
+	}
+	if s.Length() != 1 {
+		t.Errorf("Expected 1 remaining element, got %d", s.Length())
+	}
+	if got := s.PopWhile(func(v int) bool { return v < 5 }); got == nil || len(got) != 0 {
+		t.Errorf("No match should return an empty non-nil slice, got %#v", got)
+	}
+	if !strings.Contains(s.Debug(), "data=[9 0 0 0 0 0 0 0]") {
+		t.Errorf("Vacated slots should be zeroed:\n%s", s.Debug())
+	}
+	s.PopWhile(func(int) bool { return true })
+	if !s.Empty() {
+		t.Error("PopWhile(always true) should empty the stack")
+	}
+	if got := s.PopWhile(func(int) bool { return true }); got == nil || len(got) != 0 {
+		t.Error("PopWhile on an empty stack should return an empty non-nil slice")
+	}
+}
+
+// TestPopWhileConcurrentPush checks that PopWhile does not zero a slot that a concurrent lock-free Push has just
+// claimed: every pushed value must come out exactly once, either from PopWhile or from draining the stack.
+func TestPopWhileConcurrentPush(t *testing.T) {
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(8))
+	const trials, perSide = 5000, 4
+	for trial := 0; trial < trials; trial++ {
+		s := Stack.NewStack[int](64)
+		for i := 1; i <= perSide; i++ {
+			s.Push(i)
+		}
+
+		var wg sync.WaitGroup
+		var popped []int
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			popped = s.PopWhile(func(int) bool { return true })
+		}()
+		go func() {
+			defer wg.Done()
+			for i := perSide + 1; i <= 2*perSide; i++ {
+				s.Push(i)
+			}
+		}()
+		wg.Wait()
+		for v, ok := s.Pop(); ok; v, ok = s.Pop() {
+			popped = append(popped, v)
+		}
+
+		seen := make([]bool, 2*perSide+1)
+		for _, v := range popped {
+			if v == 0 || seen[v] {
+				t.Fatalf("Trial %d: pushed values were lost or duplicated, got %v", trial, popped)
+			}
+			seen[v] = true
+		}
+		if len(popped) != 2*perSide {
+			t.Fatalf("Trial %d: expected %d values, got %v", trial, 2*perSide, popped)
+		}
+	}
+}
+
+func TestFromSlice(t *testing.T) {
+	src := []int{1, 2, 3}
+	s := Stack.FromSlice(src)
+	src[2] = 100
+	if v, ok := s.Top(); !ok || v != 3 {
+		t.Fatalf("Expected 3 on top, got %d", v)
+	}
+	if got := fmt.Sprint(s.ToSlice()); got != "[3 2 1]" {
+		t.Errorf("ToSlice expected [3 2 1], got %s", got)
+	}
+	for i := 4; i <= 10; i++ {
+		s.Push(i)
+	}
+	if v, _ := s.Pop(); v != 10 || s.Len() != 9 {
+		t.Error("FromSlice stack should grow like any other")
+	}
+
+	empty := Stack.FromSlice[string](nil)
+	if !empty.Empty() || len(empty.ToSlice()) != 0 {
+		t.Error("FromSlice(nil) should create an empty stack")
+	}
+}
+
+func TestJSON(t *testing.T) {
+	type item struct {
+		Name string
+		N    int
+	}
+	s := Stack.NewStack[int]()
+	for i := 1; i <= 3; i++ {
+		s.Push(i)
+	}
+	b, err := json.Marshal(s)
+	if err != nil || string(b) != "[3,2,1]" {
+		t.Fatalf("Marshal expected [3,2,1], got %s (%v)", b, err)
+	}
+	var back Stack.Stack[int]
+	if err := json.Unmarshal(b, &back); err != nil {
+		t.Fatal(err)
+	}
+	if v, _ := back.Pop(); v != 3 || back.Len() != 2 {
+		t.Errorf("Round trip should keep 3 on top, got %d", v)
+	}
+
+	strs := Stack.FromSlice([]string{"stale"})
+	if err := json.Unmarshal([]byte(`["top","bottom"]`), strs); err != nil || fmt.Sprint(strs.ToSlice()) != "[top bottom]" {
+		t.Errorf("Unmarshal should replace the contents, got %v (%v)", strs.ToSlice(), err)
+	}
+	if err := json.Unmarshal([]byte(`{}`), strs); err == nil || strs.Len() != 2 {
+		t.Error("Invalid JSON should fail without modifying the stack")
+	}
+
+	items := Stack.FromSlice([]item{{"a", 1}, {"b", 2}})
+	b, _ = json.Marshal(items)
+	got := Stack.NewStack[item]()
+	if err := json.Unmarshal(b, got); err != nil {
+		t.Fatal(err)
+	}
+	if v, _ := got.Top(); v != (item{"b", 2}) {
+		t.Errorf("Struct round trip failed: %s", b)
+	}
+
+	bounded := Stack.NewBoundedStack[int](2)
+	if err := json.Unmarshal([]byte("[1,2,3]"), bounded); err == nil || !bounded.Empty() {
+		t.Error("Unmarshal beyond bounded capacity should fail")
+	}
+}
+
+func TestMarshalBinary(t *testing.T) {
+	type item struct {
+		Name string
+		Tags []string
+	}
+	full := Stack.NewStack[int](8)
+	for i := 0; i < 8; i++ {
+		full.Push(i)
+	}
+	cases := map[string]*Stack.Stack[int]{
+		"empty":  Stack.NewStack[int](),
+		"single": Stack.FromSlice([]int{42}),
+		"full":   full,
+	}
+	for name, s := range cases {
+		b, err := s.MarshalBinary()
+		if err != nil {
+			t.Fatalf("%s: MarshalBinary failed: %v", name, err)
+		}
+		r := Stack.NewStack[int](100)
+		if err := r.UnmarshalBinary(b); err != nil {
+			t.Fatalf("%s: UnmarshalBinary failed: %v", name, err)
+		}
+		if fmt.Sprint(r.ToSlice()) != fmt.Sprint(s.ToSlice()) || r.Capacity() != s.Capacity() {
+			t.Errorf("%s: expected %v with capacity %d, got %v with capacity %d",
+				name, s.ToSlice(), s.Capacity(), r.ToSlice(), r.Capacity())
+		}
+	}
+
+	items := Stack.FromSlice([]item{{"a", nil}, {"b", []string{"x", "y"}}})
+	b, err := items.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got Stack.Stack[item]
+	if err := got.UnmarshalBinary(b); err != nil {
+		t.Fatal(err)
+	}
+	if v, _ := got.Top(); v.Name != "b" || len(v.Tags) != 2 || got.Len() != 2 {
+		t.Errorf("Struct round trip failed: %v", got.ToSlice())
+	}
+
+	if err := full.UnmarshalBinary([]byte("garbage")); err == nil || full.Len() != 8 {
+		t.Error("Malformed payload should fail without modifying the stack")
+	}
+	b, _ = full.MarshalBinary()
+	if err := Stack.NewBoundedStack[int](4).UnmarshalBinary(b); err == nil {
+		t.Error("Unmarshal beyond bounded capacity should fail")
+	}
+	var zero Stack.Stack[int]
+	if b, err := zero.MarshalBinary(); err != nil || full.UnmarshalBinary(b) != nil || !full.Empty() {
+		t.Errorf("A zero Stack should marshal as empty, got %v", err)
+	}
+}
+
+func TestBinaryFormatSharedWithDeque(t *testing.T) {
+	// Stack writes top to bottom in the same format as a Deque front to back
+	s := Stack.FromSlice([]string{"a", "b", "c"})
+	b, err := s.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	d := Deque.NewDeque[string]()
+	if err := d.UnmarshalBinary(b); err != nil {
+		t.Fatalf("Deque should decode a Stack payload: %v", err)
+	}
+	if got := fmt.Sprint(d.ToSlice()); got != fmt.Sprint(s.ToSlice()) {
+		t.Errorf("Expected %v, got %s", s.ToSlice(), got)
+	}
+
+	// Element types outside the compact format go through gob in both containers
+	maps := Stack.FromSlice([]map[string]int{{"a": 1}, {"b": 2}})
+	if b, err = maps.MarshalBinary(); err != nil {
+		t.Fatalf("MarshalBinary of map elements failed: %v", err)
+	}
+	md := Deque.NewDeque[map[string]int]()
+	if err := md.UnmarshalBinary(b); err != nil {
+		t.Fatalf("Deque should decode a gob Stack payload: %v", err)
+	}
+	if got := fmt.Sprint(md.ToSlice()); got != "[map[b:2] map[a:1]]" {
+		t.Errorf("Expected [map[b:2] map[a:1]], got %s", got)
+	}
+	ptrs := Stack.NewStack[*int]()
+	if err := ptrs.UnmarshalBinary(b); err == nil {
+		t.Error("UnmarshalBinary into a different element type should fail")
+	}
+	ptrs.Push(nil)
+	if _, err := ptrs.MarshalBinary(); err == nil {
+		t.Error("MarshalBinary of a nil pointer should fail as it does for Deque")
+	}
+}
+
+func TestForEach(t *testing.T) {
+	s := Stack.FromSlice([]string{"a", "b", "c"})
+	var top, bottom []string
+	s.ForEach(func(i int, v string) {
+		top = append(top, fmt.Sprint(i, v))
+	})
+	s.ForEachBottom(func(i int, v string) {
+		bottom = append(bottom, fmt.Sprint(i, v))
+		s.Push(v) // the iteration runs over a snapshot
+	})
+	if got := strings.Join(top, ","); got != "0c,1b,2a" {
+		t.Errorf("ForEach expected top-down order, got %s", got)
+	}
+	if got := strings.Join(bottom, ","); got != "0a,1b,2c" {
+		t.Errorf("ForEachBottom expected bottom-up order, got %s", got)
+	}
+	if s.Len() != 6 {
+		t.Errorf("Pushes during ForEachBottom should apply, got %d elements", s.Len())
+	}
+	Stack.NewStack[int]().ForEachBottom(func(int, int) {
+		t.Error("ForEachBottom on an empty stack should not call fn")
+	})
+}
+
 func BenchmarkPushPop(b *testing.B) {
 	s := Stack.NewStack[int]()
 	b.ResetTimer()
@@ -0,0 +1,76 @@
+package main_test
+
+import (
+	"slices"
+	"testing"
+
+	"GoSTL/stl"
+)
+
+// checkContainer verifies c holds want, using only the Container interface, and then clears it.
+func checkContainer(t *testing.T, name string, c stl.Container[int], want []int) {
+	t.Helper()
+	if c.Len() != len(want) || c.Empty() != (len(want) == 0) {
+		t.Errorf("%s: expected length %d, got %d (empty=%v)", name, len(want), c.Len(), c.Empty())
+	}
+	if got := c.ToSlice(); !slices.Equal(got, want) {
+		t.Errorf("%s: ToSlice expected %v, got %v", name, want, got)
+	}
+	var seen []int
+	c.ForEach(func(i, v int) {
+		if i != len(seen) {
+			t.Errorf("%s: ForEach index %d out of order", name, i)
+		}
+		seen = append(seen, v)
+	})
+	if !slices.Equal(seen, want) {
+		t.Errorf("%s: ForEach expected %v, got %v", name, want, seen)
+	}
+	c.Clear()
+	if c.Len() != 0 || !c.Empty() || len(c.ToSlice()) != 0 {
+		t.Errorf("%s: expected empty container after Clear", name)
+	}
+}
+
+func TestContainers(t *testing.T) {
+	d := stl.NewDeque[int]()
+	d.PushBack(2)
+	d.PushBack(3)
+	d.PushFront(1)
+	checkContainer(t, "Deque", d, []int{1, 2, 3})
+
+	s := stl.NewStack[int](2)
+	for i := 1; i <= 3; i++ {
+		s.Push(i)
+	}
+	checkContainer(t, "Stack", s, []int{3, 2, 1})
+
+	q := stl.NewQueue[int]()
+	for i := 1; i <= 3; i++ {
+		q.Push(i)
+	}
+	checkContainer(t, "Queue", q, []int{1, 2, 3})
+
+	pq := stl.NewPriorityQueue(func(a, b int) int { return b - a })
+	for _, v := range []int{2, 3, 1} {
+		pq.Push(v)
+	}
+	checkContainer(t, "PriorityQueue", pq, []int{3, 2, 1})
+
+	checkContainer(t, "empty Deque", stl.NewDeque[int](), nil)
+}
+
+func TestForEachReentrant(t *testing.T) {
+	d := stl.NewDeque[int]()
+	d.PushBack(1)
+	d.PushBack(2)
+	// fn runs on a snapshot, so mutating the container must neither deadlock nor extend the loop
+	calls := 0
+	d.ForEach(func(_ int, v int) {
+		calls++
+		d.PushBack(v * 10)
+	})
+	if calls != 2 || d.Len() != 4 {
+		t.Errorf("Expected 2 calls and length 4, got %d calls and length %d", calls, d.Len())
+	}
+}
@@ -0,0 +1,153 @@
+package main_test
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"GoSTL/TreeMap"
+)
+
+func TestPutGetDelete(t *testing.T) {
+	m := TreeMap.NewTreeMap[string, int](strings.Compare)
+	for i, k := range []string{"m", "c", "x", "a", "e"} {
+		m.Put(k, i)
+	}
+	m.Put("c", 10)
+	if v, ok := m.Get("c"); !ok || v != 10 || m.Len() != 5 {
+		t.Errorf("Expected (10, true) with 5 entries, got (%d, %v) with %d", v, ok, m.Len())
+	}
+	if _, ok := m.Get("b"); ok {
+		t.Error("Missing key should not be found")
+	}
+	if !m.Delete("m") || m.Delete("m") {
+		t.Error("Delete should succeed exactly once")
+	}
+	if got := m.Keys(); !slices.Equal(got, []string{"a", "c", "e", "x"}) {
+		t.Errorf("Keys expected ascending order, got %v", got)
+	}
+	m.Clear()
+	if m.Len() != 0 || len(m.Keys()) != 0 {
+		t.Error("Clear should remove every entry")
+	}
+	if _, _, ok := m.Min(); ok {
+		t.Error("Min of an empty map should fail")
+	}
+}
+
+func TestOrderedQueries(t *testing.T) {
+	m := TreeMap.NewTreeMap[int, string](cmp.Compare[int])
+	for _, k := range []int{50, 20, 80, 10, 30, 70, 90} {
+		m.Put(k, fmt.Sprint("v", k))
+	}
+	if k, v, ok := m.Min(); !ok || k != 10 || v != "v10" {
+		t.Errorf("Min expected 10, got %d", k)
+	}
+	if k, _, ok := m.Max(); !ok || k != 90 {
+		t.Errorf("Max expected 90, got %d", k)
+	}
+	cases := []struct {
+		key               int
+		floor, ceiling    int
+		hasFloor, hasCeil bool
+	}{
+		{5, 0, 10, false, true},
+		{10, 10, 10, true, true},
+		{25, 20, 30, true, true},
+		{69, 50, 70, true, true},
+		{95, 90, 0, true, false},
+	}
+	for _, c := range cases {
+		if k, _, ok := m.Floor(c.key); ok != c.hasFloor || k != c.floor {
+			t.Errorf("Floor(%d) expected (%d, %v), got (%d, %v)", c.key, c.floor, c.hasFloor, k, ok)
+		}
+		if k, _, ok := m.Ceiling(c.key); ok != c.hasCeil || k != c.ceiling {
+			t.Errorf("Ceiling(%d) expected (%d, %v), got (%d, %v)", c.key, c.ceiling, c.hasCeil, k, ok)
+		}
+	}
+
+	var asc, desc []int
+	m.ForEachAscending(func(k int, v string) {
+		asc = append(asc, k)
+		m.Delete(k) // the traversal runs over a snapshot
+	})
+	if !slices.Equal(asc, []int{10, 20, 30, 50, 70, 80, 90}) || m.Len() != 0 {
+		t.Errorf("ForEachAscending visited %v", asc)
+	}
+	for k := range 5 {
+		m.Put(k, "")
+	}
+	m.ForEachDescending(func(k int, _ string) { desc = append(desc, k) })
+	if !slices.Equal(desc, []int{4, 3, 2, 1, 0}) {
+		t.Errorf("ForEachDescending visited %v", desc)
+	}
+}
+
+func TestForEach(t *testing.T) {
+	m := TreeMap.NewTreeMap[int, int](cmp.Compare[int])
+	m.ForEach(func(int, int) bool {
+		t.Error("ForEach should not call fn on an empty map")
+		return true
+	})
+	for _, k := range []int{50, 20, 80, 10, 30, 70, 90, 60} {
+		m.Put(k, k*10)
+	}
+
+	var asc, desc []int
+	m.ForEach(func(k, v int) bool {
+		if v != k*10 {
+			t.Errorf("Key %d has value %d", k, v)
+		}
+		asc = append(asc, k)
+		return true
+	})
+	m.ForEachReverse(func(k, _ int) bool {
+		desc = append(desc, k)
+		return true
+	})
+	if !slices.Equal(asc, []int{10, 20, 30, 50, 60, 70, 80, 90}) {
+		t.Errorf("ForEach visited %v", asc)
+	}
+	if !slices.Equal(desc, []int{90, 80, 70, 60, 50, 30, 20, 10}) {
+		t.Errorf("ForEachReverse visited %v", desc)
+	}
+
+	asc, desc = nil, nil
+	m.ForEach(func(k, _ int) bool {
+		asc = append(asc, k)
+		return k < 50
+	})
+	m.ForEachReverse(func(k, _ int) bool {
+		desc = append(desc, k)
+		return len(desc) < 2
+	})
+	if !slices.Equal(asc, []int{10, 20, 30, 50}) || !slices.Equal(desc, []int{90, 80}) {
+		t.Errorf("Traversal should stop when fn returns false, got %v and %v", asc, desc)
+	}
+}
+
+func TestConcurrentAccess(t *testing.T) {
+	m := TreeMap.NewTreeMap[int, int](cmp.Compare[int])
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 500; i++ {
+				key := g*500 + i
+				m.Put(key, key)
+				m.Floor(key)
+				if i%2 == 1 {
+					m.Delete(key)
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+	if keys := m.Keys(); len(keys) != 2000 || !slices.IsSorted(keys) {
+		t.Errorf("Expected 2000 sorted keys, got %d", len(keys))
+	}
+}
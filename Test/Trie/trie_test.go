@@ -0,0 +1,209 @@
+package main_test
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+
+	"GoSTL/Trie"
+)
+
+func TestInsertSearch(t *testing.T) {
+	tr := Trie.NewTrie()
+	for _, w := range []string{"car", "cart", "care", "dog", "car"} {
+		tr.Insert(w)
+	}
+	if tr.Len() != 4 {
+		t.Errorf("Expected 4 words, got %d", tr.Len())
+	}
+	if !tr.Search("car") || !tr.Search("cart") || tr.Search("ca") || tr.Search("cars") {
+		t.Error("Search should only match whole words")
+	}
+	if !tr.StartsWith("ca") || !tr.StartsWith("dog") || tr.StartsWith("x") {
+		t.Error("StartsWith mismatch")
+	}
+	if tr.Search("") {
+		t.Error("The empty word was never inserted")
+	}
+}
+
+func TestDelete(t *testing.T) {
+	tr := Trie.NewTrie()
+	for _, w := range []string{"car", "cart", "care"} {
+		tr.Insert(w)
+	}
+	if tr.Delete("ca") || tr.Delete("carts") {
+		t.Error("Deleting a missing word should fail")
+	}
+	if !tr.Delete("car") || tr.Delete("car") || tr.Search("car") || tr.Len() != 2 {
+		t.Error("Delete should remove the word exactly once")
+	}
+	if !tr.Search("cart") || !tr.StartsWith("car") {
+		t.Error("Deleting a prefix word should keep longer words")
+	}
+	tr.Delete("cart")
+	tr.Delete("care")
+	if tr.StartsWith("c") || tr.StartsWith("") || tr.Len() != 0 {
+		t.Error("Deleting every word should prune every node")
+	}
+}
+
+func TestEmptyWord(t *testing.T) {
+	tr := Trie.NewTrie()
+	if tr.StartsWith("") {
+		t.Error("An empty trie should not match the empty prefix")
+	}
+	tr.Insert("")
+	if !tr.Search("") || tr.Len() != 1 || !slices.Equal(tr.AllWords(), []string{""}) {
+		t.Error("The empty word should be storable")
+	}
+	if !tr.Delete("") || tr.Len() != 0 {
+		t.Error("The empty word should be deletable")
+	}
+}
+
+func TestWordsWithPrefix(t *testing.T) {
+	tr := Trie.NewTrie()
+	for _, w := range []string{"banana", "band", "ban", "apple", "bandana"} {
+		tr.Insert(w)
+	}
+	if got := tr.WordsWithPrefix("ban"); !slices.Equal(got, []string{"ban", "banana", "band", "bandana"}) {
+		t.Errorf("Unexpected words %v", got)
+	}
+	if got := tr.WordsWithPrefix("c"); got == nil || len(got) != 0 {
+		t.Errorf("Expected an empty non-nil slice, got %v", got)
+	}
+	if got := tr.AllWords(); !slices.Equal(got, []string{"apple", "ban", "banana", "band", "bandana"}) {
+		t.Errorf("Unexpected words %v", got)
+	}
+}
+
+func TestUnicode(t *testing.T) {
+	tr := Trie.NewTrie()
+	for _, w := range []string{"日本", "日本語", "日曜", "naïve", "😀"} {
+		tr.Insert(w)
+	}
+	if got := tr.WordsWithPrefix("日本"); !slices.Equal(got, []string{"日本", "日本語"}) {
+		t.Errorf("Unexpected words %v", got)
+	}
+	// Prefixes are byte sequences, so a partial code point still matches
+	partial := "日"[:2]
+	if !tr.StartsWith(partial) || len(tr.WordsWithPrefix(partial)) != 3 {
+		t.Error("A partial multi-byte prefix should match as bytes")
+	}
+	if !tr.Search("naïve") || tr.Search("naive") || !tr.Search("😀") {
+		t.Error("Multi-byte words should be found exactly")
+	}
+	if !tr.Delete("日本") || !tr.Search("日本語") || tr.Len() != 4 {
+		t.Error("Deleting a multi-byte prefix word should keep longer words")
+	}
+}
+
+// levenshtein is the textbook byte-wise edit distance, used as a reference for FuzzySearch.
+func levenshtein(a, b string) int {
+	prev := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		row := make([]int, len(b)+1)
+		row[0] = i
+		for j := 1; j <= len(b); j++ {
+			sub := prev[j-1]
+			if a[i-1] != b[j-1] {
+				sub++
+			}
+			row[j] = min(prev[j]+1, row[j-1]+1, sub)
+		}
+		prev = row
+	}
+	return prev[len(b)]
+}
+
+func TestFuzzySearch(t *testing.T) {
+	tr := Trie.NewTrie()
+	words := []string{"", "a", "ab", "abc", "bat", "bath", "cat", "cart", "chart", "hat", "that", "kitten", "sitting", "mitten", "日本"}
+	for _, w := range words {
+		tr.Insert(w)
+	}
+	if got := tr.FuzzySearch("cat", 1); !slices.Equal(got, []string{"bat", "cart", "cat", "hat"}) {
+		t.Errorf("Unexpected matches %v", got)
+	}
+	if got := tr.FuzzySearch("cat", 0); !slices.Equal(got, []string{"cat"}) {
+		t.Errorf("maxDist 0 should be an exact match, got %v", got)
+	}
+	if got := tr.FuzzySearch("cat", -1); got == nil || len(got) != 0 {
+		t.Errorf("A negative maxDist should give an empty non-nil slice, got %v", got)
+	}
+
+	for _, query := range []string{"", "a", "cat", "kitten", "sittin", "chat", "日", "xyzzy"} {
+		for maxDist := 0; maxDist <= 3; maxDist++ {
+			var want []string
+			for _, w := range tr.AllWords() {
+				if levenshtein(query, w) <= maxDist {
+					want = append(want, w)
+				}
+			}
+			if got := tr.FuzzySearch(query, maxDist); !slices.Equal(got, want) {
+				t.Errorf("FuzzySearch(%q, %d) expected %q, got %q", query, maxDist, want, got)
+			}
+		}
+	}
+}
+
+func BenchmarkFuzzySearch(b *testing.B) {
+	// 100k distinct four-letter words, the base-26 spellings of 0..99999
+	tr := Trie.NewTrie()
+	for i := 0; i < 100000; i++ {
+		w := []byte("aaaa")
+		for j, n := 3, i; n > 0; j, n = j-1, n/26 {
+			w[j] += byte(n % 26)
+		}
+		tr.Insert(string(w))
+	}
+	for _, maxDist := range []int{1, 2} {
+		b.Run(fmt.Sprintf("maxDist=%d", maxDist), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				tr.FuzzySearch("dogs", maxDist)
+			}
+		})
+	}
+}
+
+func TestClear(t *testing.T) {
+	tr := Trie.NewTrie()
+	tr.Insert("a")
+	tr.Insert("b")
+	tr.Clear()
+	if tr.Len() != 0 || len(tr.AllWords()) != 0 || tr.Search("a") {
+		t.Error("Clear should remove every word")
+	}
+	tr.Insert("c")
+	if !tr.Search("c") {
+		t.Error("Trie should stay usable after Clear")
+	}
+}
+
+func TestConcurrentAccess(t *testing.T) {
+	tr := Trie.NewTrie()
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 200; i++ {
+				w := fmt.Sprintf("g%d-%d", g, i)
+				tr.Insert(w)
+				tr.StartsWith(w[:2])
+				if i%2 == 1 {
+					tr.Delete(w)
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+	if tr.Len() != 800 || len(tr.AllWords()) != 800 {
+		t.Errorf("Expected 800 words, got %d", tr.Len())
+	}
+}
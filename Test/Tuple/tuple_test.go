@@ -0,0 +1,51 @@
+package main_test
+
+import (
+	"testing"
+
+	"GoSTL/Tuple"
+)
+
+func TestNewPair(t *testing.T) {
+	p := Tuple.NewPair(1, "one")
+	if p.First != 1 || p.Second != "one" {
+		t.Errorf("Expected (1, one), got (%d, %s)", p.First, p.Second)
+	}
+}
+
+func TestNewTriple(t *testing.T) {
+	tr := Tuple.NewTriple("a", 2, 3.5)
+	if tr.First != "a" || tr.Second != 2 || tr.Third != 3.5 {
+		t.Errorf("Expected (a, 2, 3.5), got (%s, %d, %v)", tr.First, tr.Second, tr.Third)
+	}
+}
+
+func TestUnzip(t *testing.T) {
+	pairs := []Tuple.Pair[int, string]{
+		Tuple.NewPair(1, "a"),
+		Tuple.NewPair(2, "b"),
+		Tuple.NewPair(3, "c"),
+	}
+	as, bs := Tuple.Unzip(pairs)
+	if len(as) != 3 || len(bs) != 3 {
+		t.Fatalf("Expected lengths 3 and 3, got %d and %d", len(as), len(bs))
+	}
+	for i := range pairs {
+		if as[i] != pairs[i].First || bs[i] != pairs[i].Second {
+			t.Errorf("Index %d expected (%d, %s), got (%d, %s)", i, pairs[i].First, pairs[i].Second, as[i], bs[i])
+		}
+	}
+
+	// Test empty input
+	as, bs = Tuple.Unzip[int, string](nil)
+	if len(as) != 0 || len(bs) != 0 {
+		t.Errorf("Unzip(nil) should return empty slices, got %v and %v", as, bs)
+	}
+}
+
+func TestKeyValue(t *testing.T) {
+	kv := Tuple.KeyValue[string, int]{Key: "x", Value: 42}
+	if kv.Key != "x" || kv.Value != 42 {
+		t.Errorf("Expected (x, 42), got (%s, %d)", kv.Key, kv.Value)
+	}
+}
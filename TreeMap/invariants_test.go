@@ -0,0 +1,117 @@
+package TreeMap
+
+import (
+	"cmp"
+	"math/rand"
+	"testing"
+)
+
+// checkInvariants verifies the binary search tree order, parent links, the red-black coloring rules
+// and the cached size, and returns a description of the first violation found, or "".
+func (m *TreeMap[K, V]) checkInvariants() string {
+	if m.root.color != black {
+		return "root is red"
+	}
+	if m.sentinel.color != black {
+		return "sentinel is red"
+	}
+	count := 0
+	var walk func(n *node[K, V]) (blackHeight int, problem string)
+	walk = func(n *node[K, V]) (int, string) {
+		if n == m.sentinel {
+			return 1, ""
+		}
+		count++
+		if n.color == red && (n.left.color == red || n.right.color == red) {
+			return 0, "red node has a red child"
+		}
+		for _, child := range []*node[K, V]{n.left, n.right} {
+			if child != m.sentinel && child.parent != n {
+				return 0, "broken parent link"
+			}
+		}
+		if n.left != m.sentinel && m.cmp(n.left.key, n.key) >= 0 {
+			return 0, "left child not smaller"
+		}
+		if n.right != m.sentinel && m.cmp(n.right.key, n.key) <= 0 {
+			return 0, "right child not larger"
+		}
+		lh, problem := walk(n.left)
+		if problem != "" {
+			return 0, problem
+		}
+		rh, problem := walk(n.right)
+		if problem != "" {
+			return 0, problem
+		}
+		if lh != rh {
+			return 0, "unequal black heights"
+		}
+		if n.color == black {
+			lh++
+		}
+		return lh, ""
+	}
+	if _, problem := walk(m.root); problem != "" {
+		return problem
+	}
+	if m.root != m.sentinel && m.root.parent != m.sentinel {
+		return "root parent is not the sentinel"
+	}
+	if count != m.size {
+		return "size does not match the node count"
+	}
+	return ""
+}
+
+func TestInvariantsAfterEveryMutation(t *testing.T) {
+	for _, seed := range []int64{1, 2, 3} {
+		r := rand.New(rand.NewSource(seed))
+		m := NewTreeMap[int, int](cmp.Compare[int])
+		ref := make(map[int]int)
+		for i := 0; i < 5000; i++ {
+			key := r.Intn(500)
+			if r.Intn(3) == 0 {
+				_, present := ref[key]
+				if m.Delete(key) != present {
+					t.Fatalf("Seed %d step %d: Delete(%d) disagrees with the reference", seed, i, key)
+				}
+				delete(ref, key)
+			} else {
+				m.Put(key, i)
+				ref[key] = i
+			}
+			if problem := m.checkInvariants(); problem != "" {
+				t.Fatalf("Seed %d step %d: %s", seed, i, problem)
+			}
+		}
+		if m.Len() != len(ref) {
+			t.Fatalf("Seed %d: expected %d entries, got %d", seed, len(ref), m.Len())
+		}
+	}
+}
+
+func TestInvariantsSequentialInsertDelete(t *testing.T) {
+	m := NewTreeMap[int, struct{}](cmp.Compare[int])
+	for i := 0; i < 1024; i++ {
+		m.Put(i, struct{}{})
+		if problem := m.checkInvariants(); problem != "" {
+			t.Fatalf("Ascending insert %d: %s", i, problem)
+		}
+	}
+	for i := 1023; i >= 0; i -= 2 {
+		m.Delete(i)
+		if problem := m.checkInvariants(); problem != "" {
+			t.Fatalf("Descending delete %d: %s", i, problem)
+		}
+	}
+	for i := 0; i < 1024; i += 2 {
+		m.Delete(i)
+		if problem := m.checkInvariants(); problem != "" {
+			t.Fatalf("Ascending delete %d: %s", i, problem)
+		}
+	}
+	if m.Len() != 0 || m.root != m.sentinel {
+		t.Error("Tree should be empty")
+	}
+}
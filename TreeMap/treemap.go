@@ -0,0 +1,465 @@
+package TreeMap
+
+import (
+	"iter"
+	"sync"
+
+	"GoSTL/Tuple"
+)
+
+// color is the color of a red-black tree node.
+type color bool
+
+const (
+	red   color = false
+	black color = true
+)
+
+// TreeMap is a thread-safe map that keeps its keys sorted according to a comparator.
+// It is a red-black tree, so lookups, insertions and deletions take O(log n) time, and it supports
+// ordered queries such as Min, Max, Floor and Ceiling as well as ascending and descending traversal.
+// Reads take a shared lock; writes take an exclusive lock.
+type TreeMap[K, V any] struct {
+	mu       sync.RWMutex     // guards all fields below
+	root     *node[K, V]      // root of the tree, the sentinel if empty
+	sentinel *node[K, V]      // black sentinel standing in for every leaf and the root's parent
+	cmp      func(a, b K) int // key order
+	size     int              // number of entries
+}
+
+// node is a red-black tree node.
+type node[K, V any] struct {
+	key                 K
+	value               V
+	left, right, parent *node[K, V]
+	color               color
+}
+
+// NewTreeMap creates an empty map ordered by cmp, which returns a negative number when a < b,
+// zero when a == b and a positive number when a > b.
+func NewTreeMap[K, V any](cmp func(a, b K) int) *TreeMap[K, V] {
+	s := &node[K, V]{color: black}
+	return &TreeMap[K, V]{root: s, sentinel: s, cmp: cmp}
+}
+
+// find returns the node holding key, or the sentinel (must be called with lock held).
+func (m *TreeMap[K, V]) find(key K) *node[K, V] {
+	n := m.root
+	for n != m.sentinel {
+		c := m.cmp(key, n.key)
+		switch {
+		case c < 0:
+			n = n.left
+		case c > 0:
+			n = n.right
+		default:
+			return n
+		}
+	}
+	return n
+}
+
+// rotateLeft rotates the subtree rooted at x to the left (must be called with lock held).
+func (m *TreeMap[K, V]) rotateLeft(x *node[K, V]) {
+	y := x.right
+	x.right = y.left
+	if y.left != m.sentinel {
+		y.left.parent = x
+	}
+	m.replaceChild(x, y)
+	y.left = x
+	x.parent = y
+}
+
+// rotateRight rotates the subtree rooted at x to the right (must be called with lock held).
+func (m *TreeMap[K, V]) rotateRight(x *node[K, V]) {
+	y := x.left
+	x.left = y.right
+	if y.right != m.sentinel {
+		y.right.parent = x
+	}
+	m.replaceChild(x, y)
+	y.right = x
+	x.parent = y
+}
+
+// replaceChild makes v take u's place under u's parent (must be called with lock held).
+func (m *TreeMap[K, V]) replaceChild(u, v *node[K, V]) {
+	switch {
+	case u.parent == m.sentinel:
+		m.root = v
+	case u == u.parent.left:
+		u.parent.left = v
+	default:
+		u.parent.right = v
+	}
+	v.parent = u.parent
+}
+
+// Put stores val under key, replacing any existing value.
+func (m *TreeMap[K, V]) Put(key K, val V) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	parent, n := m.sentinel, m.root
+	c := 0
+	for n != m.sentinel {
+		parent = n
+		c = m.cmp(key, n.key)
+		switch {
+		case c < 0:
+			n = n.left
+		case c > 0:
+			n = n.right
+		default:
+			n.value = val
+			return
+		}
+	}
+
+	z := &node[K, V]{key: key, value: val, left: m.sentinel, right: m.sentinel, parent: parent, color: red}
+	switch {
+	case parent == m.sentinel:
+		m.root = z
+	case c < 0:
+		parent.left = z
+	default:
+		parent.right = z
+	}
+	m.size++
+	m.insertFixup(z)
+}
+
+// insertFixup restores the red-black properties after inserting the red node z (must be called with lock held).
+func (m *TreeMap[K, V]) insertFixup(z *node[K, V]) {
+	for z.parent.color == red {
+		grand := z.parent.parent
+		if z.parent == grand.left {
+			uncle := grand.right
+			if uncle.color == red {
+				// Red uncle: push the grandparent's blackness down and continue from the grandparent
+				z.parent.color, uncle.color, grand.color = black, black, red
+				z = grand
+				continue
+			}
+			if z == z.parent.right {
+				z = z.parent
+				m.rotateLeft(z)
+			}
+			z.parent.color, grand.color = black, red
+			m.rotateRight(grand)
+		} else {
+			uncle := grand.left
+			if uncle.color == red {
+				z.parent.color, uncle.color, grand.color = black, black, red
+				z = grand
+				continue
+			}
+			if z == z.parent.left {
+				z = z.parent
+				m.rotateRight(z)
+			}
+			z.parent.color, grand.color = black, red
+			m.rotateLeft(grand)
+		}
+	}
+	m.root.color = black
+}
+
+// Get returns the value stored under key and whether it was present.
+func (m *TreeMap[K, V]) Get(key K) (V, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if n := m.find(key); n != m.sentinel {
+		return n.value, true
+	}
+	var zero V
+	return zero, false
+}
+
+// Delete removes key from the map. Returns true if it was present.
+func (m *TreeMap[K, V]) Delete(key K) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	z := m.find(key)
+	if z == m.sentinel {
+		return false
+	}
+
+	// y is the node actually unlinked from the tree and x the node that takes its place
+	y, yColor := z, z.color
+	var x *node[K, V]
+	switch {
+	case z.left == m.sentinel:
+		x = z.right
+		m.replaceChild(z, x)
+	case z.right == m.sentinel:
+		x = z.left
+		m.replaceChild(z, x)
+	default:
+		y = m.minNode(z.right)
+		yColor = y.color
+		x = y.right
+		if y.parent == z {
+			x.parent = y
+		} else {
+			m.replaceChild(y, x)
+			y.right = z.right
+			y.right.parent = y
+		}
+		m.replaceChild(z, y)
+		y.left = z.left
+		y.left.parent = y
+		y.color = z.color
+	}
+	if yColor == black {
+		m.deleteFixup(x)
+	}
+	m.sentinel.parent = nil
+	z.left, z.right, z.parent = nil, nil, nil
+	m.size--
+	return true
+}
+
+// deleteFixup restores the red-black properties after a black node was removed above x
+// (must be called with lock held).
+func (m *TreeMap[K, V]) deleteFixup(x *node[K, V]) {
+	for x != m.root && x.color == black {
+		if x == x.parent.left {
+			w := x.parent.right
+			if w.color == red {
+				w.color, x.parent.color = black, red
+				m.rotateLeft(x.parent)
+				w = x.parent.right
+			}
+			if w.left.color == black && w.right.color == black {
+				w.color = red
+				x = x.parent
+				continue
+			}
+			if w.right.color == black {
+				w.left.color, w.color = black, red
+				m.rotateRight(w)
+				w = x.parent.right
+			}
+			w.color, x.parent.color, w.right.color = x.parent.color, black, black
+			m.rotateLeft(x.parent)
+			x = m.root
+		} else {
+			w := x.parent.left
+			if w.color == red {
+				w.color, x.parent.color = black, red
+				m.rotateRight(x.parent)
+				w = x.parent.left
+			}
+			if w.right.color == black && w.left.color == black {
+				w.color = red
+				x = x.parent
+				continue
+			}
+			if w.left.color == black {
+				w.right.color, w.color = black, red
+				m.rotateLeft(w)
+				w = x.parent.left
+			}
+			w.color, x.parent.color, w.left.color = x.parent.color, black, black
+			m.rotateRight(x.parent)
+			x = m.root
+		}
+	}
+	x.color = black
+}
+
+// minNode returns the leftmost node of the non-empty subtree rooted at n.
+func (m *TreeMap[K, V]) minNode(n *node[K, V]) *node[K, V] {
+	for n.left != m.sentinel {
+		n = n.left
+	}
+	return n
+}
+
+// maxNode returns the rightmost node of the non-empty subtree rooted at n.
+func (m *TreeMap[K, V]) maxNode(n *node[K, V]) *node[K, V] {
+	for n.right != m.sentinel {
+		n = n.right
+	}
+	return n
+}
+
+// entry returns the key and value of n, and false if n is the sentinel.
+func (m *TreeMap[K, V]) entry(n *node[K, V]) (K, V, bool) {
+	if n == m.sentinel {
+		var key K
+		var val V
+		return key, val, false
+	}
+	return n.key, n.value, true
+}
+
+// Min returns the entry with the smallest key.
+func (m *TreeMap[K, V]) Min() (K, V, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if m.root == m.sentinel {
+		return m.entry(m.sentinel)
+	}
+	return m.entry(m.minNode(m.root))
+}
+
+// Max returns the entry with the largest key.
+func (m *TreeMap[K, V]) Max() (K, V, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if m.root == m.sentinel {
+		return m.entry(m.sentinel)
+	}
+	return m.entry(m.maxNode(m.root))
+}
+
+// Floor returns the entry with the largest key less than or equal to key.
+func (m *TreeMap[K, V]) Floor(key K) (K, V, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	best := m.sentinel
+	for n := m.root; n != m.sentinel; {
+		c := m.cmp(key, n.key)
+		if c == 0 {
+			return m.entry(n)
+		}
+		if c > 0 {
+			best = n
+			n = n.right
+		} else {
+			n = n.left
+		}
+	}
+	return m.entry(best)
+}
+
+// Ceiling returns the entry with the smallest key greater than or equal to key.
+func (m *TreeMap[K, V]) Ceiling(key K) (K, V, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	best := m.sentinel
+	for n := m.root; n != m.sentinel; {
+		c := m.cmp(key, n.key)
+		if c == 0 {
+			return m.entry(n)
+		}
+		if c < 0 {
+			best = n
+			n = n.left
+		} else {
+			n = n.right
+		}
+	}
+	return m.entry(best)
+}
+
+// snapshot returns the entries in ascending key order.
+func (m *TreeMap[K, V]) snapshot() []Tuple.KeyValue[K, V] {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	entries := make([]Tuple.KeyValue[K, V], 0, m.size)
+	m.walk(false, func(n *node[K, V]) bool {
+		entries = append(entries, Tuple.KeyValue[K, V]{Key: n.key, Value: n.value})
+		return true
+	})
+	return entries
+}
+
+// walk visits the nodes in ascending key order, or descending if reverse is set, until visit returns false
+// (must be called with lock held).
+func (m *TreeMap[K, V]) walk(reverse bool, visit func(n *node[K, V]) bool) {
+	near, far := func(n *node[K, V]) *node[K, V] { return n.left }, func(n *node[K, V]) *node[K, V] { return n.right }
+	if reverse {
+		near, far = far, near
+	}
+	stack := make([]*node[K, V], 0, 64)
+	for n := m.root; n != m.sentinel || len(stack) > 0; {
+		for ; n != m.sentinel; n = near(n) {
+			stack = append(stack, n)
+		}
+		n = stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if !visit(n) {
+			return
+		}
+		n = far(n)
+	}
+}
+
+// ForEach calls fn with every entry in ascending key order until fn returns false.
+// Unlike ForEachAscending it copies nothing: the read lock is held throughout, so fn must not modify the map.
+func (m *TreeMap[K, V]) ForEach(fn func(K, V) bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	m.walk(false, func(n *node[K, V]) bool { return fn(n.key, n.value) })
+}
+
+// ForEachReverse calls fn with every entry in descending key order until fn returns false.
+// The read lock is held throughout, so fn must not modify the map.
+func (m *TreeMap[K, V]) ForEachReverse(fn func(K, V) bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	m.walk(true, func(n *node[K, V]) bool { return fn(n.key, n.value) })
+}
+
+// ForEachAscending calls fn with every entry in ascending key order.
+// It iterates over a snapshot, so fn may modify the map.
+func (m *TreeMap[K, V]) ForEachAscending(fn func(K, V)) {
+	for _, e := range m.snapshot() {
+		fn(e.Key, e.Value)
+	}
+}
+
+// ForEachDescending calls fn with every entry in descending key order.
+// It iterates over a snapshot, so fn may modify the map.
+func (m *TreeMap[K, V]) ForEachDescending(fn func(K, V)) {
+	entries := m.snapshot()
+	for i := len(entries) - 1; i >= 0; i-- {
+		fn(entries[i].Key, entries[i].Value)
+	}
+}
+
+// All returns an iterator over the entries in ascending key order.
+// It iterates over a snapshot taken when iteration starts, so the loop body may modify the map.
+func (m *TreeMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for _, e := range m.snapshot() {
+			if !yield(e.Key, e.Value) {
+				return
+			}
+		}
+	}
+}
+
+// Keys returns the keys in ascending order.
+func (m *TreeMap[K, V]) Keys() []K {
+	entries := m.snapshot()
+	keys := make([]K, len(entries))
+	for i, e := range entries {
+		keys[i] = e.Key
+	}
+	return keys
+}
+
+// Len returns the number of entries in the map.
+func (m *TreeMap[K, V]) Len() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.size
+}
+
+// Clear removes all entries.
+func (m *TreeMap[K, V]) Clear() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.root = m.sentinel
+	m.size = 0
+}
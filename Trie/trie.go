@@ -0,0 +1,205 @@
+package Trie
+
+import "sync"
+
+// Trie is a thread-safe prefix tree of strings. Strings are treated as byte sequences, so multi-byte
+// UTF-8 characters take one level per byte. Each node indexes its children directly by byte, which makes
+// every step O(1) at the cost of a 256-entry array per node. Reads take a shared lock; writes take an
+// exclusive lock.
+type Trie struct {
+	mu   sync.RWMutex // guards all fields below
+	root *TrieNode    // root node, standing for the empty prefix
+	size int          // number of words
+}
+
+// TrieNode is a trie node. The path from the root to a node spells out its prefix.
+// Its fields are managed by Trie and are not accessible outside the package.
+type TrieNode struct {
+	children [256]*TrieNode // child for each next byte
+	count    int            // number of non-nil children
+	word     bool           // true if the prefix spelled out by this node is a word
+}
+
+// NewTrie creates an empty Trie.
+func NewTrie() *Trie {
+	return &Trie{root: &TrieNode{}}
+}
+
+// find returns the node spelling out prefix, or nil (must be called with lock held).
+func (t *Trie) find(prefix string) *TrieNode {
+	n := t.root
+	for i := 0; i < len(prefix) && n != nil; i++ {
+		n = n.children[prefix[i]]
+	}
+	return n
+}
+
+// Insert adds word to the trie. Inserting a word already present has no effect.
+func (t *Trie) Insert(word string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	n := t.root
+	for i := 0; i < len(word); i++ {
+		c := word[i]
+		if n.children[c] == nil {
+			n.children[c] = &TrieNode{}
+			n.count++
+		}
+		n = n.children[c]
+	}
+	if !n.word {
+		n.word = true
+		t.size++
+	}
+}
+
+// Search reports whether word was inserted.
+func (t *Trie) Search(word string) bool {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	n := t.find(word)
+	return n != nil && n.word
+}
+
+// StartsWith reports whether any word begins with prefix. Every trie starts with the empty prefix
+// once it holds at least one word.
+func (t *Trie) StartsWith(prefix string) bool {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	n := t.find(prefix)
+	return n != nil && (n.word || n.count > 0)
+}
+
+// Delete removes word from the trie and prunes the nodes no other word needs.
+// Returns true if word was present.
+func (t *Trie) Delete(word string) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	// Record the path so that emptied nodes can be unlinked from the bottom up
+	path := make([]*TrieNode, 0, len(word)+1)
+	n := t.root
+	for i := 0; i < len(word) && n != nil; i++ {
+		path = append(path, n)
+		n = n.children[word[i]]
+	}
+	if n == nil || !n.word {
+		return false
+	}
+	n.word = false
+	t.size--
+
+	for i := len(word) - 1; i >= 0 && !n.word && n.count == 0; i-- {
+		parent := path[i]
+		parent.children[word[i]] = nil
+		parent.count--
+		n = parent
+	}
+	return true
+}
+
+// collect appends to out every word below n, which spells out prefix, in byte order (must be called with lock held).
+func collect(n *TrieNode, prefix []byte, out []string) []string {
+	if n.word {
+		out = append(out, string(prefix))
+	}
+	for c, child := range n.children {
+		if child != nil {
+			out = collect(child, append(prefix, byte(c)), out)
+		}
+	}
+	return out
+}
+
+// WordsWithPrefix returns the words beginning with prefix in byte-wise lexicographic order.
+func (t *Trie) WordsWithPrefix(prefix string) []string {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	out := make([]string, 0)
+	if n := t.find(prefix); n != nil {
+		out = collect(n, []byte(prefix), out)
+	}
+	return out
+}
+
+// AllWords returns every word in byte-wise lexicographic order.
+func (t *Trie) AllWords() []string {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return collect(t.root, nil, make([]string, 0, t.size))
+}
+
+// fuzzy appends to out every word below n within maxDist edits of query, in byte order. n spells out prefix and
+// prev is the edit distance row for it: prev[j] is the distance from prefix to query[:j]. A child is only
+// descended into while some entry of its row is within maxDist, since rows never decrease along a path
+// (must be called with lock held).
+func fuzzy(n *TrieNode, query string, maxDist int, prefix []byte, prev []int, out []string) []string {
+	row := make([]int, len(prev))
+	for c, child := range n.children {
+		if child == nil {
+			continue
+		}
+		row[0] = prev[0] + 1
+		best := row[0]
+		for j := 1; j < len(row); j++ {
+			sub := prev[j-1]
+			if query[j-1] != byte(c) {
+				sub++
+			}
+			row[j] = min(prev[j]+1, row[j-1]+1, sub)
+			best = min(best, row[j])
+		}
+		if best > maxDist {
+			continue
+		}
+		word := append(prefix, byte(c))
+		if child.word && row[len(row)-1] <= maxDist {
+			out = append(out, string(word))
+		}
+		if child.count > 0 {
+			out = fuzzy(child, query, maxDist, word, row, out)
+		}
+	}
+	return out
+}
+
+// FuzzySearch returns the words within Levenshtein distance maxDist of query in byte-wise lexicographic order.
+// Distances count byte insertions, deletions and substitutions. The search walks the trie once, keeping one row
+// of the edit distance table per level, and abandons a branch as soon as no extension of it can come within
+// maxDist, so its cost depends on how much of the trie lies near query rather than on the number of words.
+func (t *Trie) FuzzySearch(query string, maxDist int) []string {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	out := make([]string, 0)
+	if maxDist < 0 {
+		return out
+	}
+	row := make([]int, len(query)+1)
+	for j := range row {
+		row[j] = j
+	}
+	if t.root.word && len(query) <= maxDist {
+		out = append(out, "")
+	}
+	return fuzzy(t.root, query, maxDist, make([]byte, 0, len(query)+maxDist), row, out)
+}
+
+// Len returns the number of words in the trie.
+func (t *Trie) Len() int {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.size
+}
+
+// Clear removes all words.
+func (t *Trie) Clear() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.root = &TrieNode{}
+	t.size = 0
+}
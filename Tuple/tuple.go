@@ -0,0 +1,43 @@
+package Tuple
+
+// Pair holds two values of possibly different types.
+type Pair[A, B any] struct {
+	First  A // first element of the pair
+	Second B // second element of the pair
+}
+
+// Triple holds three values of possibly different types.
+type Triple[A, B, C any] struct {
+	First  A // first element of the triple
+	Second B // second element of the triple
+	Third  C // third element of the triple
+}
+
+// KeyValue is the key-value entry type shared by the associative containers
+// when they expose their contents for iteration.
+type KeyValue[K, V any] struct {
+	Key   K // entry key
+	Value V // entry value
+}
+
+// NewPair creates a Pair from the given values.
+func NewPair[A, B any](a A, b B) Pair[A, B] {
+	return Pair[A, B]{First: a, Second: b}
+}
+
+// NewTriple creates a Triple from the given values.
+func NewTriple[A, B, C any](a A, b B, c C) Triple[A, B, C] {
+	return Triple[A, B, C]{First: a, Second: b, Third: c}
+}
+
+// Unzip splits a slice of pairs into a slice of first elements and a slice of second elements.
+// Both returned slices have the same length as pairs and preserve its order.
+func Unzip[A, B any](pairs []Pair[A, B]) ([]A, []B) {
+	as := make([]A, len(pairs))
+	bs := make([]B, len(pairs))
+	for i, p := range pairs {
+		as[i] = p.First
+		bs[i] = p.Second
+	}
+	return as, bs
+}
@@ -0,0 +1,347 @@
+// Package codec implements the binary format shared by the GoSTL containers' MarshalBinary and UnmarshalBinary
+// methods, so that every container writes the same payload layout and accepts the same element types.
+//
+// A payload is a fixed header (version byte, int64 length, int64 initial capacity, uint64 element type hash)
+// followed by the elements in the container's natural order. Booleans, integers, floats and complex numbers are
+// written at a fixed width; strings and slices are length-prefixed; arrays and structs are written field by field.
+//
+// Element types this compact format cannot represent (pointers, maps, interfaces, and composites containing them
+// or structs with unexported fields) are written as a stream of encoding/gob values instead, marked by a
+// different version byte. gob's rules apply to them: unexported struct fields are skipped, nil pointers cannot be
+// encoded and concrete types stored in interfaces must be registered with gob.Register. Channels and functions
+// are not supported by either format.
+// Errors carry no container prefix; callers wrap them with their own.
+package codec
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"math"
+	"reflect"
+)
+
+// Version bytes written at the start of every payload, one per element encoding.
+const (
+	version    = 1 // elements in the compact binary format
+	gobVersion = 2 // elements as a stream of gob values
+)
+
+// payloadVersion returns the version byte used for payloads of T.
+func payloadVersion[T any]() byte {
+	if compact(reflect.TypeFor[T](), map[reflect.Type]bool{}) {
+		return version
+	}
+	return gobVersion
+}
+
+// compact reports whether values of t can be written in the compact binary format.
+// seen holds the types being checked, so that recursive types such as struct{ Kids []Node } terminate.
+func compact(t reflect.Type, seen map[reflect.Type]bool) bool {
+	if seen[t] {
+		return true
+	}
+	switch t.Kind() {
+	case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128, reflect.String:
+		return true
+	case reflect.Slice, reflect.Array:
+		seen[t] = true
+		return compact(t.Elem(), seen)
+	case reflect.Struct:
+		seen[t] = true
+		for i := 0; i < t.NumField(); i++ {
+			if !t.Field(i).IsExported() || !compact(t.Field(i).Type, seen) {
+				return false
+			}
+		}
+		return true
+	default:
+		return false
+	}
+}
+
+// Encode returns the payload for length elements, where elem(i) returns the i-th element in the container's
+// natural order, and the container's initial capacity.
+func Encode[T any](length, initCap int, elem func(i int) T) ([]byte, error) {
+	var buf bytes.Buffer
+	v := payloadVersion[T]()
+	buf.WriteByte(v)
+	var hdr [24]byte
+	binary.LittleEndian.PutUint64(hdr[0:], uint64(length))
+	binary.LittleEndian.PutUint64(hdr[8:], uint64(initCap))
+	binary.LittleEndian.PutUint64(hdr[16:], typeHash[T]())
+	buf.Write(hdr[:])
+
+	if v == gobVersion {
+		enc := gob.NewEncoder(&buf)
+		for i := 0; i < length; i++ {
+			// A pointer to the element lets gob encode interface elements with their concrete type
+			val := elem(i)
+			if rv := reflect.ValueOf(&val).Elem(); rv.Kind() == reflect.Pointer && rv.IsNil() {
+				// gob would silently write nothing, leaving a payload that cannot be decoded
+				return nil, fmt.Errorf("cannot encode nil pointer element %d", i)
+			}
+			if err := enc.Encode(&val); err != nil {
+				return nil, fmt.Errorf("encoding element %d: %w", i, err)
+			}
+		}
+		return buf.Bytes(), nil
+	}
+	for i := 0; i < length; i++ {
+		val := elem(i)
+		if err := encodeValue(&buf, reflect.ValueOf(&val).Elem()); err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// defaultCap is the initial capacity of the GoSTL containers when none is given.
+const defaultCap = 8
+
+// Decode parses a payload produced by Encode for the same element type and returns the elements in their encoded
+// order together with the encoded initial capacity. If maxLen is positive, payloads holding more than maxLen
+// elements are rejected before any element is decoded. The initial capacity is not trusted beyond
+// max(len(elems), 8), so that a small payload cannot make the caller allocate a large backing array; it is also
+// limited to maxLen. The returned slice has spare capacity for max(initCap, len(elems)) elements, so callers can
+// adopt it as their backing array.
+func Decode[T any](b []byte, maxLen int) (elems []T, initCap int, err error) {
+	r := bytes.NewReader(b)
+	v, err := r.ReadByte()
+	if err != nil {
+		return nil, 0, fmt.Errorf("reading header: %w", err)
+	}
+	if v != payloadVersion[T]() {
+		return nil, 0, fmt.Errorf("unsupported binary version %d", v)
+	}
+	var hdr [24]byte
+	if _, err := io.ReadFull(r, hdr[:]); err != nil {
+		return nil, 0, fmt.Errorf("reading header: %w", err)
+	}
+	length := binary.LittleEndian.Uint64(hdr[0:])
+	capHint := binary.LittleEndian.Uint64(hdr[8:])
+	if binary.LittleEndian.Uint64(hdr[16:]) != typeHash[T]() {
+		return nil, 0, fmt.Errorf("element type mismatch, expected %v", reflect.TypeFor[T]())
+	}
+	if length > math.MaxInt32 || capHint > math.MaxInt32 {
+		return nil, 0, errors.New("length or capacity out of range")
+	}
+	if reflect.TypeFor[T]().Size() > 0 && length > uint64(r.Len()) {
+		return nil, 0, io.ErrUnexpectedEOF
+	}
+	if maxLen > 0 && length > uint64(maxLen) {
+		return nil, 0, fmt.Errorf("%d elements exceed max capacity %d", length, maxLen)
+	}
+
+	initCap = min(int(capHint), max(int(length), defaultCap))
+	if maxLen > 0 && initCap > maxLen {
+		initCap = maxLen
+	}
+	elems = make([]T, length, max(initCap, int(length)))
+	if v == gobVersion {
+		// bytes.Reader is an io.ByteReader, so the decoder reads no further than the values it decodes
+		dec := gob.NewDecoder(r)
+		for i := range elems {
+			if err := dec.Decode(&elems[i]); err != nil {
+				if errors.Is(err, io.EOF) {
+					err = io.ErrUnexpectedEOF
+				}
+				return nil, 0, fmt.Errorf("decoding element %d: %w", i, err)
+			}
+		}
+	} else {
+		for i := range elems {
+			if err := decodeValue(r, reflect.ValueOf(&elems[i]).Elem()); err != nil {
+				return nil, 0, err
+			}
+		}
+	}
+	if r.Len() != 0 {
+		return nil, 0, fmt.Errorf("%d trailing bytes", r.Len())
+	}
+	return elems, initCap, nil
+}
+
+// typeHash returns a hash of T's type name, used to reject payloads encoded for a different element type.
+func typeHash[T any]() uint64 {
+	h := fnv.New64a()
+	_, _ = io.WriteString(h, reflect.TypeFor[T]().String())
+	return h.Sum64()
+}
+
+// encodeValue appends the binary encoding of v to buf.
+func encodeValue(buf *bytes.Buffer, v reflect.Value) error {
+	var scratch [binary.MaxVarintLen64]byte
+	switch v.Kind() {
+	case reflect.Bool:
+		if v.Bool() {
+			buf.WriteByte(1)
+		} else {
+			buf.WriteByte(0)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		buf.Write(binary.LittleEndian.AppendUint64(scratch[:0], uint64(v.Int())))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		buf.Write(binary.LittleEndian.AppendUint64(scratch[:0], v.Uint()))
+	case reflect.Float32, reflect.Float64:
+		buf.Write(binary.LittleEndian.AppendUint64(scratch[:0], math.Float64bits(v.Float())))
+	case reflect.Complex64, reflect.Complex128:
+		c := v.Complex()
+		buf.Write(binary.LittleEndian.AppendUint64(scratch[:0], math.Float64bits(real(c))))
+		buf.Write(binary.LittleEndian.AppendUint64(scratch[:0], math.Float64bits(imag(c))))
+	case reflect.String:
+		s := v.String()
+		buf.Write(binary.AppendUvarint(scratch[:0], uint64(len(s))))
+		buf.WriteString(s)
+	case reflect.Slice:
+		// Length is written as n+1 so that 0 can mark a nil slice
+		if v.IsNil() {
+			buf.WriteByte(0)
+			return nil
+		}
+		buf.Write(binary.AppendUvarint(scratch[:0], uint64(v.Len())+1))
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			buf.Write(v.Bytes())
+			return nil
+		}
+		for i := 0; i < v.Len(); i++ {
+			if err := encodeValue(buf, v.Index(i)); err != nil {
+				return err
+			}
+		}
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if err := encodeValue(buf, v.Index(i)); err != nil {
+				return err
+			}
+		}
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < v.NumField(); i++ {
+			if !t.Field(i).IsExported() {
+				return fmt.Errorf("cannot encode unexported field %s of %v", t.Field(i).Name, t)
+			}
+			if err := encodeValue(buf, v.Field(i)); err != nil {
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("cannot encode element of type %v", v.Type())
+	}
+	return nil
+}
+
+// decodeValue reads the binary encoding produced by encodeValue from r into v.
+func decodeValue(r *bytes.Reader, v reflect.Value) error {
+	readUint64 := func() (uint64, error) {
+		var b [8]byte
+		if _, err := io.ReadFull(r, b[:]); err != nil {
+			return 0, io.ErrUnexpectedEOF
+		}
+		return binary.LittleEndian.Uint64(b[:]), nil
+	}
+	readLen := func() (int, error) {
+		n, err := binary.ReadUvarint(r)
+		if err != nil {
+			return 0, io.ErrUnexpectedEOF
+		}
+		if n > uint64(r.Len())+1 {
+			return 0, io.ErrUnexpectedEOF
+		}
+		return int(n), nil
+	}
+
+	switch v.Kind() {
+	case reflect.Bool:
+		b, err := r.ReadByte()
+		if err != nil {
+			return io.ErrUnexpectedEOF
+		}
+		v.SetBool(b != 0)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		u, err := readUint64()
+		if err != nil {
+			return err
+		}
+		v.SetInt(int64(u))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		u, err := readUint64()
+		if err != nil {
+			return err
+		}
+		v.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		u, err := readUint64()
+		if err != nil {
+			return err
+		}
+		v.SetFloat(math.Float64frombits(u))
+	case reflect.Complex64, reflect.Complex128:
+		re, err := readUint64()
+		if err != nil {
+			return err
+		}
+		im, err := readUint64()
+		if err != nil {
+			return err
+		}
+		v.SetComplex(complex(math.Float64frombits(re), math.Float64frombits(im)))
+	case reflect.String:
+		n, err := readLen()
+		if err != nil {
+			return err
+		}
+		b := make([]byte, n)
+		if _, err := io.ReadFull(r, b); err != nil {
+			return io.ErrUnexpectedEOF
+		}
+		v.SetString(string(b))
+	case reflect.Slice:
+		n, err := readLen()
+		if err != nil {
+			return err
+		}
+		if n == 0 {
+			v.SetZero()
+			return nil
+		}
+		s := reflect.MakeSlice(v.Type(), n-1, n-1)
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			if _, err := io.ReadFull(r, s.Bytes()); err != nil {
+				return io.ErrUnexpectedEOF
+			}
+		} else {
+			for i := 0; i < n-1; i++ {
+				if err := decodeValue(r, s.Index(i)); err != nil {
+					return err
+				}
+			}
+		}
+		v.Set(s)
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if err := decodeValue(r, v.Index(i)); err != nil {
+				return err
+			}
+		}
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < v.NumField(); i++ {
+			if !t.Field(i).IsExported() {
+				return fmt.Errorf("cannot decode unexported field %s of %v", t.Field(i).Name, t)
+			}
+			if err := decodeValue(r, v.Field(i)); err != nil {
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("cannot decode element of type %v", v.Type())
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+// Package nodepool recycles the nodes of the lock-free containers through a sync.Pool without exposing them to
+// the ABA problem.
+//
+// ABA: a compare-and-swap on a node pointer is fooled if the node is unlinked, reused and linked in again while
+// another goroutine still holds it as the expected value; the CAS then succeeds against a node whose contents
+// have changed. Freshly allocated nodes rule this out, because the garbage collector only frees a node once no
+// goroutine references it. Recycled nodes do not have that guarantee, so a Pool only recycles a node when it can
+// prove that nobody else holds it: every container operation is bracketed by Enter and Exit, and a node unlinked
+// by the only operation in flight cannot be referenced by any other goroutine, since operations that start later
+// can no longer reach it. A node unlinked while other operations are running is left to the garbage collector.
+//
+// Tagged pointers are not used: hiding a version counter in the bits of a pointer hides the pointer from the
+// garbage collector.
+package nodepool
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// Pool hands out and recycles nodes of type N. The zero value is ready to use.
+type Pool[N any] struct {
+	active atomic.Int64 // number of operations between Enter and Exit
+	nodes  sync.Pool    // recycled nodes, reset to their zero value
+}
+
+// Enter registers the start of an operation that may load node pointers. It must be paired with Exit.
+func (p *Pool[N]) Enter() {
+	p.active.Add(1)
+}
+
+// Exit registers the end of an operation started with Enter; the operation must not use any node after it.
+func (p *Pool[N]) Exit() {
+	p.active.Add(-1)
+}
+
+// Get returns a recycled node, reset to its zero value, or a new one if none is available.
+func (p *Pool[N]) Get() *N {
+	if n, ok := p.nodes.Get().(*N); ok {
+		return n
+	}
+	return new(N)
+}
+
+// Retire offers n for reuse. The caller must have unlinked n, so that no operation starting from now on can
+// reach it. n is reset and recycled only if the caller's operation is the only one in flight; otherwise it is
+// left untouched to the garbage collector, since other operations may still read it. Returns true if n was recycled.
+func (p *Pool[N]) Retire(n *N) bool {
+	if p.active.Load() != 1 {
+		return false
+	}
+	var zero N
+	*n = zero
+	p.nodes.Put(n)
+	return true
+}
@@ -0,0 +1,53 @@
+// Package stl is an umbrella package that gathers the GoSTL containers behind a single import.
+// It re-exports the container constructors and defines Container, the interface every container satisfies,
+// so that algorithms and test helpers can be written once for all of them.
+package stl
+
+import (
+	"GoSTL/Deque"
+	"GoSTL/LinkedList"
+	"GoSTL/PriorityQueue"
+	queue "GoSTL/Queue"
+	"GoSTL/Stack"
+)
+
+// Container is the common read/clear interface implemented by every GoSTL container.
+// ToSlice and ForEach visit elements in removal order: front to back for deques, queues and lists,
+// top to bottom for stacks and by priority for priority queues.
+type Container[T any] interface {
+	Len() int                // number of elements
+	Empty() bool             // true if there are no elements
+	Clear()                  // removes all elements
+	ToSlice() []T            // copy of the elements in removal order
+	ForEach(fn func(int, T)) // calls fn with each index and element in removal order
+}
+
+// Compile-time checks that the containers implement Container.
+var (
+	_ Container[int] = (*Deque.Deque[int])(nil)
+	_ Container[int] = (*Stack.Stack[int])(nil)
+	_ Container[int] = (*queue.Queue[int])(nil)
+	_ Container[int] = (*PriorityQueue.PriorityQueue[int])(nil)
+	_ Container[int] = (*LinkedList.LinkedList[int])(nil)
+)
+
+// NewDeque creates a new Deque with an optional initial capacity. See Deque.NewDeque.
+func NewDeque[T any](initCap ...int) *Deque.Deque[T] {
+	return Deque.NewDeque[T](initCap...)
+}
+
+// NewStack creates a new Stack with an optional initial capacity. See Stack.NewStack.
+func NewStack[T any](initCap ...int) *Stack.Stack[T] {
+	return Stack.NewStack[T](initCap...)
+}
+
+// NewQueue creates a new Queue configured by opts. See queue.NewQueue.
+func NewQueue[T any](opts ...queue.QueueOption) *queue.Queue[T] {
+	return queue.NewQueue[T](opts...)
+}
+
+// NewPriorityQueue creates a new PriorityQueue ordered by cmp with an optional initial capacity.
+// See PriorityQueue.NewPriorityQueue.
+func NewPriorityQueue[T any](cmp func(a, b T) int, initCap ...int) *PriorityQueue.PriorityQueue[T] {
+	return PriorityQueue.NewPriorityQueue(cmp, initCap...)
+}